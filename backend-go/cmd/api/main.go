@@ -12,6 +12,7 @@ import (
 	"backend-go/config"
 	"backend-go/internal/db"
 	"backend-go/internal/logger"
+	"backend-go/internal/utils"
 
 	// Kept for server setup usage if needed, wait.
 	_ "backend-go/docs" // Import generated docs
@@ -48,6 +49,10 @@ func main() {
 		println("Error loading config:", err.Error())
 		os.Exit(1)
 	}
+	if err := utils.ValidateNISPattern(config.AppConfig.NISPattern); err != nil {
+		println("Error loading config:", err.Error())
+		os.Exit(1)
+	}
 
 	// Init Logger
 	logger.Init()
@@ -80,6 +85,13 @@ func main() {
 		logger.Fatal("Failed to initialize API", zap.Error(err))
 	}
 
+	// Background jobs such as schedule reminders and the outbox worker that sends emails
+	workers, err := InitializeWorkers()
+	if err != nil {
+		logger.Fatal("Failed to initialize background workers", zap.Error(err))
+	}
+	workers.Start()
+
 	// Run Server
 	port := config.AppConfig.Port
 	if port == "" {
@@ -113,5 +125,13 @@ func main() {
 		logger.Fatal("Server forced to shutdown:", zap.Error(err))
 	}
 
+	// Once no more requests come in, let the outbox worker finish the jobs it is running.
+	// Anything cut off is retried by the next instance when its lease expires.
+	workersCtx, cancelWorkers := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancelWorkers()
+	if err := workers.Stop(workersCtx); err != nil {
+		logger.Error("Background workers forced to stop", zap.Error(err))
+	}
+
 	logger.Info("Server exiting")
 }
@@ -7,6 +7,7 @@ import (
 	"backend-go/config"
 	"backend-go/internal/api"
 	"backend-go/internal/handlers"
+	"backend-go/internal/middleware"
 	"backend-go/internal/repository"
 	"backend-go/internal/services"
 
@@ -22,8 +23,15 @@ func ProvideDB() *gorm.DB {
 var repositorySet = wire.NewSet(
 	ProvideDB,
 	repository.NewUserRepository,
+	repository.NewRecoveryCodeRepository,
 	repository.NewSantriRepository,
+	repository.NewAdmissionWaveRepository,
+	repository.NewSantriDocumentRepository,
+	repository.NewIssuedDocumentRepository,
+	repository.NewSelectionRepository,
+	repository.NewTestSessionRepository,
 	repository.NewArticleRepository,
+	repository.NewArticleRevisionRepository,
 	repository.NewGalleryRepository,
 	repository.NewMessageRepository,
 	repository.NewVideoRepository,
@@ -31,6 +39,13 @@ var repositorySet = wire.NewSet(
 	repository.NewCategoryRepository,
 	repository.NewTagRepository,
 	repository.NewActivityLogRepository,
+	repository.NewOutboxRepository,
+	repository.NewTxManager,
+	repository.NewNotificationDeliveryRepository,
+	repository.NewNotificationTemplateRepository,
+	repository.NewUserSessionRepository,
+	repository.NewRoleRepository,
+	repository.NewSearchRepository,
 )
 
 var serviceSet = wire.NewSet(
@@ -38,6 +53,13 @@ var serviceSet = wire.NewSet(
 	services.NewCacheService,
 	services.NewAuthService,
 	services.NewPSBService,
+	services.NewAdmissionWaveService,
+	services.NewSantriDocumentService,
+	services.NewIssuedDocumentService,
+	services.NewPDFService,
+	services.NewSantriDuplicateService,
+	services.NewSelectionService,
+	services.NewTestSessionService,
 	services.NewArticleService,
 	services.NewDashboardService,
 	services.NewGalleryService,
@@ -49,11 +71,26 @@ var serviceSet = wire.NewSet(
 	services.NewActivityLogService,
 	services.NewEmailService,
 	services.NewExportService,
+	services.NewOutboxService,
+	services.NewNotifiers,
+	services.NewNotificationService,
+	services.NewNotificationTemplateService,
+	services.NewSessionService,
+	services.NewRoleService,
+	services.NewSearchService,
+	services.NewFeedService,
+	wire.Bind(new(middleware.SessionValidator), new(services.SessionService)),
 )
 
 var handlerSet = wire.NewSet(
 	handlers.NewAuthHandler,
 	handlers.NewPSBHandler,
+	handlers.NewAdmissionWaveHandler,
+	handlers.NewPSBDocumentHandler,
+	handlers.NewIssuedDocumentHandler,
+	handlers.NewPSBDuplicateHandler,
+	handlers.NewSelectionHandler,
+	handlers.NewTestSessionHandler,
 	handlers.NewArticleHandler,
 	handlers.NewMediaHandler,
 	handlers.NewDashboardHandler,
@@ -67,6 +104,13 @@ var handlerSet = wire.NewSet(
 	handlers.NewActivityLogHandler,
 	handlers.NewExportHandler,
 	handlers.NewCleanupHandler,
+	handlers.NewOutboxHandler,
+	handlers.NewNotificationHandler,
+	handlers.NewNotificationTemplateHandler,
+	handlers.NewSessionHandler,
+	handlers.NewRoleHandler,
+	handlers.NewSearchHandler,
+	handlers.NewFeedHandler,
 )
 
 func InitializeAPI() (*gin.Engine, error) {
@@ -79,3 +123,14 @@ func InitializeAPI() (*gin.Engine, error) {
 	)
 	return nil, nil
 }
+
+func InitializeWorkers() (*Workers, error) {
+	wire.Build(
+		repositorySet,
+		serviceSet,
+		NewScheduler,
+		services.NewOutboxWorker,
+		wire.Struct(new(Workers), "*"),
+	)
+	return nil, nil
+}
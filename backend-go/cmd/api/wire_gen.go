@@ -10,6 +10,7 @@ import (
 	"backend-go/config"
 	"backend-go/internal/api"
 	"backend-go/internal/handlers"
+	"backend-go/internal/middleware"
 	"backend-go/internal/repository"
 	"backend-go/internal/services"
 
@@ -26,13 +27,41 @@ func InitializeAPI() (*gin.Engine, error) {
 	db := ProvideDB()
 	userRepository := repository.NewUserRepository(db)
 	cacheService := services.NewCacheService()
-	authService := services.NewAuthService(userRepository, cacheService)
+	txManager := repository.NewTxManager(db)
+	notificationDeliveryRepository := repository.NewNotificationDeliveryRepository(db)
+	outboxRepository := repository.NewOutboxRepository(db)
+	outboxService := services.NewOutboxService(outboxRepository, txManager)
+	emailService := services.NewEmailService()
+	notificationTemplateRepository := repository.NewNotificationTemplateRepository(db)
+	notificationTemplateService, err := services.NewNotificationTemplateService(notificationTemplateRepository, txManager)
+	if err != nil {
+		return nil, err
+	}
+	v := services.NewNotifiers(emailService)
+	notificationService := services.NewNotificationService(notificationDeliveryRepository, outboxService, notificationTemplateService, cacheService, v)
+	recoveryCodeRepository := repository.NewRecoveryCodeRepository(db)
+	userSessionRepository := repository.NewUserSessionRepository(db)
+	sessionService := services.NewSessionService(userSessionRepository, cacheService, txManager)
+	roleRepository := repository.NewRoleRepository(db)
+	authService := services.NewAuthService(userRepository, roleRepository, recoveryCodeRepository, sessionService, cacheService, txManager, notificationService)
 	authHandler := handlers.NewAuthHandler(authService)
 	santriRepository := repository.NewSantriRepository(db)
-	psbService := services.NewPSBService(santriRepository)
-	psbHandler := handlers.NewPSBHandler(psbService)
+	admissionWaveRepository := repository.NewAdmissionWaveRepository(db)
+	santriDocumentRepository := repository.NewSantriDocumentRepository(db)
+	testSessionRepository := repository.NewTestSessionRepository(db)
+	psbService := services.NewPSBService(santriRepository, admissionWaveRepository, santriDocumentRepository, testSessionRepository, txManager, notificationService)
+	issuedDocumentRepository := repository.NewIssuedDocumentRepository(db)
+	issuedDocumentService := services.NewIssuedDocumentService(issuedDocumentRepository, txManager)
+	pdfService, err := services.NewPDFService(issuedDocumentService)
+	if err != nil {
+		return nil, err
+	}
+	psbHandler := handlers.NewPSBHandler(psbService, pdfService)
+	admissionWaveService := services.NewAdmissionWaveService(admissionWaveRepository, santriRepository, txManager)
+	admissionWaveHandler := handlers.NewAdmissionWaveHandler(admissionWaveService)
 	articleRepository := repository.NewArticleRepository(db)
-	articleService := services.NewArticleService(articleRepository, cacheService)
+	articleRevisionRepository := repository.NewArticleRevisionRepository(db)
+	articleService := services.NewArticleService(articleRepository, articleRevisionRepository, cacheService, txManager)
 	articleHandler := handlers.NewArticleHandler(articleService)
 	mediaService, err := services.NewMediaService()
 	if err != nil {
@@ -42,58 +71,124 @@ func InitializeAPI() (*gin.Engine, error) {
 	dashboardService := services.NewDashboardService(santriRepository, articleRepository, userRepository)
 	dashboardHandler := handlers.NewDashboardHandler(dashboardService)
 	galleryRepository := repository.NewGalleryRepository(db)
-	galleryService := services.NewGalleryService(galleryRepository, mediaService)
+	galleryService := services.NewGalleryService(galleryRepository, mediaService, cacheService, txManager)
 	galleryHandler := handlers.NewGalleryHandler(galleryService)
 	messageRepository := repository.NewMessageRepository(db)
 	messageService := services.NewMessageService(messageRepository)
 	messageHandler := handlers.NewMessageHandler(messageService)
 	videoRepository := repository.NewVideoRepository(db)
-	videoService := services.NewVideoService(videoRepository)
+	videoService := services.NewVideoService(videoRepository, cacheService, txManager)
 	videoHandler := handlers.NewVideoHandler(videoService)
 	achievementRepository := repository.NewAchievementRepository(db)
-	achievementService := services.NewAchievementService(achievementRepository)
+	achievementService := services.NewAchievementService(achievementRepository, cacheService, txManager)
 	achievementHandler := handlers.NewAchievementHandler(achievementService)
 	healthHandler := handlers.NewHealthHandler()
 	categoryRepository := repository.NewCategoryRepository(db)
-	categoryService := services.NewCategoryService(categoryRepository)
+	categoryService := services.NewCategoryService(categoryRepository, txManager)
 	categoryHandler := handlers.NewCategoryHandler(categoryService)
 	tagRepository := repository.NewTagRepository(db)
-	tagService := services.NewTagService(tagRepository)
+	tagService := services.NewTagService(tagRepository, txManager)
 	tagHandler := handlers.NewTagHandler(tagService)
 	activityLogRepository := repository.NewActivityLogRepository(db)
 	activityLogService := services.NewActivityLogService(activityLogRepository)
 	activityLogHandler := handlers.NewActivityLogHandler(activityLogService)
-	emailService := services.NewEmailService()
 	exportService := services.NewExportService(santriRepository)
 	exportHandler := handlers.NewExportHandler(exportService)
 	cleanupHandler := handlers.NewCleanupHandler(mediaService)
+	santriDocumentService := services.NewSantriDocumentService(santriDocumentRepository, santriRepository, mediaService, txManager)
+	psbDocumentHandler := handlers.NewPSBDocumentHandler(santriDocumentService)
+	issuedDocumentHandler := handlers.NewIssuedDocumentHandler(issuedDocumentService)
+	santriDuplicateService := services.NewSantriDuplicateService(santriRepository, txManager)
+	psbDuplicateHandler := handlers.NewPSBDuplicateHandler(santriDuplicateService)
+	selectionRepository := repository.NewSelectionRepository(db)
+	selectionService := services.NewSelectionService(selectionRepository, admissionWaveRepository, santriRepository, txManager, notificationService)
+	selectionHandler := handlers.NewSelectionHandler(selectionService)
+	testSessionService := services.NewTestSessionService(testSessionRepository, admissionWaveRepository, txManager, notificationService)
+	testSessionHandler := handlers.NewTestSessionHandler(testSessionService)
+	outboxHandler := handlers.NewOutboxHandler(outboxService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	notificationTemplateHandler := handlers.NewNotificationTemplateHandler(notificationTemplateService)
+	sessionHandler := handlers.NewSessionHandler(sessionService)
+	roleService := services.NewRoleService(roleRepository, sessionService, txManager)
+	roleHandler := handlers.NewRoleHandler(roleService)
+	searchRepository := repository.NewSearchRepository(db)
+	searchService := services.NewSearchService(searchRepository, cacheService)
+	searchHandler := handlers.NewSearchHandler(searchService)
+	feedService := services.NewFeedService(articleRepository, categoryRepository, tagRepository, cacheService)
+	feedHandler := handlers.NewFeedHandler(feedService)
 
-	// Initialize global service helpers for async logging and email
-	services.SetActivityLogger(activityLogService)
-	services.SetEmailer(emailService)
+	// Initialize global service helpers for queued logging and media cleanup
+	services.SetOutbox(outboxService)
 	services.SetMediaCleaner(mediaService)
 
 	apiHandlers := api.Handlers{
-		AuthHandler:        authHandler,
-		PSBHandler:         psbHandler,
-		ArticleHandler:     articleHandler,
-		MediaHandler:       mediaHandler,
-		DashboardHandler:   dashboardHandler,
-		GalleryHandler:     galleryHandler,
-		MessageHandler:     messageHandler,
-		VideoHandler:       videoHandler,
-		AchievementHandler: achievementHandler,
-		HealthHandler:      healthHandler,
-		CategoryHandler:    categoryHandler,
-		TagHandler:         tagHandler,
-		ActivityLogHandler: activityLogHandler,
-		ExportHandler:      exportHandler,
-		CleanupHandler:     cleanupHandler,
+		AuthHandler:                 authHandler,
+		PSBHandler:                  psbHandler,
+		AdmissionWaveHandler:        admissionWaveHandler,
+		PSBDocumentHandler:          psbDocumentHandler,
+		PSBDuplicateHandler:         psbDuplicateHandler,
+		SelectionHandler:            selectionHandler,
+		TestSessionHandler:          testSessionHandler,
+		IssuedDocumentHandler:       issuedDocumentHandler,
+		ArticleHandler:              articleHandler,
+		MediaHandler:                mediaHandler,
+		DashboardHandler:            dashboardHandler,
+		GalleryHandler:              galleryHandler,
+		MessageHandler:              messageHandler,
+		VideoHandler:                videoHandler,
+		AchievementHandler:          achievementHandler,
+		HealthHandler:               healthHandler,
+		CategoryHandler:             categoryHandler,
+		TagHandler:                  tagHandler,
+		ActivityLogHandler:          activityLogHandler,
+		ExportHandler:               exportHandler,
+		CleanupHandler:              cleanupHandler,
+		OutboxHandler:               outboxHandler,
+		NotificationHandler:         notificationHandler,
+		NotificationTemplateHandler: notificationTemplateHandler,
+		SessionHandler:              sessionHandler,
+		RoleHandler:                 roleHandler,
+		SearchHandler:               searchHandler,
+		FeedHandler:                 feedHandler,
 	}
-	engine := api.NewRouter(apiHandlers)
+	engine := api.NewRouter(apiHandlers, sessionService)
 	return engine, nil
 }
 
+func InitializeWorkers() (*Workers, error) {
+	db := ProvideDB()
+	testSessionRepository := repository.NewTestSessionRepository(db)
+	admissionWaveRepository := repository.NewAdmissionWaveRepository(db)
+	txManager := repository.NewTxManager(db)
+	notificationDeliveryRepository := repository.NewNotificationDeliveryRepository(db)
+	outboxRepository := repository.NewOutboxRepository(db)
+	outboxService := services.NewOutboxService(outboxRepository, txManager)
+	emailService := services.NewEmailService()
+	notificationTemplateRepository := repository.NewNotificationTemplateRepository(db)
+	notificationTemplateService, err := services.NewNotificationTemplateService(notificationTemplateRepository, txManager)
+	if err != nil {
+		return nil, err
+	}
+	v := services.NewNotifiers(emailService)
+	cacheService := services.NewCacheService()
+	notificationService := services.NewNotificationService(notificationDeliveryRepository, outboxService, notificationTemplateService, cacheService, v)
+	testSessionService := services.NewTestSessionService(testSessionRepository, admissionWaveRepository, txManager, notificationService)
+	userSessionRepository := repository.NewUserSessionRepository(db)
+	sessionService := services.NewSessionService(userSessionRepository, cacheService, txManager)
+	articleRepository := repository.NewArticleRepository(db)
+	articleRevisionRepository := repository.NewArticleRevisionRepository(db)
+	articleService := services.NewArticleService(articleRepository, articleRevisionRepository, cacheService, txManager)
+	schedulerScheduler := NewScheduler(testSessionService, outboxService, sessionService, articleService)
+	activityLogRepository := repository.NewActivityLogRepository(db)
+	activityLogService := services.NewActivityLogService(activityLogRepository)
+	outboxWorker := services.NewOutboxWorker(outboxRepository, notificationService, activityLogService)
+	workers := &Workers{
+		Scheduler: schedulerScheduler,
+		Outbox:    outboxWorker,
+	}
+	return workers, nil
+}
+
 // wire.go:
 
 func ProvideDB() *gorm.DB {
@@ -101,9 +196,9 @@ func ProvideDB() *gorm.DB {
 }
 
 var repositorySet = wire.NewSet(
-	ProvideDB, repository.NewUserRepository, repository.NewSantriRepository, repository.NewArticleRepository, repository.NewGalleryRepository, repository.NewMessageRepository, repository.NewVideoRepository, repository.NewAchievementRepository, repository.NewCategoryRepository, repository.NewTagRepository, repository.NewActivityLogRepository,
+	ProvideDB, repository.NewUserRepository, repository.NewRecoveryCodeRepository, repository.NewSantriRepository, repository.NewAdmissionWaveRepository, repository.NewSantriDocumentRepository, repository.NewIssuedDocumentRepository, repository.NewSelectionRepository, repository.NewTestSessionRepository, repository.NewArticleRepository, repository.NewArticleRevisionRepository, repository.NewGalleryRepository, repository.NewMessageRepository, repository.NewVideoRepository, repository.NewAchievementRepository, repository.NewCategoryRepository, repository.NewTagRepository, repository.NewActivityLogRepository, repository.NewOutboxRepository, repository.NewTxManager, repository.NewNotificationDeliveryRepository, repository.NewNotificationTemplateRepository, repository.NewUserSessionRepository, repository.NewRoleRepository, repository.NewSearchRepository,
 )
 
-var serviceSet = wire.NewSet(services.NewMediaService, services.NewCacheService, services.NewAuthService, services.NewPSBService, services.NewArticleService, services.NewDashboardService, services.NewGalleryService, services.NewMessageService, services.NewVideoService, services.NewAchievementService, services.NewCategoryService, services.NewTagService, services.NewActivityLogService, services.NewEmailService, services.NewExportService)
+var serviceSet = wire.NewSet(services.NewMediaService, services.NewCacheService, services.NewAuthService, services.NewPSBService, services.NewAdmissionWaveService, services.NewSantriDocumentService, services.NewIssuedDocumentService, services.NewPDFService, services.NewSantriDuplicateService, services.NewSelectionService, services.NewTestSessionService, services.NewArticleService, services.NewDashboardService, services.NewGalleryService, services.NewMessageService, services.NewVideoService, services.NewAchievementService, services.NewCategoryService, services.NewTagService, services.NewActivityLogService, services.NewEmailService, services.NewExportService, services.NewOutboxService, services.NewNotifiers, services.NewNotificationService, services.NewNotificationTemplateService, services.NewSessionService, services.NewRoleService, services.NewSearchService, services.NewFeedService, wire.Bind(new(middleware.SessionValidator), new(services.SessionService)))
 
-var handlerSet = wire.NewSet(handlers.NewAuthHandler, handlers.NewPSBHandler, handlers.NewArticleHandler, handlers.NewMediaHandler, handlers.NewDashboardHandler, handlers.NewGalleryHandler, handlers.NewMessageHandler, handlers.NewVideoHandler, handlers.NewAchievementHandler, handlers.NewHealthHandler, handlers.NewCategoryHandler, handlers.NewTagHandler, handlers.NewActivityLogHandler, handlers.NewExportHandler, handlers.NewCleanupHandler)
+var handlerSet = wire.NewSet(handlers.NewAuthHandler, handlers.NewPSBHandler, handlers.NewAdmissionWaveHandler, handlers.NewPSBDocumentHandler, handlers.NewIssuedDocumentHandler, handlers.NewPSBDuplicateHandler, handlers.NewSelectionHandler, handlers.NewTestSessionHandler, handlers.NewArticleHandler, handlers.NewMediaHandler, handlers.NewDashboardHandler, handlers.NewGalleryHandler, handlers.NewMessageHandler, handlers.NewVideoHandler, handlers.NewAchievementHandler, handlers.NewHealthHandler, handlers.NewCategoryHandler, handlers.NewTagHandler, handlers.NewActivityLogHandler, handlers.NewExportHandler, handlers.NewCleanupHandler, handlers.NewOutboxHandler, handlers.NewNotificationHandler, handlers.NewNotificationTemplateHandler, handlers.NewSessionHandler, handlers.NewRoleHandler, handlers.NewSearchHandler, handlers.NewFeedHandler)
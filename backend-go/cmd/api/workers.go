@@ -0,0 +1,84 @@
+package main
+
+import (
+	"backend-go/internal/logger"
+	"backend-go/internal/scheduler"
+	"backend-go/internal/services"
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// How long completed outbox jobs, and sessions that ended, are kept before they are purged
+const (
+	outboxRetention  = 7 * 24 * time.Hour
+	sessionRetention = 30 * 24 * time.Hour
+)
+
+// Workers are the background processes that run next to the HTTP server
+type Workers struct {
+	Scheduler *scheduler.Scheduler
+	Outbox    *services.OutboxWorker
+}
+
+func (w *Workers) Start() {
+	w.Outbox.Start()
+	w.Scheduler.Start()
+}
+
+// Stop drains the outbox worker and stops the scheduler, giving up when ctx is done
+func (w *Workers) Stop(ctx context.Context) error {
+	return errors.Join(w.Outbox.Stop(ctx), w.Scheduler.Stop(ctx))
+}
+
+// NewScheduler registers the background jobs
+func NewScheduler(testSessions services.TestSessionService, outbox services.OutboxService, sessions services.SessionService, articles services.ArticleService) *scheduler.Scheduler {
+	return scheduler.New(
+		scheduler.Job{
+			Name:     "article-publish",
+			Interval: time.Minute,
+			Run: func(ctx context.Context) error {
+				published, err := articles.PublishScheduled(ctx, time.Now())
+				if published > 0 {
+					logger.Info("Scheduled articles published", zap.Int("count", published))
+				}
+				return err
+			},
+		},
+		scheduler.Job{
+			Name:     "test-session-reminders",
+			Interval: 15 * time.Minute,
+			Run: func(ctx context.Context) error {
+				sent, err := testSessions.SendDueReminders(ctx, time.Now())
+				if sent > 0 {
+					logger.Info("Test session reminders sent", zap.Int("count", sent))
+				}
+				return err
+			},
+		},
+		scheduler.Job{
+			Name:     "outbox-purge",
+			Interval: 6 * time.Hour,
+			Run: func(ctx context.Context) error {
+				purged, err := outbox.PurgeDone(ctx, outboxRetention)
+				if purged > 0 {
+					logger.Info("Completed outbox jobs purged", zap.Int64("count", purged))
+				}
+				return err
+			},
+		},
+		scheduler.Job{
+			Name:     "session-purge",
+			Interval: 24 * time.Hour,
+			Run: func(ctx context.Context) error {
+				purged, err := sessions.PurgeInactive(ctx, sessionRetention)
+				if purged > 0 {
+					logger.Info("Ended sessions purged", zap.Int64("count", purged))
+				}
+				return err
+			},
+		},
+	)
+}
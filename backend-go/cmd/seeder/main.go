@@ -41,9 +41,10 @@ func main() {
 	}
 
 	superAdmin := models.User{
-		Username: username,
-		Password: string(hashedPassword),
-		Role:     models.RoleSuperAdmin,
+		Username:           username,
+		Password:           string(hashedPassword),
+		Role:               models.RoleSuperAdmin,
+		MustChangePassword: true,
 	}
 
 	if err := db.Create(&superAdmin).Error; err != nil {
@@ -54,5 +55,6 @@ func main() {
 	fmt.Println("Super Admin Created Successfully!")
 	fmt.Printf("Username: %s\n", username)
 	fmt.Printf("Password: %s\n", password)
+	fmt.Println("You will be asked to change the password on first login.")
 	fmt.Println("------------------------------------------------")
 }
@@ -19,12 +19,32 @@ type Config struct {
 	Environment   string `mapstructure:"ENV"`
 	AllowedOrigin string `mapstructure:"ALLOWED_ORIGIN"`
 	CSRFSecret    string `mapstructure:"CSRF_SECRET" validate:"required"`
+	// Signs the verification tokens printed on generated PSB documents
+	DocumentSigningSecret string `mapstructure:"DOCUMENT_SIGNING_SECRET" validate:"required"`
+	// Public base URL of this API, used for links printed on generated documents
+	PublicAPIURL string `mapstructure:"PUBLIC_API_URL"`
+	// Base URL of the admin frontend, used for password reset links
+	FrontendURL string `mapstructure:"FRONTEND_URL"`
+	// Refuse super admins access to everything but 2FA enrollment until they have enabled TOTP
+	RequireSuperAdmin2FA bool `mapstructure:"REQUIRE_SUPER_ADMIN_2FA"`
+	// Failed logins allowed per username before the account is locked, and per IP address before it is blocked,
+	// within the lockout window. Both are counted in Redis.
+	LoginMaxAttempts      int `mapstructure:"LOGIN_MAX_ATTEMPTS"`
+	LoginMaxAttemptsPerIP int `mapstructure:"LOGIN_MAX_ATTEMPTS_PER_IP"`
+	LoginLockoutMinutes   int `mapstructure:"LOGIN_LOCKOUT_MINUTES"`
+	// Pattern for generated NIS numbers, e.g. {year}{gender}{seq:4}
+	NISPattern string `mapstructure:"NIS_PATTERN"`
 	// SMTP Configuration (optional)
 	SMTPHost string `mapstructure:"SMTP_HOST"`
 	SMTPPort int    `mapstructure:"SMTP_PORT"`
 	SMTPUser string `mapstructure:"SMTP_USER"`
 	SMTPPass string `mapstructure:"SMTP_PASS"`
 	SMTPFrom string `mapstructure:"SMTP_FROM"`
+	// HTTP gateways for WhatsApp and SMS notifications (optional). The token is sent as the Authorization header.
+	WhatsAppGatewayURL   string `mapstructure:"WHATSAPP_GATEWAY_URL"`
+	WhatsAppGatewayToken string `mapstructure:"WHATSAPP_GATEWAY_TOKEN"`
+	SMSGatewayURL        string `mapstructure:"SMS_GATEWAY_URL"`
+	SMSGatewayToken      string `mapstructure:"SMS_GATEWAY_TOKEN"`
 }
 
 var AppConfig Config
@@ -47,6 +67,18 @@ func LoadConfig() error {
 	// 4. Set Defaults
 	viper.SetDefault("PORT", "8080")
 	viper.SetDefault("ALLOWED_ORIGIN", "http://localhost:3000") // Default for local dev
+	viper.SetDefault("PUBLIC_API_URL", "http://localhost:8080/api")
+	viper.SetDefault("FRONTEND_URL", "http://localhost:3000")
+	viper.SetDefault("REQUIRE_SUPER_ADMIN_2FA", false)
+	viper.SetDefault("LOGIN_MAX_ATTEMPTS", 5)
+	viper.SetDefault("LOGIN_MAX_ATTEMPTS_PER_IP", 50)
+	viper.SetDefault("LOGIN_LOCKOUT_MINUTES", 15)
+	viper.SetDefault("DOCUMENT_SIGNING_SECRET", "")
+	viper.SetDefault("NIS_PATTERN", "{year}{gender}{seq:4}")
+	viper.SetDefault("WHATSAPP_GATEWAY_URL", "")
+	viper.SetDefault("WHATSAPP_GATEWAY_TOKEN", "")
+	viper.SetDefault("SMS_GATEWAY_URL", "")
+	viper.SetDefault("SMS_GATEWAY_TOKEN", "")
 
 	// 5. Unmarshal into Struct
 	if err := viper.Unmarshal(&AppConfig); err != nil {
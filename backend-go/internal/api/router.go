@@ -3,6 +3,8 @@ package api
 import (
 	"backend-go/internal/handlers"
 	"backend-go/internal/middleware"
+	"backend-go/internal/models"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -11,24 +13,37 @@ import (
 )
 
 type Handlers struct {
-	AuthHandler        *handlers.AuthHandler
-	PSBHandler         *handlers.PSBHandler
-	ArticleHandler     *handlers.ArticleHandler
-	MediaHandler       *handlers.MediaHandler
-	DashboardHandler   *handlers.DashboardHandler
-	GalleryHandler     *handlers.GalleryHandler
-	MessageHandler     *handlers.MessageHandler
-	VideoHandler       *handlers.VideoHandler
-	AchievementHandler *handlers.AchievementHandler
-	HealthHandler      *handlers.HealthHandler
-	CategoryHandler    *handlers.CategoryHandler
-	TagHandler         *handlers.TagHandler
-	ActivityLogHandler *handlers.ActivityLogHandler
-	ExportHandler      *handlers.ExportHandler
-	CleanupHandler     *handlers.CleanupHandler
+	AuthHandler                 *handlers.AuthHandler
+	PSBHandler                  *handlers.PSBHandler
+	AdmissionWaveHandler        *handlers.AdmissionWaveHandler
+	PSBDocumentHandler          *handlers.PSBDocumentHandler
+	PSBDuplicateHandler         *handlers.PSBDuplicateHandler
+	SelectionHandler            *handlers.SelectionHandler
+	TestSessionHandler          *handlers.TestSessionHandler
+	IssuedDocumentHandler       *handlers.IssuedDocumentHandler
+	ArticleHandler              *handlers.ArticleHandler
+	MediaHandler                *handlers.MediaHandler
+	DashboardHandler            *handlers.DashboardHandler
+	GalleryHandler              *handlers.GalleryHandler
+	MessageHandler              *handlers.MessageHandler
+	VideoHandler                *handlers.VideoHandler
+	AchievementHandler          *handlers.AchievementHandler
+	HealthHandler               *handlers.HealthHandler
+	CategoryHandler             *handlers.CategoryHandler
+	TagHandler                  *handlers.TagHandler
+	ActivityLogHandler          *handlers.ActivityLogHandler
+	ExportHandler               *handlers.ExportHandler
+	CleanupHandler              *handlers.CleanupHandler
+	OutboxHandler               *handlers.OutboxHandler
+	NotificationHandler         *handlers.NotificationHandler
+	NotificationTemplateHandler *handlers.NotificationTemplateHandler
+	SessionHandler              *handlers.SessionHandler
+	RoleHandler                 *handlers.RoleHandler
+	SearchHandler               *handlers.SearchHandler
+	FeedHandler                 *handlers.FeedHandler
 }
 
-func NewRouter(h Handlers) *gin.Engine {
+func NewRouter(h Handlers, sessions middleware.SessionValidator) *gin.Engine {
 	r := gin.New()
 	r.Use(gin.Recovery())
 	r.Use(middleware.RequestIDMiddleware()) // Must be first for request tracing
@@ -49,6 +64,10 @@ func NewRouter(h Handlers) *gin.Engine {
 	// Rate Limiters
 	loginLimiter := middleware.RateLimitMiddleware(1)
 	uploadLimiter := middleware.RateLimitMiddleware(0.5)
+	lookupLimiter := middleware.RateLimitMiddleware(0.2)
+
+	// Replays the stored response for retried requests with the same Idempotency-Key
+	idempotent := middleware.IdempotencyMiddleware(24 * time.Hour)
 
 	// Routes
 	api := r.Group("/api")
@@ -64,7 +83,25 @@ func NewRouter(h Handlers) *gin.Engine {
 		api.POST("/login", loginLimiter, h.AuthHandler.Login)
 		api.POST("/logout", h.AuthHandler.Logout)
 		api.POST("/refresh", h.AuthHandler.RefreshToken) // New refresh token endpoint
+		api.POST("/password/forgot", lookupLimiter, h.AuthHandler.ForgotPassword)
+		api.POST("/password/reset", loginLimiter, h.AuthHandler.ResetPassword)
+		api.POST("/login/2fa", loginLimiter, h.AuthHandler.VerifyTwoFactor)
+
+		// Account setup, outside the protected group, which refuses admins who still have to change their
+		// password or enroll in two-factor authentication
+		account := api.Group("/me")
+		account.Use(middleware.AccountSetupAuthMiddleware(sessions))
+		{
+			account.PUT("/password", h.AuthHandler.ChangePassword)
+			account.POST("/2fa/setup", h.AuthHandler.SetupTwoFactor)
+			account.POST("/2fa/enable", h.AuthHandler.EnableTwoFactor)
+		}
 		api.POST("/psb/register", h.PSBHandler.Register)
+		api.GET("/psb/status", lookupLimiter, h.PSBHandler.GetStatus)
+		api.GET("/psb/status/card", lookupLimiter, h.PSBHandler.DownloadPublicRegistrationCard)
+		api.GET("/psb/waves/open", h.AdmissionWaveHandler.GetOpen)
+		api.POST("/psb/documents", uploadLimiter, h.PSBDocumentHandler.Upload)
+		api.GET("/verify/:token", lookupLimiter, h.IssuedDocumentHandler.Verify)
 		api.GET("/articles", h.ArticleHandler.GetAll)
 		api.GET("/articles/search", h.ArticleHandler.Search)
 		api.GET("/articles/category", h.ArticleHandler.GetByCategory)
@@ -72,6 +109,14 @@ func NewRouter(h Handlers) *gin.Engine {
 		api.GET("/articles/:id", h.ArticleHandler.GetDetail)
 		api.GET("/articles/slug/:slug", h.ArticleHandler.GetDetailBySlug)
 
+		// Public Feed Routes
+		api.GET("/feeds/articles.rss", h.FeedHandler.ArticlesRSS)
+		api.GET("/feeds/articles.atom", h.FeedHandler.ArticlesAtom)
+		api.GET("/feeds/feed.json", h.FeedHandler.ArticlesJSON)
+		api.GET("/feeds/category/:slug", h.FeedHandler.Category)
+		api.GET("/feeds/tag/:slug", h.FeedHandler.Tag)
+
+		api.GET("/search", h.SearchHandler.Search)
 		api.POST("/contact", h.MessageHandler.SubmitMessage)
 
 		// Public Gallery Routes
@@ -92,80 +137,158 @@ func NewRouter(h Handlers) *gin.Engine {
 		api.GET("/tags", h.TagHandler.GetAll)
 		api.GET("/tags/:id", h.TagHandler.GetByID)
 
-		// Protected Routes
+		// Protected Routes. Each route requires a permission of the admin's role, carried in the access token.
 		protected := api.Group("/")
-		protected.Use(middleware.AuthMiddleware())
+		protected.Use(middleware.AuthMiddleware(sessions))
 		{
-			protected.POST("/upload", uploadLimiter, h.MediaHandler.Upload)
+			psbRead := middleware.RequirePermission(models.PermPSBRead)
+			psbUpdate := middleware.RequirePermission(models.PermPSBUpdate)
+			psbVerify := middleware.RequirePermission(models.PermPSBVerify)
+			psbManage := middleware.RequirePermission(models.PermPSBManage)
+			articleWrite := middleware.RequirePermission(models.PermArticleWrite)
+			mediaManage := middleware.RequirePermission(models.PermMediaManage)
+			adminManage := middleware.RequirePermission(models.PermAdminManage)
+			systemManage := middleware.RequirePermission(models.PermSystemManage)
+
+			protected.POST("/upload", middleware.RequirePermission(models.PermMediaManage, models.PermArticleWrite), uploadLimiter, h.MediaHandler.Upload)
+			protected.GET("/me/sessions", h.SessionHandler.GetMine)
+			protected.DELETE("/me/sessions", h.SessionHandler.RevokeOthers)
+			protected.DELETE("/me/sessions/:id", h.SessionHandler.Revoke)
+			protected.POST("/me/2fa/disable", h.AuthHandler.DisableTwoFactor)
+			protected.POST("/me/2fa/recovery-codes", h.AuthHandler.RegenerateRecoveryCodes)
+
+			protected.GET("/psb/registrants", psbRead, h.PSBHandler.GetAll)
+			protected.GET("/psb/registrants/:id", psbRead, h.PSBHandler.GetDetail)
+			protected.PUT("/psb/registrants/:id", psbUpdate, h.PSBHandler.Update)
+			protected.DELETE("/psb/registrants/:id", middleware.RequirePermission(models.PermPSBDelete), h.PSBHandler.Delete)
+			protected.PUT("/psb/registrants/:id/status", psbUpdate, h.PSBHandler.UpdateStatus)
+			protected.PUT("/psb/registrants/:id/verify", psbVerify, idempotent, h.PSBHandler.Verify)
+			protected.GET("/psb/registrants/:id/history", psbRead, h.PSBHandler.GetStatusHistory)
+			protected.GET("/psb/registrants/:id/notifications", psbRead, h.NotificationHandler.GetByRegistrant)
+			protected.GET("/psb/registrants/:id/possible-duplicates", psbRead, h.PSBDuplicateHandler.GetPossibleDuplicates)
+			protected.POST("/psb/registrants/:id/merge", psbUpdate, h.PSBDuplicateHandler.Merge)
+			protected.GET("/psb/registrants/:id/registration-card", psbRead, h.PSBHandler.DownloadRegistrationCard)
+			protected.GET("/psb/registrants/:id/acceptance-letter", psbRead, h.PSBHandler.DownloadAcceptanceLetter)
+			protected.GET("/psb/registrants/:id/documents", psbRead, h.PSBDocumentHandler.GetByRegistrant)
+			protected.PUT("/psb/documents/:id/verify", psbVerify, h.PSBDocumentHandler.Verify)
+			protected.GET("/psb/registrants/:id/issued-documents", psbRead, h.IssuedDocumentHandler.GetByRegistrant)
+			protected.PUT("/psb/issued-documents/:id/revoke", psbVerify, h.IssuedDocumentHandler.Revoke)
+
+			// Admission Wave Routes
+			protected.GET("/psb/waves", psbRead, h.AdmissionWaveHandler.GetAll)
+			protected.GET("/psb/waves/:id", psbRead, h.AdmissionWaveHandler.GetByID)
+			protected.POST("/psb/waves", psbManage, h.AdmissionWaveHandler.Create)
+			protected.PUT("/psb/waves/:id", psbManage, h.AdmissionWaveHandler.Update)
+			protected.DELETE("/psb/waves/:id", psbManage, h.AdmissionWaveHandler.Delete)
 
-			protected.GET("/psb/registrants", h.PSBHandler.GetAll)
-			protected.GET("/psb/registrants/:id", h.PSBHandler.GetDetail)
-			protected.PUT("/psb/registrants/:id", h.PSBHandler.Update)
-			protected.DELETE("/psb/registrants/:id", h.PSBHandler.Delete)
-			protected.PUT("/psb/registrants/:id/status", h.PSBHandler.UpdateStatus)
-			protected.PUT("/psb/registrants/:id/verify", h.PSBHandler.Verify)
+			// Selection Routes
+			protected.GET("/psb/waves/:id/rubric", psbRead, h.SelectionHandler.GetRubric)
+			protected.PUT("/psb/waves/:id/rubric", psbManage, h.SelectionHandler.UpdateRubric)
+			protected.GET("/psb/waves/:id/ranking", psbRead, h.SelectionHandler.GetRanking)
+			protected.POST("/psb/waves/:id/selection", psbManage, idempotent, h.SelectionHandler.RunSelection)
+			protected.GET("/psb/registrants/:id/scores", psbRead, h.SelectionHandler.GetScores)
+			protected.PUT("/psb/registrants/:id/scores", psbUpdate, h.SelectionHandler.UpdateScores)
+
+			// Test Session Routes
+			protected.GET("/psb/test-sessions", psbRead, h.TestSessionHandler.GetAll)
+			protected.GET("/psb/test-sessions/:id", psbRead, h.TestSessionHandler.GetByID)
+			protected.POST("/psb/test-sessions", psbManage, h.TestSessionHandler.Create)
+			protected.PUT("/psb/test-sessions/:id", psbManage, h.TestSessionHandler.Update)
+			protected.DELETE("/psb/test-sessions/:id", psbManage, h.TestSessionHandler.Delete)
+			protected.GET("/psb/test-sessions/:id/calendar.ics", psbRead, h.TestSessionHandler.ExportCalendar)
+			protected.POST("/psb/test-sessions/:id/assignments", psbUpdate, h.TestSessionHandler.Assign)
+			protected.DELETE("/psb/test-sessions/:id/assignments/:santri_id", psbUpdate, h.TestSessionHandler.Unassign)
+			protected.POST("/psb/waves/:id/test-sessions/assign", psbManage, h.TestSessionHandler.AutoAssign)
+
+			// Notification Template Routes
+			protected.GET("/notification-templates", psbManage, h.NotificationTemplateHandler.GetAll)
+			protected.GET("/notification-templates/:event/:locale", psbManage, h.NotificationTemplateHandler.GetByKey)
+			protected.PUT("/notification-templates/:event/:locale", psbManage, h.NotificationTemplateHandler.Update)
+			protected.DELETE("/notification-templates/:event/:locale", psbManage, h.NotificationTemplateHandler.Reset)
+			protected.POST("/notification-templates/:event/:locale/preview", psbManage, h.NotificationTemplateHandler.Preview)
 
 			// Dashboard Routes
-			protected.GET("/dashboard/stats", h.DashboardHandler.GetStats)
+			protected.GET("/dashboard/stats", middleware.RequirePermission(models.PermDashboardRead), h.DashboardHandler.GetStats)
 
 			// Message Routes
-			protected.GET("/messages", h.MessageHandler.GetAllMessages)
-			protected.PUT("/messages/:id/read", h.MessageHandler.MarkAsRead)
-			protected.DELETE("/messages/:id", h.MessageHandler.DeleteMessage)
+			protected.GET("/messages", middleware.RequirePermission(models.PermMessageRead), h.MessageHandler.GetAllMessages)
+			protected.PUT("/messages/:id/read", middleware.RequirePermission(models.PermMessageRead), h.MessageHandler.MarkAsRead)
+			protected.DELETE("/messages/:id", middleware.RequirePermission(models.PermMessageDelete), h.MessageHandler.DeleteMessage)
 
-			// CMS Routes
-			protected.POST("/articles", h.ArticleHandler.Create)
-			protected.PUT("/articles/:id", h.ArticleHandler.Update)
-			protected.DELETE("/articles/:id", h.ArticleHandler.Delete)
+			// CMS Routes. Publishing, scheduling and unpublishing additionally require article.publish, checked by the handler.
+			protected.GET("/articles/manage", articleWrite, h.ArticleHandler.GetManaged)
+			protected.GET("/articles/manage/:id", articleWrite, h.ArticleHandler.GetManagedDetail)
+			protected.POST("/articles", articleWrite, h.ArticleHandler.Create)
+			protected.PUT("/articles/:id", articleWrite, h.ArticleHandler.Update)
+			protected.DELETE("/articles/:id", middleware.RequirePermission(models.PermArticleDelete), h.ArticleHandler.Delete)
+			protected.GET("/articles/:id/revisions", articleWrite, h.ArticleHandler.GetRevisions)
+			protected.GET("/articles/:id/revisions/diff", articleWrite, h.ArticleHandler.DiffRevisions)
+			protected.GET("/articles/:id/revisions/:rev", articleWrite, h.ArticleHandler.GetRevision)
+			protected.POST("/articles/:id/revisions/:rev/restore", articleWrite, h.ArticleHandler.RestoreRevision)
 
 			// Gallery Routes (Admin Management)
-			protected.POST("/galleries", h.GalleryHandler.Create)
-			protected.PUT("/galleries/:id", h.GalleryHandler.Update)
-			protected.DELETE("/galleries/:id", h.GalleryHandler.Delete)
-			protected.POST("/galleries/:id/photos", h.GalleryHandler.UploadPhotos)
-			protected.DELETE("/galleries/photos/:photo_id", h.GalleryHandler.DeletePhoto)
+			protected.POST("/galleries", mediaManage, h.GalleryHandler.Create)
+			protected.PUT("/galleries/:id", mediaManage, h.GalleryHandler.Update)
+			protected.DELETE("/galleries/:id", mediaManage, h.GalleryHandler.Delete)
+			protected.POST("/galleries/:id/photos", mediaManage, h.GalleryHandler.UploadPhotos)
+			protected.DELETE("/galleries/photos/:photo_id", mediaManage, h.GalleryHandler.DeletePhoto)
 
 			// Video Routes
-			protected.GET("/videos/:id", h.VideoHandler.GetByID)
-			protected.POST("/videos", h.VideoHandler.Create)
-			protected.PUT("/videos/:id", h.VideoHandler.Update)
-			protected.DELETE("/videos/:id", h.VideoHandler.Delete)
+			protected.GET("/videos/:id", mediaManage, h.VideoHandler.GetByID)
+			protected.POST("/videos", mediaManage, h.VideoHandler.Create)
+			protected.PUT("/videos/:id", mediaManage, h.VideoHandler.Update)
+			protected.DELETE("/videos/:id", mediaManage, h.VideoHandler.Delete)
 
 			// Achievement Routes
-			protected.POST("/achievements", h.AchievementHandler.Create)
-			protected.PUT("/achievements/:id", h.AchievementHandler.Update)
-			protected.DELETE("/achievements/:id", h.AchievementHandler.Delete)
+			protected.POST("/achievements", mediaManage, h.AchievementHandler.Create)
+			protected.PUT("/achievements/:id", mediaManage, h.AchievementHandler.Update)
+			protected.DELETE("/achievements/:id", mediaManage, h.AchievementHandler.Delete)
 
 			// Category Routes (Admin)
-			protected.POST("/categories", h.CategoryHandler.Create)
-			protected.PUT("/categories/:id", h.CategoryHandler.Update)
-			protected.DELETE("/categories/:id", h.CategoryHandler.Delete)
+			protected.POST("/categories", articleWrite, h.CategoryHandler.Create)
+			protected.PUT("/categories/:id", articleWrite, h.CategoryHandler.Update)
+			protected.DELETE("/categories/:id", articleWrite, h.CategoryHandler.Delete)
 
 			// Tag Routes (Admin)
-			protected.POST("/tags", h.TagHandler.Create)
-			protected.PUT("/tags/:id", h.TagHandler.Update)
-			protected.DELETE("/tags/:id", h.TagHandler.Delete)
-
-			// Super Admin Routes
-			superAdmin := protected.Group("/")
-			superAdmin.Use(middleware.RBACMiddleware("super_admin"))
-			{
-				superAdmin.POST("/admins", h.AuthHandler.CreateAdmin)
-				superAdmin.GET("/admins", h.AuthHandler.GetAllAdmins)
-				superAdmin.DELETE("/admins/:id", h.AuthHandler.DeleteAdmin)
-				superAdmin.PUT("/admins/:id/password", h.AuthHandler.UpdateAdminPassword)
-
-				// Activity Log Routes (Super Admin)
-				superAdmin.GET("/activity-logs", h.ActivityLogHandler.GetAll)
-				superAdmin.GET("/activity-logs/:entity_type/:entity_id", h.ActivityLogHandler.GetByEntity)
-
-				// Export Routes (Super Admin)
-				superAdmin.GET("/export/santri/excel", h.ExportHandler.ExportSantriExcel)
-
-				// Cleanup Routes (Super Admin)
-				superAdmin.GET("/cleanup/cloudinary/usage", h.CleanupHandler.GetCloudinaryUsage)
-				superAdmin.POST("/cleanup/cloudinary/delete", h.CleanupHandler.DeleteImageByURL)
-			}
+			protected.POST("/tags", articleWrite, h.TagHandler.Create)
+			protected.PUT("/tags/:id", articleWrite, h.TagHandler.Update)
+			protected.DELETE("/tags/:id", articleWrite, h.TagHandler.Delete)
+
+			// Admin Management Routes
+			protected.POST("/admins", adminManage, h.AuthHandler.CreateAdmin)
+			protected.GET("/admins", adminManage, h.AuthHandler.GetAllAdmins)
+			protected.DELETE("/admins/:id", adminManage, h.AuthHandler.DeleteAdmin)
+			protected.PUT("/admins/:id/password", adminManage, h.AuthHandler.UpdateAdminPassword)
+			protected.PUT("/admins/:id/email", adminManage, h.AuthHandler.UpdateAdminEmail)
+			protected.PUT("/admins/:id/role", adminManage, h.AuthHandler.UpdateAdminRole)
+			protected.DELETE("/admins/:id/2fa", adminManage, h.AuthHandler.ResetTwoFactor)
+			protected.DELETE("/admins/:id/lockout", adminManage, h.AuthHandler.UnlockAdmin)
+			protected.DELETE("/admins/:id/sessions", adminManage, h.SessionHandler.RevokeAllForUser)
+
+			// Role Routes
+			protected.GET("/roles", adminManage, h.RoleHandler.GetAll)
+			protected.GET("/roles/:id", adminManage, h.RoleHandler.GetByID)
+			protected.POST("/roles", adminManage, h.RoleHandler.Create)
+			protected.PUT("/roles/:id", adminManage, h.RoleHandler.Update)
+			protected.DELETE("/roles/:id", adminManage, h.RoleHandler.Delete)
+			protected.GET("/permissions", adminManage, h.RoleHandler.GetPermissions)
+
+			// Activity Log Routes
+			protected.GET("/activity-logs", middleware.RequirePermission(models.PermActivityRead), h.ActivityLogHandler.GetAll)
+			protected.GET("/activity-logs/:entity_type/:entity_id", middleware.RequirePermission(models.PermActivityRead), h.ActivityLogHandler.GetByEntity)
+
+			// Export Routes
+			protected.GET("/export/santri/excel", middleware.RequirePermission(models.PermPSBExport), h.ExportHandler.ExportSantriExcel)
+
+			// Cleanup Routes
+			protected.GET("/cleanup/cloudinary/usage", systemManage, h.CleanupHandler.GetCloudinaryUsage)
+			protected.POST("/cleanup/cloudinary/delete", systemManage, h.CleanupHandler.DeleteImageByURL)
+
+			// Outbox Routes
+			protected.GET("/outbox/jobs", systemManage, h.OutboxHandler.GetAll)
+			protected.POST("/outbox/jobs/replay-dead", systemManage, h.OutboxHandler.ReplayDead)
+			protected.GET("/outbox/jobs/:id", systemManage, h.OutboxHandler.GetByID)
+			protected.POST("/outbox/jobs/:id/replay", systemManage, h.OutboxHandler.Replay)
 		}
 	}
 
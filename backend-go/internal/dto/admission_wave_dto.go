@@ -0,0 +1,25 @@
+package dto
+
+// CreateAdmissionWaveRequest is the DTO for creating an admission wave (gelombang)
+type CreateAdmissionWaveRequest struct {
+	Name            string `json:"name" binding:"required,min=3,max=100"`
+	AcademicYear    string `json:"academic_year" binding:"required,len=9"` // Format: 2025/2026
+	StartDate       string `json:"start_date" binding:"required"`          // Format: YYYY-MM-DD
+	EndDate         string `json:"end_date" binding:"required"`            // Format: YYYY-MM-DD
+	QuotaMale       int    `json:"quota_male" binding:"min=0"`             // 0 means unlimited
+	QuotaFemale     int    `json:"quota_female" binding:"min=0"`           // 0 means unlimited
+	RegistrationFee int64  `json:"registration_fee" binding:"min=0"`
+	IsActive        *bool  `json:"is_active"` // Defaults to true
+}
+
+// UpdateAdmissionWaveRequest is the DTO for replacing an admission wave's settings
+type UpdateAdmissionWaveRequest struct {
+	Name            string `json:"name" binding:"required,min=3,max=100"`
+	AcademicYear    string `json:"academic_year" binding:"required,len=9"`
+	StartDate       string `json:"start_date" binding:"required"`
+	EndDate         string `json:"end_date" binding:"required"`
+	QuotaMale       int    `json:"quota_male" binding:"min=0"`
+	QuotaFemale     int    `json:"quota_female" binding:"min=0"`
+	RegistrationFee int64  `json:"registration_fee" binding:"min=0"`
+	IsActive        *bool  `json:"is_active" binding:"required"`
+}
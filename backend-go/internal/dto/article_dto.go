@@ -1,17 +1,25 @@
 package dto
 
-// CreateArticleRequest is the DTO for creating a new article
+import "time"
+
+// CreateArticleRequest is the DTO for creating a new article. IsPublished is the older way to publish and is
+// only used when Status is empty.
 type CreateArticleRequest struct {
-	Title        string `json:"title" binding:"required,min=3,max=200"`
-	Content      string `json:"content" binding:"required,min=10"`
-	ThumbnailURL string `json:"thumbnail_url" binding:"omitempty,url"`
-	IsPublished  bool   `json:"is_published"`
+	Title        string     `json:"title" binding:"required,min=3,max=200"`
+	Content      string     `json:"content" binding:"required,min=10"`
+	ThumbnailURL string     `json:"thumbnail_url" binding:"omitempty,url"`
+	IsPublished  bool       `json:"is_published"`
+	Status       string     `json:"status" binding:"omitempty,oneof=draft in_review scheduled published archived"`
+	PublishAt    *time.Time `json:"publish_at"`
 }
 
-// UpdateArticleRequest is the DTO for updating an existing article
+// UpdateArticleRequest is the DTO for updating an existing article. An empty Status and a nil IsPublished keep
+// the current status.
 type UpdateArticleRequest struct {
-	Title        string `json:"title" binding:"omitempty,min=3,max=200"`
-	Content      string `json:"content" binding:"omitempty,min=10"`
-	ThumbnailURL string `json:"thumbnail_url" binding:"omitempty,url"`
-	IsPublished  *bool  `json:"is_published"`
+	Title        string     `json:"title" binding:"omitempty,min=3,max=200"`
+	Content      string     `json:"content" binding:"omitempty,min=10"`
+	ThumbnailURL string     `json:"thumbnail_url" binding:"omitempty,url"`
+	IsPublished  *bool      `json:"is_published"`
+	Status       string     `json:"status" binding:"omitempty,oneof=draft in_review scheduled published archived"`
+	PublishAt    *time.Time `json:"publish_at"`
 }
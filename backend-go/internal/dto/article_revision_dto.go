@@ -0,0 +1,14 @@
+package dto
+
+import "backend-go/internal/utils"
+
+// ArticleRevisionDiff is the word-level difference between two revisions of an article
+type ArticleRevisionDiff struct {
+	ArticleID        uint                `json:"article_id"`
+	From             int                 `json:"from"`
+	To               int                 `json:"to"`
+	Title            []utils.DiffSegment `json:"title"`
+	Content          []utils.DiffSegment `json:"content"`
+	ThumbnailChanged bool                `json:"thumbnail_changed"`
+	StatusChanged    bool                `json:"status_changed"`
+}
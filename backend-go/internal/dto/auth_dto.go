@@ -10,7 +10,9 @@ type LoginRequest struct {
 type CreateAdminRequest struct {
 	Username string `json:"username" binding:"required,min=2,max=50"`
 	Password string `json:"password" binding:"required,min=8"`
+	Email    string `json:"email" binding:"omitempty,email,max=255"`
 	Name     string `json:"name" binding:"omitempty,max=100"`
+	Role     string `json:"role" binding:"omitempty,max=50"` // defaults to admin
 }
 
 // RefreshTokenRequest is the DTO for refreshing access token
@@ -20,9 +22,13 @@ type RefreshTokenRequest struct {
 
 // UserDTO for basic user info response
 type UserDTO struct {
-	ID       uint   `json:"id"`
-	Username string `json:"username"`
-	Role     string `json:"role"`
+	ID                     uint     `json:"id"`
+	Username               string   `json:"username"`
+	Role                   string   `json:"role"`
+	Permissions            []string `json:"permissions"`
+	MustChangePassword     bool     `json:"must_change_password"`
+	TwoFactorEnabled       bool     `json:"two_factor_enabled"`
+	TwoFactorSetupRequired bool     `json:"two_factor_setup_required"`
 }
 
 // TokenPair contains tokens and user info
@@ -33,7 +39,74 @@ type TokenPair struct {
 	User         UserDTO `json:"user"`
 }
 
+// LoginResponse is the result of a password login. Accounts with two-factor authentication get a challenge token,
+// to be completed at /login/2fa, instead of the token pair.
+type LoginResponse struct {
+	*TokenPair
+	TwoFactorRequired  bool   `json:"two_factor_required"`
+	ChallengeToken     string `json:"challenge_token,omitempty"`
+	ChallengeExpiresIn int64  `json:"challenge_expires_in,omitempty"` // Challenge expiry in seconds
+}
+
+// TwoFactorLoginRequest is the DTO for completing a login with a TOTP or recovery code
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required,max=20"`
+}
+
 // ChangePasswordRequest is the DTO for changing admin password
 type ChangePasswordRequest struct {
 	Password string `json:"password" binding:"required,min=8"`
 }
+
+// UpdateAdminEmailRequest is the DTO for setting an admin's email address. An empty email removes it.
+type UpdateAdminEmailRequest struct {
+	Email string `json:"email" binding:"omitempty,email,max=255"`
+}
+
+// ChangeOwnPasswordRequest is the DTO for an admin changing their own password
+type ChangeOwnPasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8"`
+}
+
+// ForgotPasswordRequest is the DTO for requesting a password reset link
+type ForgotPasswordRequest struct {
+	Email  string `json:"email" binding:"required,email"`
+	Locale string `json:"locale" binding:"omitempty,oneof=id en"`
+}
+
+// ResetPasswordRequest is the DTO for setting a new password with a reset token
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// TwoFactorSetupResponse holds a new TOTP secret to add to an authenticator app
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURL string `json:"otpauth_url"`
+	QRCode     string `json:"qr_code"` // PNG data URI of OTPAuthURL
+}
+
+// TwoFactorCodeRequest is the DTO for confirming an action with a TOTP or recovery code
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required,max=20"`
+}
+
+// DisableTwoFactorRequest is the DTO for turning two-factor authentication off
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required,max=20"`
+}
+
+// RecoveryCodesResponse lists recovery codes. They are only shown once.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorEnabledResponse carries the recovery codes and new tokens after enrollment
+type TwoFactorEnabledResponse struct {
+	*TokenPair
+	RecoveryCodes []string `json:"recovery_codes"`
+}
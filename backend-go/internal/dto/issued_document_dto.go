@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+// RevokeIssuedDocumentRequest is the DTO for revoking a generated PSB document
+type RevokeIssuedDocumentRequest struct {
+	Reason string `json:"reason" binding:"required,min=3,max=500"`
+}
+
+// DocumentVerificationResponse is the public result of scanning the QR code on a PSB document
+type DocumentVerificationResponse struct {
+	Valid        bool       `json:"valid"`
+	Status       string     `json:"status"` // VALID or REVOKED
+	DocumentType string     `json:"document_type"`
+	FullName     string     `json:"full_name,omitempty"`
+	NIS          string     `json:"nis,omitempty"`
+	IssuedAt     time.Time  `json:"issued_at"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// UpdateNotificationTemplateRequest is the DTO for overriding a notification template.
+// Subject and text are Go text templates; the HTML body is an HTML template that escapes its data.
+type UpdateNotificationTemplateRequest struct {
+	Subject  string `json:"subject" binding:"required,max=255"`
+	TextBody string `json:"text_body" binding:"required,max=10000"`
+	HTMLBody string `json:"html_body" binding:"required,max=50000"`
+}
+
+// PreviewNotificationTemplateRequest renders a draft with sample data. Empty parts use the current template.
+type PreviewNotificationTemplateRequest struct {
+	Subject  string `json:"subject" binding:"omitempty,max=255"`
+	TextBody string `json:"text_body" binding:"omitempty,max=10000"`
+	HTMLBody string `json:"html_body" binding:"omitempty,max=50000"`
+}
+
+// NotificationTemplateResponse is the template used for an event and locale
+type NotificationTemplateResponse struct {
+	Event      string     `json:"event"`
+	Locale     string     `json:"locale"`
+	Subject    string     `json:"subject"`
+	TextBody   string     `json:"text_body"`
+	HTMLBody   string     `json:"html_body"`
+	Fields     []string   `json:"fields"`     // Data available to the template, e.g. SantriName
+	Customized bool       `json:"customized"` // False while the built-in template is used
+	ReadOnly   bool       `json:"read_only"`  // Account emails with a password link always use the built-in template
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// NotificationPreviewResponse is a template rendered with sample data
+type NotificationPreviewResponse struct {
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	HTML    string `json:"html"`
+}
@@ -1,5 +1,11 @@
 package dto
 
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/utils"
+	"time"
+)
+
 // RegisterSantriRequest is the DTO for PSB registration
 type RegisterSantriRequest struct {
 	// Santri Data
@@ -16,6 +22,8 @@ type RegisterSantriRequest struct {
 	MotherName  string `json:"mother_name" binding:"required,min=3,max=100"`
 	MotherJob   string `json:"mother_job" binding:"required,min=2,max=100"`
 	ParentPhone string `json:"parent_phone" binding:"required,min=10,max=15"`
+	ParentEmail string `json:"parent_email" binding:"omitempty,email,max=100"`
+	Locale      string `json:"locale" binding:"omitempty,oneof=id en"` // Language of notifications, defaults to id
 
 	// Education Data
 	SchoolOrigin  string `json:"school_origin" binding:"required,min=3,max=100"`
@@ -29,11 +37,11 @@ type RegisterSantriRequest struct {
 // UpdateSantriStatusRequest is the DTO for updating santri status
 type UpdateSantriStatusRequest struct {
 	Status string `json:"status" binding:"required,oneof=PENDING VERIFIED ACCEPTED REJECTED"`
+	Reason string `json:"reason" binding:"omitempty,max=500"`
 }
 
-// VerifySantriRequest is the DTO for verifying and accepting a santri
+// VerifySantriRequest is the DTO for verifying and accepting a santri. The NIS is generated from NIS_PATTERN.
 type VerifySantriRequest struct {
-	NIS       string `json:"nis" binding:"required,min=5,max=20"`
 	Class     string `json:"class" binding:"required,min=1,max=10"`
 	EntryYear int    `json:"entry_year" binding:"required,min=2000,max=2100"`
 }
@@ -48,7 +56,26 @@ type UpdateSantriRequest struct {
 	Address     string `json:"address" binding:"omitempty,min=10,max=500"`
 	ParentName  string `json:"parent_name" binding:"omitempty,min=3,max=100"`
 	ParentPhone string `json:"parent_phone" binding:"omitempty,min=10,max=15"`
+	ParentEmail string `json:"parent_email" binding:"omitempty,email,max=100"`
+	Locale      string `json:"locale" binding:"omitempty,oneof=id en"`
 	PhotoURL    string `json:"photo_url" binding:"omitempty,url"`
+
+	// Parent Data
+	FatherName string `json:"father_name" binding:"omitempty,min=3,max=100"`
+	FatherJob  string `json:"father_job" binding:"omitempty,min=2,max=100"`
+	MotherName string `json:"mother_name" binding:"omitempty,min=3,max=100"`
+	MotherJob  string `json:"mother_job" binding:"omitempty,min=2,max=100"`
+
+	// Education Data
+	SchoolOrigin   string `json:"school_origin" binding:"omitempty,min=3,max=100"`
+	SchoolAddress  string `json:"school_address" binding:"omitempty,min=10,max=500"`
+	GraduationYear string `json:"graduation_year" binding:"omitempty,len=4,numeric"`
+}
+
+// SantriDetailResponse is the admin detail view of a registrant with data consistency warnings
+type SantriDetailResponse struct {
+	*models.Santri
+	Warnings []utils.FieldError `json:"warnings"`
 }
 
 // PaginationMeta contains pagination metadata
@@ -64,3 +91,52 @@ type PaginatedSantriResponse struct {
 	Items []interface{}  `json:"items"`
 	Meta  PaginationMeta `json:"meta"`
 }
+
+// PSBStatusQuery is the query for the public registration status lookup
+type PSBStatusQuery struct {
+	Code      string `form:"code" binding:"required,min=6,max=20"`
+	BirthDate string `form:"birth_date" binding:"required"` // Format: YYYY-MM-DD
+}
+
+// PSBStatusResponse is the public view of a registration. It deliberately leaves out NIK, address and contact data.
+type PSBStatusResponse struct {
+	RegistrationCode     string              `json:"registration_code"`
+	FullName             string              `json:"full_name"`
+	Status               string              `json:"status"`
+	Wave                 *PSBStatusWave      `json:"wave,omitempty"`
+	Documents            []PSBStatusDocument `json:"documents"`
+	OutstandingDocuments []string            `json:"outstanding_documents"`
+	Notes                []PSBStatusNote     `json:"notes"`
+	Schedule             []PSBStatusSchedule `json:"schedule"`
+	RegisteredAt         time.Time           `json:"registered_at"`
+	UpdatedAt            time.Time           `json:"updated_at"`
+}
+
+// PSBStatusWave is the admission wave summary shown in the status lookup
+type PSBStatusWave struct {
+	Name         string `json:"name"`
+	AcademicYear string `json:"academic_year"`
+}
+
+// PSBStatusDocument is the verification state of one uploaded document
+type PSBStatusDocument struct {
+	Type   string `json:"type"`
+	Status string `json:"status"`
+	Note   string `json:"note,omitempty"`
+}
+
+// PSBStatusNote is a note left by the admissions team when changing the registration status
+type PSBStatusNote struct {
+	Status    string    `json:"status"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PSBStatusSchedule is an entrance test or interview the registrant is scheduled for
+type PSBStatusSchedule struct {
+	Kind     string    `json:"kind"`
+	Name     string    `json:"name"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Room     string    `json:"room"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+// CreateRoleRequest is the DTO for creating a role. The name is lowercase letters, digits and underscores.
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required,min=2,max=50"`
+	Description string   `json:"description" binding:"omitempty,max=255"`
+	Permissions []string `json:"permissions" binding:"dive,max=50"`
+}
+
+// UpdateRoleRequest is the DTO for updating a role. The permissions replace the current ones.
+type UpdateRoleRequest struct {
+	Description string   `json:"description" binding:"omitempty,max=255"`
+	Permissions []string `json:"permissions" binding:"required,dive,max=50"`
+}
+
+// UpdateAdminRoleRequest is the DTO for assigning a role to an admin
+type UpdateAdminRoleRequest struct {
+	Role string `json:"role" binding:"required,max=50"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+// UploadSantriDocumentRequest is the multipart form for a registrant uploading a PSB document.
+// The registration is identified by its code and birth date, as in the status lookup; the file itself is sent as "file".
+type UploadSantriDocumentRequest struct {
+	RegistrationCode string `form:"registration_code" binding:"required,min=6,max=20"`
+	BirthDate        string `form:"birth_date" binding:"required"` // Format: YYYY-MM-DD
+	Type             string `form:"type" binding:"required,oneof=KARTU_KELUARGA AKTA_KELAHIRAN IJAZAH"`
+}
+
+// VerifySantriDocumentRequest is the DTO for approving a document or asking for a re-upload
+type VerifySantriDocumentRequest struct {
+	Status string `json:"status" binding:"required,oneof=APPROVED NEEDS_REUPLOAD"`
+	Note   string `json:"note" binding:"omitempty,max=500"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// PossibleDuplicate is a registrant that is likely the same person as the one being reviewed
+type PossibleDuplicate struct {
+	ID               uint      `json:"id"`
+	RegistrationCode string    `json:"registration_code"`
+	FullName         string    `json:"full_name"`
+	NIK              string    `json:"nik"`
+	BirthPlace       string    `json:"birth_place"`
+	BirthDate        time.Time `json:"birth_date"`
+	ParentPhone      string    `json:"parent_phone"`
+	Status           string    `json:"status"`
+	Score            int       `json:"score"`   // 0-100
+	Reasons          []string  `json:"reasons"` // Which fields matched
+	RegisteredAt     time.Time `json:"registered_at"`
+}
+
+// MergeSantriRequest is the DTO for merging a duplicate registration into another one
+type MergeSantriRequest struct {
+	DuplicateID uint `json:"duplicate_id" binding:"required,min=1"`
+}
@@ -0,0 +1,85 @@
+package dto
+
+// SelectionComponentInput is one component of a wave's selection rubric. Leave ID empty for a new component.
+type SelectionComponentInput struct {
+	ID       uint    `json:"id"`
+	Name     string  `json:"name" binding:"required,min=2,max=100"`
+	Weight   int     `json:"weight" binding:"required,min=1,max=100"` // Percentage of the total score
+	MaxScore float64 `json:"max_score" binding:"omitempty,gt=0"`      // Defaults to 100
+}
+
+// UpdateSelectionRubricRequest replaces a wave's rubric. The weights must add up to 100.
+type UpdateSelectionRubricRequest struct {
+	Components []SelectionComponentInput `json:"components" binding:"required,min=1,max=20,dive"`
+}
+
+// SelectionScoreInput is the score for one rubric component
+type SelectionScoreInput struct {
+	ComponentID uint     `json:"component_id" binding:"required"`
+	Score       *float64 `json:"score" binding:"required,min=0"`
+}
+
+// UpdateSelectionScoresRequest is the DTO for entering a registrant's selection scores
+type UpdateSelectionScoresRequest struct {
+	Scores []SelectionScoreInput `json:"scores" binding:"required,min=1,dive"`
+}
+
+// RankingEntry is one registrant's place in the wave ranking
+type RankingEntry struct {
+	Rank             int              `json:"rank"`
+	SantriID         uint             `json:"santri_id"`
+	RegistrationCode string           `json:"registration_code"`
+	FullName         string           `json:"full_name"`
+	TotalScore       float64          `json:"total_score"` // 0-100
+	Scores           map[uint]float64 `json:"scores"`      // Raw score per component ID
+	Complete         bool             `json:"complete"`    // Every rubric component has a score
+	WithinQuota      bool             `json:"within_quota"`
+}
+
+// GenderRanking ranks the verified registrants of one gender against the remaining seats
+type GenderRanking struct {
+	Quota     int            `json:"quota"` // 0 means unlimited
+	Accepted  int            `json:"accepted"`
+	Remaining int            `json:"remaining"` // -1 means unlimited
+	Entries   []RankingEntry `json:"entries"`
+}
+
+// WaveRankingResponse is the ranking of a wave's verified registrants, per gender
+type WaveRankingResponse struct {
+	WaveID     uint            `json:"wave_id"`
+	WaveName   string          `json:"wave_name"`
+	Components []RubricSummary `json:"components"`
+	Male       GenderRanking   `json:"male"`
+	Female     GenderRanking   `json:"female"`
+}
+
+// RubricSummary describes a rubric component in the ranking
+type RubricSummary struct {
+	ID       uint    `json:"id"`
+	Name     string  `json:"name"`
+	Weight   int     `json:"weight"`
+	MaxScore float64 `json:"max_score"`
+}
+
+// RunSelectionRequest accepts the top ranked registrants of a wave and optionally rejects the rest.
+// TopMale and TopFemale default to the remaining quota; they are required when the quota is unlimited.
+type RunSelectionRequest struct {
+	Class      string `json:"class" binding:"required,min=1,max=10"`
+	EntryYear  int    `json:"entry_year" binding:"required,min=2000,max=2100"`
+	TopMale    *int   `json:"top_male" binding:"omitempty,min=0"`
+	TopFemale  *int   `json:"top_female" binding:"omitempty,min=0"`
+	RejectRest bool   `json:"reject_rest"`
+	Reason     string `json:"reason" binding:"omitempty,max=500"` // Stored on the rejections
+}
+
+// SelectionResult lists what a selection run changed
+type SelectionResult struct {
+	Accepted []SelectionAccepted `json:"accepted"`
+	Rejected []uint              `json:"rejected"`
+}
+
+// SelectionAccepted is a registrant accepted by a selection run
+type SelectionAccepted struct {
+	SantriID uint   `json:"santri_id"`
+	NIS      string `json:"nis"`
+}
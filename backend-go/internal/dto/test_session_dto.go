@@ -0,0 +1,54 @@
+package dto
+
+import "time"
+
+// CreateTestSessionRequest is the DTO for scheduling an entrance test or interview session
+type CreateTestSessionRequest struct {
+	WaveID   uint      `json:"wave_id" binding:"required"`
+	Kind     string    `json:"kind" binding:"required,oneof=TEST INTERVIEW"`
+	Name     string    `json:"name" binding:"required,min=3,max=100"`
+	StartsAt time.Time `json:"starts_at" binding:"required"` // RFC 3339, e.g. 2025-03-10T08:00:00+07:00
+	EndsAt   time.Time `json:"ends_at" binding:"required"`
+	Room     string    `json:"room" binding:"required,min=1,max=100"`
+	Capacity int       `json:"capacity" binding:"required,min=1,max=1000"`
+	Gender   string    `json:"gender" binding:"omitempty,oneof=L P"` // Empty for mixed sessions
+	Examiner string    `json:"examiner" binding:"omitempty,max=100"`
+	Notes    string    `json:"notes" binding:"omitempty,max=1000"`
+}
+
+// UpdateTestSessionRequest is the DTO for updating a session. The whole session is replaced.
+type UpdateTestSessionRequest struct {
+	Kind     string    `json:"kind" binding:"required,oneof=TEST INTERVIEW"`
+	Name     string    `json:"name" binding:"required,min=3,max=100"`
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	EndsAt   time.Time `json:"ends_at" binding:"required"`
+	Room     string    `json:"room" binding:"required,min=1,max=100"`
+	Capacity int       `json:"capacity" binding:"required,min=1,max=1000"`
+	Gender   string    `json:"gender" binding:"omitempty,oneof=L P"`
+	Examiner string    `json:"examiner" binding:"omitempty,max=100"`
+	Notes    string    `json:"notes" binding:"omitempty,max=1000"`
+}
+
+// AutoAssignTestSessionsRequest selects which kind of session to fill
+type AutoAssignTestSessionsRequest struct {
+	Kind string `json:"kind" binding:"required,oneof=TEST INTERVIEW"`
+}
+
+// AssignTestSessionRequest is the DTO for placing a registrant in a session by hand
+type AssignTestSessionRequest struct {
+	SantriID uint `json:"santri_id" binding:"required"`
+}
+
+// AutoAssignResult summarises an auto-assignment run
+type AutoAssignResult struct {
+	Assigned int                  `json:"assigned"`
+	Unplaced []UnplacedRegistrant `json:"unplaced"` // Registrants for whom no session had room
+}
+
+// UnplacedRegistrant is a verified registrant that could not be given a session
+type UnplacedRegistrant struct {
+	SantriID         uint   `json:"santri_id"`
+	RegistrationCode string `json:"registration_code"`
+	FullName         string `json:"full_name"`
+	Gender           string `json:"gender"`
+}
@@ -46,17 +46,11 @@ func (h *AchievementHandler) Create(c *gin.Context) {
 		Color:       req.Color,
 	}
 
-	if err := h.service.CreateAchievement(c.Request.Context(), achievement); err != nil {
+	if err := h.service.CreateAchievement(actorContext(c), achievement); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionCreate, "achievement", &achievement.ID, nil, achievement, c.ClientIP(), c.GetHeader("User-Agent"))
-	}
-
 	utils.SuccessResponse(c, http.StatusCreated, "Achievement created successfully", achievement)
 }
 
@@ -97,18 +91,11 @@ func (h *AchievementHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeleteAchievement(c.Request.Context(), uint(id)); err != nil {
+	if err := h.service.DeleteAchievement(actorContext(c), uint(id)); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		entityID := uint(id)
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionDelete, "achievement", &entityID, nil, nil, c.ClientIP(), c.GetHeader("User-Agent"))
-	}
-
 	utils.SuccessResponse(c, http.StatusOK, "Achievement deleted successfully", nil)
 }
 
@@ -147,18 +134,11 @@ func (h *AchievementHandler) Update(c *gin.Context) {
 		Color:       req.Color,
 	}
 
-	if err := h.service.UpdateAchievement(c.Request.Context(), uint(id), achievement); err != nil {
+	if err := h.service.UpdateAchievement(actorContext(c), uint(id), achievement); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		entityID := uint(id)
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionUpdate, "achievement", &entityID, nil, achievement, c.ClientIP(), c.GetHeader("User-Agent"))
-	}
-
 	utils.SuccessResponse(c, http.StatusOK, "Achievement updated successfully", nil)
 }
 
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"backend-go/internal/dto"
+	"backend-go/internal/models"
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdmissionWaveHandler struct {
+	service services.AdmissionWaveService
+}
+
+func NewAdmissionWaveHandler(service services.AdmissionWaveService) *AdmissionWaveHandler {
+	return &AdmissionWaveHandler{service}
+}
+
+// Create godoc
+// @Summary      Create an admission wave
+// @Description  Create a new PSB admission wave (gelombang) with its registration window, quotas and fee (admin only)
+// @Tags         psb-waves
+// @Accept       json
+// @Produce      json
+// @Param        wave  body      dto.CreateAdmissionWaveRequest  true  "Wave data"
+// @Success      201   {object}  utils.APIResponse
+// @Failure      400   {object}  utils.APIResponse
+// @Failure      401   {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/waves [post]
+func (h *AdmissionWaveHandler) Create(c *gin.Context) {
+	var req dto.CreateAdmissionWaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	startDate, endDate, err := parseWaveDates(req.StartDate, req.EndDate)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", "Use YYYY-MM-DD format")
+		return
+	}
+
+	wave := &models.AdmissionWave{
+		Name:            req.Name,
+		AcademicYear:    req.AcademicYear,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		QuotaMale:       req.QuotaMale,
+		QuotaFemale:     req.QuotaFemale,
+		RegistrationFee: req.RegistrationFee,
+		IsActive:        req.IsActive == nil || *req.IsActive,
+	}
+
+	if err := h.service.CreateWave(actorContext(c), wave); err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Admission wave created successfully", wave)
+}
+
+// GetAll godoc
+// @Summary      Get all admission waves
+// @Description  Get all PSB admission waves, newest first (admin only)
+// @Tags         psb-waves
+// @Produce      json
+// @Param        academic_year  query     string  false  "Filter by academic year (e.g. 2025/2026)"
+// @Success      200            {object}  utils.APIResponse
+// @Failure      401            {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/waves [get]
+func (h *AdmissionWaveHandler) GetAll(c *gin.Context) {
+	waves, err := h.service.GetAllWaves(c.Request.Context(), c.Query("academic_year"))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Admission waves retrieved successfully", waves)
+}
+
+// GetByID godoc
+// @Summary      Get admission wave by ID
+// @Description  Get a single PSB admission wave (admin only)
+// @Tags         psb-waves
+// @Produce      json
+// @Param        id   path      int  true  "Wave ID"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/waves/{id} [get]
+func (h *AdmissionWaveHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	wave, err := h.service.GetWaveByID(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Admission wave retrieved successfully", wave)
+}
+
+// GetOpen godoc
+// @Summary      Get the open admission wave
+// @Description  Get the admission wave currently accepting registrations, including its fee (public)
+// @Tags         psb-waves
+// @Produce      json
+// @Success      200  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Router       /psb/waves/open [get]
+func (h *AdmissionWaveHandler) GetOpen(c *gin.Context) {
+	wave, err := h.service.GetOpenWave(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			utils.ErrorResponse(c, http.StatusNotFound, "PSB registration is currently closed", nil)
+			return
+		}
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Open admission wave retrieved successfully", wave)
+}
+
+// Update godoc
+// @Summary      Update an admission wave
+// @Description  Replace the settings of an admission wave (admin only)
+// @Tags         psb-waves
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                             true  "Wave ID"
+// @Param        wave  body      dto.UpdateAdmissionWaveRequest  true  "Wave data"
+// @Success      200   {object}  utils.APIResponse
+// @Failure      400   {object}  utils.APIResponse
+// @Failure      401   {object}  utils.APIResponse
+// @Failure      404   {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/waves/{id} [put]
+func (h *AdmissionWaveHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req dto.UpdateAdmissionWaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	startDate, endDate, err := parseWaveDates(req.StartDate, req.EndDate)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", "Use YYYY-MM-DD format")
+		return
+	}
+
+	wave := &models.AdmissionWave{
+		Name:            req.Name,
+		AcademicYear:    req.AcademicYear,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		QuotaMale:       req.QuotaMale,
+		QuotaFemale:     req.QuotaFemale,
+		RegistrationFee: req.RegistrationFee,
+		IsActive:        *req.IsActive,
+	}
+
+	if err := h.service.UpdateWave(actorContext(c), uint(id), wave); err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Admission wave updated successfully", nil)
+}
+
+// Delete godoc
+// @Summary      Delete an admission wave
+// @Description  Delete an admission wave that has no registrants (admin only)
+// @Tags         psb-waves
+// @Produce      json
+// @Param        id   path      int  true  "Wave ID"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      409  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/waves/{id} [delete]
+func (h *AdmissionWaveHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	if err := h.service.DeleteWave(actorContext(c), uint(id)); err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Admission wave deleted successfully", nil)
+}
+
+// parseWaveDates parses the YYYY-MM-DD start and end dates of a wave
+func parseWaveDates(start, end string) (time.Time, time.Time, error) {
+	startDate, err := time.Parse("2006-01-02", start)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	endDate, err := time.Parse("2006-01-02", end)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return startDate, endDate, nil
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"backend-go/internal/consts"
 	"backend-go/internal/dto"
+	"backend-go/internal/middleware"
 	"backend-go/internal/models"
 	"backend-go/internal/services"
 	"backend-go/internal/utils"
@@ -22,14 +23,15 @@ func NewArticleHandler(service services.ArticleService) *ArticleHandler {
 
 // Create godoc
 // @Summary      Create a new article
-// @Description  Create a new article (admin only)
+// @Description  Create a new article (admin only). Status defaults to draft; a scheduled article needs publish_at in the future.
 // @Tags         articles
 // @Accept       json
 // @Produce      json
-// @Param        article  body      models.Article  true  "Article data"
+// @Param        article  body      dto.CreateArticleRequest  true  "Article data"
 // @Success      201      {object}  utils.APIResponse
 // @Failure      400      {object}  utils.APIResponse
 // @Failure      401      {object}  utils.APIResponse
+// @Failure      403      {object}  utils.APIResponse  "Publishing requires article.publish"
 // @Security     BearerAuth
 // @Router       /articles [post]
 func (h *ArticleHandler) Create(c *gin.Context) {
@@ -44,23 +46,26 @@ func (h *ArticleHandler) Create(c *gin.Context) {
 		utils.ResponseWithError(c, utils.ErrUnauthorized)
 		return
 	}
+	status := requestedArticleStatus(input.Status, &input.IsPublished)
+	if needsPublishPermission("", status, input.PublishAt != nil) && !middleware.HasPermission(c, models.PermArticlePublish) {
+		utils.ErrorResponse(c, http.StatusForbidden, "Access denied", "Publishing articles requires the article.publish permission")
+		return
+	}
 
 	article := &models.Article{
 		Title:        input.Title,
 		Content:      input.Content,
 		ThumbnailURL: input.ThumbnailURL,
-		IsPublished:  input.IsPublished,
+		Status:       status,
+		PublishAt:    input.PublishAt,
 		AuthorID:     userID.(uint),
 	}
 
-	if err := h.service.CreateArticle(c.Request.Context(), article); err != nil {
+	if err := h.service.CreateArticle(actorContext(c), article); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	services.LogActivityAsync(c.Request.Context(), userID.(uint), models.ActionCreate, "article", &article.ID, nil, article, c.ClientIP(), c.GetHeader("User-Agent"))
-
 	utils.SuccessResponse(c, http.StatusCreated, "Article created successfully", article)
 }
 
@@ -111,7 +116,7 @@ func (h *ArticleHandler) GetAll(c *gin.Context) {
 
 // GetDetail godoc
 // @Summary      Get article by ID
-// @Description  Get a single article by its ID
+// @Description  Get a single published article by its ID
 // @Tags         articles
 // @Produce      json
 // @Param        id   path      int  true  "Article ID"
@@ -155,7 +160,7 @@ func (h *ArticleHandler) GetDetailBySlug(c *gin.Context) {
 
 // Search godoc
 // @Summary      Search articles
-// @Description  Search articles by title or content
+// @Description  Full-text search of published articles, most relevant first. Title matches rank above content matches. Each item has a rank and a headline: the matching part of the content with the matches wrapped in <mark>. The query supports "quoted phrases", OR and -excluded words.
 // @Tags         articles
 // @Produce      json
 // @Param        q      query     string  true   "Search query"
@@ -323,15 +328,16 @@ func (h *ArticleHandler) GetByTag(c *gin.Context) {
 
 // Update godoc
 // @Summary      Update an article
-// @Description  Update an existing article (admin only)
+// @Description  Update an existing article (admin only). Leaving status empty keeps the current status.
 // @Tags         articles
 // @Accept       json
 // @Produce      json
-// @Param        id       path      int             true  "Article ID"
-// @Param        article  body      models.Article  true  "Updated article data"
+// @Param        id       path      int                       true  "Article ID"
+// @Param        article  body      dto.UpdateArticleRequest  true  "Updated article data"
 // @Success      200      {object}  utils.APIResponse
 // @Failure      400      {object}  utils.APIResponse
 // @Failure      401      {object}  utils.APIResponse
+// @Failure      403      {object}  utils.APIResponse  "Publishing requires article.publish"
 // @Failure      404      {object}  utils.APIResponse
 // @Security     BearerAuth
 // @Router       /articles/{id} [put]
@@ -348,27 +354,37 @@ func (h *ArticleHandler) Update(c *gin.Context) {
 		return
 	}
 
+	previous, err := h.service.GetManagedArticle(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	status := requestedArticleStatus(input.Status, input.IsPublished)
+	target := previous.Status
+	if status != "" {
+		target = status
+	}
+	if needsPublishPermission(previous.Status, target, input.PublishAt != nil) && !middleware.HasPermission(c, models.PermArticlePublish) {
+		utils.ErrorResponse(c, http.StatusForbidden, "Access denied", "Publishing articles requires the article.publish permission")
+		return
+	}
+
 	article := &models.Article{
 		Title:        input.Title,
 		Content:      input.Content,
 		ThumbnailURL: input.ThumbnailURL,
-	}
-	if input.IsPublished != nil {
-		article.IsPublished = *input.IsPublished
+		Status:       status,
+		PublishAt:    input.PublishAt,
 	}
 
-	if err := h.service.UpdateArticle(c.Request.Context(), uint(id), article); err != nil {
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uint)
+	if err := h.service.UpdateArticle(actorContext(c), uint(id), uid, article); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		entityID := uint(id)
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionUpdate, "article", &entityID, nil, article, c.ClientIP(), c.GetHeader("User-Agent"))
-	}
-
 	utils.SuccessResponse(c, http.StatusOK, "Article updated successfully", nil)
 }
 
@@ -391,18 +407,226 @@ func (h *ArticleHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeleteArticle(c.Request.Context(), uint(id)); err != nil {
+	if err := h.service.DeleteArticle(actorContext(c), uint(id)); err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Article deleted successfully", nil)
+}
+
+// GetRevisions godoc
+// @Summary      Get article revisions
+// @Description  List the saved revisions of an article, newest first, without their content
+// @Tags         articles
+// @Produce      json
+// @Param        id   path      int  true  "Article ID"
+// @Success      200  {object}  utils.APIResponse{data=[]models.ArticleRevision}
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /articles/{id}/revisions [get]
+func (h *ArticleHandler) GetRevisions(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	revisions, err := h.service.GetRevisions(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Article revisions fetched successfully", revisions)
+}
+
+// GetRevision godoc
+// @Summary      Get article revision
+// @Description  Get a revision of an article with its content
+// @Tags         articles
+// @Produce      json
+// @Param        id   path      int  true  "Article ID"
+// @Param        rev  path      int  true  "Revision number"
+// @Success      200  {object}  utils.APIResponse{data=models.ArticleRevision}
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /articles/{id}/revisions/{rev} [get]
+func (h *ArticleHandler) GetRevision(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+	rev, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid revision", err.Error())
+		return
+	}
+
+	revision, err := h.service.GetRevision(c.Request.Context(), uint(id), rev)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Article revision fetched successfully", revision)
+}
+
+// DiffRevisions godoc
+// @Summary      Compare article revisions
+// @Description  Word-level diff of the title and content of two revisions. Content is compared as text, without markup.
+// @Tags         articles
+// @Produce      json
+// @Param        id    path      int  true  "Article ID"
+// @Param        from  query     int  true  "Older revision number"
+// @Param        to    query     int  true  "Newer revision number"
+// @Success      200   {object}  utils.APIResponse{data=dto.ArticleRevisionDiff}
+// @Failure      400   {object}  utils.APIResponse
+// @Failure      404   {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /articles/{id}/revisions/diff [get]
+func (h *ArticleHandler) DiffRevisions(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+	from, err := strconv.Atoi(c.Query("from"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid revision", "from must be a revision number")
+		return
+	}
+	to, err := strconv.Atoi(c.Query("to"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid revision", "to must be a revision number")
+		return
+	}
+
+	diff, err := h.service.DiffRevisions(c.Request.Context(), uint(id), from, to)
+	if err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
+	utils.SuccessResponse(c, http.StatusOK, "Article revisions compared successfully", diff)
+}
+
+// RestoreRevision godoc
+// @Summary      Restore article revision
+// @Description  Bring back the title, content and thumbnail of a revision. The restore is saved as a new revision and the publish state is kept.
+// @Tags         articles
+// @Produce      json
+// @Param        id   path      int  true  "Article ID"
+// @Param        rev  path      int  true  "Revision number"
+// @Success      200  {object}  utils.APIResponse{data=models.Article}
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /articles/{id}/revisions/{rev}/restore [post]
+func (h *ArticleHandler) RestoreRevision(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+	rev, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid revision", err.Error())
+		return
+	}
+
 	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		entityID := uint(id)
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionDelete, "article", &entityID, nil, nil, c.ClientIP(), c.GetHeader("User-Agent"))
+	uid, _ := userID.(uint)
+	article, err := h.service.RestoreRevision(actorContext(c), uint(id), rev, uid)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Article deleted successfully", nil)
+	utils.SuccessResponse(c, http.StatusOK, "Article revision restored successfully", article)
 }
 
+// GetManaged godoc
+// @Summary      Get articles for editing
+// @Description  List articles in every state, newest change first, optionally filtered by status (admin only)
+// @Tags         articles
+// @Produce      json
+// @Param        status  query     string  false  "Filter by status (draft, in_review, scheduled, published, archived)"
+// @Param        page    query     int     false  "Page number (default: 1)"
+// @Param        limit   query     int     false  "Items per page (default: 10)"
+// @Success      200     {object}  utils.APIResponse{data=[]models.Article}
+// @Failure      400     {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /articles/manage [get]
+func (h *ArticleHandler) GetManaged(c *gin.Context) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	if page < 1 {
+		page = consts.DefaultPage
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	if limit < 1 {
+		limit = consts.DefaultPageLimit
+	} else if limit > consts.MaxPageLimit {
+		limit = consts.MaxPageLimit
+	}
+
+	articles, total, err := h.service.GetManagedArticles(c.Request.Context(), models.ArticleStatus(c.Query("status")), page, limit)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponsePaginated(c, http.StatusOK, "Articles fetched successfully", articles, page, limit, total)
+}
+
+// GetManagedDetail godoc
+// @Summary      Get article for editing
+// @Description  Get an article in any state by its ID (admin only)
+// @Tags         articles
+// @Produce      json
+// @Param        id   path      int  true  "Article ID"
+// @Success      200  {object}  utils.APIResponse{data=models.Article}
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /articles/manage/{id} [get]
+func (h *ArticleHandler) GetManagedDetail(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	article, err := h.service.GetManagedArticle(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Article detail fetched successfully", article)
+}
+
+// requestedArticleStatus reads the status of an article request, falling back to the older is_published flag.
+// It is empty when the request asks for neither.
+func requestedArticleStatus(status string, isPublished *bool) models.ArticleStatus {
+	switch {
+	case status != "":
+		return models.ArticleStatus(status)
+	case isPublished == nil:
+		return ""
+	case *isPublished:
+		return models.ArticlePublished
+	default:
+		return models.ArticleDraft
+	}
+}
+
+// needsPublishPermission reports whether a status change, or a new publish_at, changes what the public sees
+func needsPublishPermission(from, to models.ArticleStatus, reschedule bool) bool {
+	if from != to {
+		return from.IsPublishing() || to.IsPublishing()
+	}
+	return reschedule && to.IsPublishing()
+}
@@ -2,9 +2,9 @@ package handlers
 
 import (
 	"backend-go/internal/dto"
-	"backend-go/internal/models"
 	"backend-go/internal/services"
 	"backend-go/internal/utils"
+	"context"
 	"net/http"
 	"strconv"
 
@@ -21,14 +21,15 @@ func NewAuthHandler(service services.AuthService) *AuthHandler {
 
 // Login godoc
 // @Summary      Login admin
-// @Description  Login with username and password to get JWT tokens
+// @Description  Login with username and password to get JWT tokens. Accounts with two-factor authentication get a challenge token instead, to complete at /login/2fa. Repeated failures slow down further attempts and then lock the username for a while.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        input body dto.LoginRequest true "Login Credentials"
-// @Success      200  {object} utils.APIResponse{data=dto.TokenPair}
+// @Success      200  {object} utils.APIResponse{data=dto.LoginResponse}
 // @Failure      400  {object} utils.APIResponse
 // @Failure      401  {object} utils.APIResponse
+// @Failure      429  {object} utils.APIResponse
 // @Router       /login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var input dto.LoginRequest
@@ -38,24 +39,50 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	tokenPair, err := h.service.Login(c.Request.Context(), input.Username, input.Password)
+	result, err := h.service.Login(c.Request.Context(), input.Username, input.Password, clientInfo(c))
 	if err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log login activity
-	if tokenPair.User.ID > 0 {
-		services.LogActivityAsync(c.Request.Context(), tokenPair.User.ID, models.ActionLogin, "auth", nil, nil, map[string]string{"username": input.Username}, c.ClientIP(), c.GetHeader("User-Agent"))
+	// No tokens until the second factor is checked
+	if result.TwoFactorRequired {
+		utils.SuccessResponse(c, http.StatusOK, "Two-factor authentication required", result)
+		return
 	}
 
-	// Set access token in HttpOnly cookie
-	c.SetCookie("auth_token", tokenPair.AccessToken, int(tokenPair.ExpiresIn), "/", "", false, true)
-
-	// Set refresh token in HttpOnly cookie with longer expiry
-	c.SetCookie("refresh_token", tokenPair.RefreshToken, 7*24*3600, "/", "", false, true)
+	setAuthCookies(c, result.TokenPair)
 
 	// Return tokens in response body as well (for mobile/API clients)
+	utils.SuccessResponse(c, http.StatusOK, "Login successful", result)
+}
+
+// VerifyTwoFactor godoc
+// @Summary      Complete two-factor login
+// @Description  Exchange the challenge token from /login and a 6-digit TOTP code or a recovery code for JWT tokens. A challenge is valid for 5 minutes and 5 attempts. Wrong codes count toward the account lockout like wrong passwords.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        input body dto.TwoFactorLoginRequest true "Challenge token and code"
+// @Success      200  {object} utils.APIResponse{data=dto.TokenPair}
+// @Failure      400  {object} utils.APIResponse
+// @Failure      401  {object} utils.APIResponse
+// @Failure      429  {object} utils.APIResponse  "Too many failed attempts"
+// @Router       /login/2fa [post]
+func (h *AuthHandler) VerifyTwoFactor(c *gin.Context) {
+	var input dto.TwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	tokenPair, _, err := h.service.VerifyTwoFactor(c.Request.Context(), input.ChallengeToken, input.Code, clientInfo(c))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	setAuthCookies(c, tokenPair)
 	utils.SuccessResponse(c, http.StatusOK, "Login successful", tokenPair)
 }
 
@@ -91,32 +118,23 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	}
 
 	// Update cookies with new tokens
-	c.SetCookie("auth_token", tokenPair.AccessToken, int(tokenPair.ExpiresIn), "/", "", false, true)
-	c.SetCookie("refresh_token", tokenPair.RefreshToken, 7*24*3600, "/", "", false, true)
+	setAuthCookies(c, tokenPair)
 
 	utils.SuccessResponse(c, http.StatusOK, "Token refreshed successfully", tokenPair)
 }
 
 // Logout godoc
 // @Summary      Logout admin
-// @Description  Clear authentication cookies
+// @Description  End the session of the refresh token and clear authentication cookies
 // @Tags         auth
 // @Produce      json
 // @Success      200  {object} utils.APIResponse
 // @Router       /logout [post]
 func (h *AuthHandler) Logout(c *gin.Context) {
-	// Get user ID before logout
-	userID, _ := c.Get("user_id")
-
-	// Get refresh token from cookie to blacklist it
+	// Get refresh token from cookie to end its session
 	if refreshToken, err := c.Cookie("refresh_token"); err == nil && refreshToken != "" {
-		// Blacklist the refresh token to prevent reuse
-		_ = h.service.BlacklistToken(c.Request.Context(), refreshToken)
-	}
-
-	// Log logout activity
-	if uid, ok := userID.(uint); ok {
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionLogout, "auth", nil, nil, nil, c.ClientIP(), c.GetHeader("User-Agent"))
+		// Blacklists the refresh token and revokes the session, so its access token stops working too
+		_ = h.service.Logout(c.Request.Context(), refreshToken, clientInfo(c))
 	}
 
 	// Clear both cookies
@@ -127,7 +145,7 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 
 // CreateAdmin godoc
 // @Summary      Create new admin
-// @Description  Create a new admin user (Super Admin only)
+// @Description  Create a new admin user who has to change the password on first login. When an email is given, a link to choose a password is sent there (Super Admin only)
 // @Tags         auth
 // @Accept       json
 // @Produce      json
@@ -145,7 +163,7 @@ func (h *AuthHandler) CreateAdmin(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.RegisterAdmin(c.Request.Context(), input.Username, input.Password, ""); err != nil {
+	if err := h.service.RegisterAdmin(c.Request.Context(), input.Username, input.Password, input.Email, input.Role); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
@@ -173,7 +191,7 @@ func (h *AuthHandler) GetAllAdmins(c *gin.Context) {
 
 // DeleteAdmin godoc
 // @Summary      Delete admin
-// @Description  Delete an admin user by ID
+// @Description  Delete an admin user by ID and end their sessions
 // @Tags         auth
 // @Produce      json
 // @Param        id   path      int  true  "Admin ID"
@@ -189,7 +207,7 @@ func (h *AuthHandler) DeleteAdmin(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeleteAdmin(c.Request.Context(), uint(id)); err != nil {
+	if err := h.service.DeleteAdmin(actorContext(c), uint(id)); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
@@ -199,7 +217,7 @@ func (h *AuthHandler) DeleteAdmin(c *gin.Context) {
 
 // UpdateAdminPassword godoc
 // @Summary      Update admin password
-// @Description  Update password for an admin user (Super Admin only)
+// @Description  Update password for an admin user, who is logged out and has to change it on their next login (Super Admin only)
 // @Tags         auth
 // @Accept       json
 // @Produce      json
@@ -230,3 +248,345 @@ func (h *AuthHandler) UpdateAdminPassword(c *gin.Context) {
 
 	utils.SuccessResponse(c, http.StatusOK, "Password updated successfully", nil)
 }
+
+// UpdateAdminEmail godoc
+// @Summary      Update admin email
+// @Description  Set the email address used for password reset links, or remove it with an empty email (Super Admin only)
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Admin ID"
+// @Param        input body dto.UpdateAdminEmailRequest true "Email"
+// @Success      200  {object} utils.APIResponse
+// @Failure      400  {object} utils.APIResponse
+// @Failure      404  {object} utils.APIResponse
+// @Failure      409  {object} utils.APIResponse "Email is already used"
+// @Security     BearerAuth
+// @Router       /admins/{id}/email [put]
+func (h *AuthHandler) UpdateAdminEmail(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var input dto.UpdateAdminEmailRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	if err := h.service.UpdateAdminEmail(c.Request.Context(), uint(id), input.Email); err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Email updated successfully", nil)
+}
+
+// UpdateAdminRole godoc
+// @Summary      Update admin role
+// @Description  Assign a role to an admin and log them out, so the role's permissions apply at once. The last super admin keeps their role.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Admin ID"
+// @Param        input body dto.UpdateAdminRoleRequest true "Role name"
+// @Success      200  {object} utils.APIResponse
+// @Failure      400  {object} utils.APIResponse
+// @Failure      404  {object} utils.APIResponse
+// @Security     BearerAuth
+// @Router       /admins/{id}/role [put]
+func (h *AuthHandler) UpdateAdminRole(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var input dto.UpdateAdminRoleRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	if err := h.service.UpdateAdminRole(actorContext(c), uint(id), input.Role); err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Role updated successfully", nil)
+}
+
+// ChangePassword godoc
+// @Summary      Change own password
+// @Description  Change the logged-in admin's password and log out their other sessions. It stays available while a password change is required; the response carries new tokens without that requirement.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        input body dto.ChangeOwnPasswordRequest true "Current and new password"
+// @Success      200  {object} utils.APIResponse{data=dto.TokenPair}
+// @Failure      400  {object} utils.APIResponse
+// @Failure      401  {object} utils.APIResponse
+// @Security     BearerAuth
+// @Router       /me/password [put]
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	var input dto.ChangeOwnPasswordRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uint)
+
+	tokenPair, err := h.service.ChangePassword(actorContext(c), uid, c.GetString("session_id"), input.CurrentPassword, input.NewPassword)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	// The old refresh token would issue access tokens that still require a password change
+	if refreshToken, err := c.Cookie("refresh_token"); err == nil && refreshToken != "" {
+		_ = h.service.BlacklistToken(c.Request.Context(), refreshToken)
+	}
+
+	setAuthCookies(c, tokenPair)
+
+	utils.SuccessResponse(c, http.StatusOK, "Password changed successfully", tokenPair)
+}
+
+// ForgotPassword godoc
+// @Summary      Request password reset
+// @Description  Email a single-use password reset link, valid for one hour, to the admin with this address. The response is the same whether or not the address belongs to an account.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        input body dto.ForgotPasswordRequest true "Email and email language (id, en)"
+// @Success      200  {object} utils.APIResponse
+// @Failure      400  {object} utils.APIResponse
+// @Failure      503  {object} utils.APIResponse
+// @Router       /password/forgot [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var input dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	if err := h.service.ForgotPassword(c.Request.Context(), input.Email, input.Locale); err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "If the email belongs to an account, a reset link has been sent", nil)
+}
+
+// ResetPassword godoc
+// @Summary      Reset password
+// @Description  Set a new password with the token from a password reset link. The token can only be used once.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        input body dto.ResetPasswordRequest true "Reset token and new password"
+// @Success      200  {object} utils.APIResponse
+// @Failure      400  {object} utils.APIResponse "Invalid or expired reset token"
+// @Router       /password/reset [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var input dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	if err := h.service.ResetPassword(c.Request.Context(), input.Token, input.Password); err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Password reset successfully", nil)
+}
+
+// SetupTwoFactor godoc
+// @Summary      Start two-factor setup
+// @Description  Create a TOTP secret with its otpauth:// URI and QR code for an authenticator app. It is saved once confirmed at /me/2fa/enable within 10 minutes.
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object} utils.APIResponse{data=dto.TwoFactorSetupResponse}
+// @Failure      401  {object} utils.APIResponse
+// @Failure      409  {object} utils.APIResponse "Already enabled"
+// @Security     BearerAuth
+// @Router       /me/2fa/setup [post]
+func (h *AuthHandler) SetupTwoFactor(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uint)
+
+	setup, err := h.service.SetupTwoFactor(c.Request.Context(), uid)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Scan the QR code with an authenticator app", setup)
+}
+
+// EnableTwoFactor godoc
+// @Summary      Enable two-factor authentication
+// @Description  Confirm the secret from /me/2fa/setup with a code from the authenticator app. The response carries the recovery codes, shown only once, and new tokens.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        input body dto.TwoFactorCodeRequest true "TOTP code"
+// @Success      200  {object} utils.APIResponse{data=dto.TwoFactorEnabledResponse}
+// @Failure      400  {object} utils.APIResponse
+// @Failure      409  {object} utils.APIResponse "Already enabled"
+// @Security     BearerAuth
+// @Router       /me/2fa/enable [post]
+func (h *AuthHandler) EnableTwoFactor(c *gin.Context) {
+	var input dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uint)
+
+	result, err := h.service.EnableTwoFactor(actorContext(c), uid, c.GetString("session_id"), input.Code)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	setAuthCookies(c, result.TokenPair)
+	utils.SuccessResponse(c, http.StatusOK, "Two-factor authentication enabled", result)
+}
+
+// DisableTwoFactor godoc
+// @Summary      Disable two-factor authentication
+// @Description  Turn two-factor authentication off with the password and a TOTP or recovery code. Not allowed for super admins while REQUIRE_SUPER_ADMIN_2FA is set.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        input body dto.DisableTwoFactorRequest true "Password and code"
+// @Success      200  {object} utils.APIResponse
+// @Failure      400  {object} utils.APIResponse
+// @Security     BearerAuth
+// @Router       /me/2fa/disable [post]
+func (h *AuthHandler) DisableTwoFactor(c *gin.Context) {
+	var input dto.DisableTwoFactorRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uint)
+
+	if err := h.service.DisableTwoFactor(actorContext(c), uid, input.Password, input.Code); err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Two-factor authentication disabled", nil)
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary      Regenerate recovery codes
+// @Description  Replace all recovery codes after checking a TOTP or recovery code. The new codes are shown only once.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        input body dto.TwoFactorCodeRequest true "TOTP or recovery code"
+// @Success      200  {object} utils.APIResponse{data=dto.RecoveryCodesResponse}
+// @Failure      400  {object} utils.APIResponse
+// @Security     BearerAuth
+// @Router       /me/2fa/recovery-codes [post]
+func (h *AuthHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var input dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uint)
+
+	codes, err := h.service.RegenerateRecoveryCodes(actorContext(c), uid, input.Code)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Recovery codes regenerated", dto.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// ResetTwoFactor godoc
+// @Summary      Reset admin two-factor authentication
+// @Description  Turn two-factor authentication off for an admin who lost their authenticator and recovery codes (Super Admin only)
+// @Tags         auth
+// @Produce      json
+// @Param        id   path      int  true  "Admin ID"
+// @Success      200  {object} utils.APIResponse
+// @Failure      400  {object} utils.APIResponse
+// @Failure      404  {object} utils.APIResponse
+// @Security     BearerAuth
+// @Router       /admins/{id}/2fa [delete]
+func (h *AuthHandler) ResetTwoFactor(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	if err := h.service.ResetTwoFactor(actorContext(c), uint(id)); err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Two-factor authentication reset", nil)
+}
+
+// UnlockAdmin godoc
+// @Summary      Unlock admin login
+// @Description  Lift the temporary lockout of an admin account after too many failed logins (Super Admin only)
+// @Tags         auth
+// @Produce      json
+// @Param        id   path      int  true  "Admin ID"
+// @Success      200  {object} utils.APIResponse
+// @Failure      400  {object} utils.APIResponse
+// @Failure      404  {object} utils.APIResponse
+// @Security     BearerAuth
+// @Router       /admins/{id}/lockout [delete]
+func (h *AuthHandler) UnlockAdmin(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	if err := h.service.UnlockAdmin(actorContext(c), uint(id)); err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Admin unlocked successfully", nil)
+}
+
+// clientInfo describes the device a login comes from
+func clientInfo(c *gin.Context) services.ClientInfo {
+	return services.ClientInfo{IPAddress: c.ClientIP(), UserAgent: c.GetHeader("User-Agent")}
+}
+
+// actorContext returns the request context carrying the signed-in user, so services record their changes in the activity log
+func actorContext(c *gin.Context) context.Context {
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uint)
+	return services.WithActor(c.Request.Context(), uid, clientInfo(c))
+}
+
+// setAuthCookies stores the tokens in HttpOnly cookies, the refresh token with its longer expiry
+func setAuthCookies(c *gin.Context, tokenPair *dto.TokenPair) {
+	c.SetCookie("auth_token", tokenPair.AccessToken, int(tokenPair.ExpiresIn), "/", "", false, true)
+	c.SetCookie("refresh_token", tokenPair.RefreshToken, 7*24*3600, "/", "", false, true)
+}
@@ -43,17 +43,11 @@ func (h *CategoryHandler) Create(c *gin.Context) {
 		Description: input.Description,
 	}
 
-	if err := h.service.CreateCategory(c.Request.Context(), category); err != nil {
+	if err := h.service.CreateCategory(actorContext(c), category); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionCreate, "category", &category.ID, nil, category, c.ClientIP(), c.GetHeader("User-Agent"))
-	}
-
 	utils.SuccessResponse(c, http.StatusCreated, "Category created successfully", category)
 }
 
@@ -131,18 +125,11 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 		Description: input.Description,
 	}
 
-	if err := h.service.UpdateCategory(c.Request.Context(), uint(id), category); err != nil {
+	if err := h.service.UpdateCategory(actorContext(c), uint(id), category); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		entityID := uint(id)
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionUpdate, "category", &entityID, nil, category, c.ClientIP(), c.GetHeader("User-Agent"))
-	}
-
 	utils.SuccessResponse(c, http.StatusOK, "Category updated successfully", nil)
 }
 
@@ -165,17 +152,10 @@ func (h *CategoryHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeleteCategory(c.Request.Context(), uint(id)); err != nil {
+	if err := h.service.DeleteCategory(actorContext(c), uint(id)); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		entityID := uint(id)
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionDelete, "category", &entityID, nil, nil, c.ClientIP(), c.GetHeader("User-Agent"))
-	}
-
 	utils.SuccessResponse(c, http.StatusOK, "Category deleted successfully", nil)
 }
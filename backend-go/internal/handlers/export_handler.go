@@ -23,16 +23,24 @@ func NewExportHandler(service services.ExportService) *ExportHandler {
 // @Description  Export all or filtered santri data to Excel file (admin only)
 // @Tags         export
 // @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
-// @Param        status  query     string  false  "Filter by status (PENDING, VERIFIED, ACCEPTED, REJECTED)"
-// @Success      200     {file}    file
-// @Failure      401     {object}  utils.APIResponse
-// @Failure      500     {object}  utils.APIResponse
+// @Param        status   query     string  false  "Filter by status (PENDING, VERIFIED, ACCEPTED, REJECTED)"
+// @Param        wave_id  query     int     false  "Filter by admission wave ID"
+// @Success      200      {file}    file
+// @Failure      400      {object}  utils.APIResponse
+// @Failure      401      {object}  utils.APIResponse
+// @Failure      500      {object}  utils.APIResponse
 // @Security     BearerAuth
 // @Router       /export/santri/excel [get]
 func (h *ExportHandler) ExportSantriExcel(c *gin.Context) {
 	status := c.Query("status")
 
-	buf, err := h.service.ExportSantriToExcel(c.Request.Context(), status)
+	waveID, err := parseWaveIDQuery(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wave ID", err.Error())
+		return
+	}
+
+	buf, err := h.service.ExportSantriToExcel(c.Request.Context(), status, waveID)
 	if err != nil {
 		utils.ResponseWithError(c, err)
 		return
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// feedCacheMaxAge lets readers and proxies reuse a feed for a while before revalidating it
+const feedCacheMaxAge = "public, max-age=300"
+
+type FeedHandler struct {
+	service services.FeedService
+}
+
+func NewFeedHandler(service services.FeedService) *FeedHandler {
+	return &FeedHandler{service}
+}
+
+// ArticlesRSS godoc
+// @Summary      Articles RSS feed
+// @Description  The latest published articles as RSS 2.0. Supports If-None-Match and If-Modified-Since.
+// @Tags         feeds
+// @Produce      xml
+// @Success      200  {string}  string  "RSS feed"
+// @Success      304  "Not modified"
+// @Router       /feeds/articles.rss [get]
+func (h *FeedHandler) ArticlesRSS(c *gin.Context) {
+	feed, err := h.service.ArticleFeed(c.Request.Context(), services.FeedRSS)
+	writeFeed(c, feed, err)
+}
+
+// ArticlesAtom godoc
+// @Summary      Articles Atom feed
+// @Description  The latest published articles as Atom. Supports If-None-Match and If-Modified-Since.
+// @Tags         feeds
+// @Produce      xml
+// @Success      200  {string}  string  "Atom feed"
+// @Success      304  "Not modified"
+// @Router       /feeds/articles.atom [get]
+func (h *FeedHandler) ArticlesAtom(c *gin.Context) {
+	feed, err := h.service.ArticleFeed(c.Request.Context(), services.FeedAtom)
+	writeFeed(c, feed, err)
+}
+
+// ArticlesJSON godoc
+// @Summary      Articles JSON Feed
+// @Description  The latest published articles as JSON Feed 1.1, with the category and tags of each article. Supports If-None-Match and If-Modified-Since.
+// @Tags         feeds
+// @Produce      json
+// @Success      200  {string}  string  "JSON Feed"
+// @Success      304  "Not modified"
+// @Router       /feeds/feed.json [get]
+func (h *FeedHandler) ArticlesJSON(c *gin.Context) {
+	feed, err := h.service.ArticleFeed(c.Request.Context(), services.FeedJSON)
+	writeFeed(c, feed, err)
+}
+
+// Category godoc
+// @Summary      Category feed
+// @Description  The latest published articles of a category. The format is taken from the extension of the slug (.rss, .atom, .json), then from the format query, and defaults to RSS.
+// @Tags         feeds
+// @Produce      xml
+// @Produce      json
+// @Param        slug    path      string  true   "Category slug, optionally with a .rss, .atom or .json extension"
+// @Param        format  query     string  false  "rss, atom or json"
+// @Success      200     {string}  string  "Feed"
+// @Success      304     "Not modified"
+// @Failure      404     {object}  utils.APIResponse
+// @Router       /feeds/category/{slug} [get]
+func (h *FeedHandler) Category(c *gin.Context) {
+	slug, format := feedSlugAndFormat(c)
+	feed, err := h.service.CategoryFeed(c.Request.Context(), slug, format)
+	writeFeed(c, feed, err)
+}
+
+// Tag godoc
+// @Summary      Tag feed
+// @Description  The latest published articles with a tag. The format is taken from the extension of the slug (.rss, .atom, .json), then from the format query, and defaults to RSS.
+// @Tags         feeds
+// @Produce      xml
+// @Produce      json
+// @Param        slug    path      string  true   "Tag slug, optionally with a .rss, .atom or .json extension"
+// @Param        format  query     string  false  "rss, atom or json"
+// @Success      200     {string}  string  "Feed"
+// @Success      304     "Not modified"
+// @Failure      404     {object}  utils.APIResponse
+// @Router       /feeds/tag/{slug} [get]
+func (h *FeedHandler) Tag(c *gin.Context) {
+	slug, format := feedSlugAndFormat(c)
+	feed, err := h.service.TagFeed(c.Request.Context(), slug, format)
+	writeFeed(c, feed, err)
+}
+
+// feedSlugAndFormat splits a feed extension off the slug parameter
+func feedSlugAndFormat(c *gin.Context) (string, string) {
+	slug := c.Param("slug")
+	for _, format := range []string{services.FeedRSS, services.FeedAtom, services.FeedJSON} {
+		if strings.HasSuffix(slug, "."+format) {
+			return strings.TrimSuffix(slug, "."+format), format
+		}
+	}
+	return slug, c.DefaultQuery("format", services.FeedRSS)
+}
+
+// writeFeed answers with the feed, or with 304 when the client's copy is current
+func writeFeed(c *gin.Context, feed *services.Feed, err error) {
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	c.Header("ETag", feed.ETag)
+	c.Header("Cache-Control", feedCacheMaxAge)
+	if !feed.LastModified.IsZero() {
+		c.Header("Last-Modified", feed.LastModified.Format(http.TimeFormat))
+	}
+	if utils.NotModified(c.Request, feed.ETag, feed.LastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, feed.ContentType, []byte(feed.Body))
+}
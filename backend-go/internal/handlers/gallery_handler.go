@@ -50,17 +50,11 @@ func (h *GalleryHandler) Create(c *gin.Context) {
 	// Handle Cover File
 	coverFile, _ := c.FormFile("cover")
 
-	if err := h.service.CreateGallery(c.Request.Context(), gallery, coverFile); err != nil {
+	if err := h.service.CreateGallery(actorContext(c), gallery, coverFile); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionCreate, "gallery", &gallery.ID, nil, gallery, c.ClientIP(), c.GetHeader("User-Agent"))
-	}
-
 	utils.SuccessResponse(c, http.StatusCreated, "Gallery created successfully", gallery)
 }
 
@@ -175,18 +169,11 @@ func (h *GalleryHandler) Update(c *gin.Context) {
 		Description: input.Description,
 	}
 
-	if err := h.service.UpdateGallery(c.Request.Context(), uint(id), galleryData); err != nil {
+	if err := h.service.UpdateGallery(actorContext(c), uint(id), galleryData); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		entityID := uint(id)
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionUpdate, "gallery", &entityID, nil, galleryData, c.ClientIP(), c.GetHeader("User-Agent"))
-	}
-
 	utils.SuccessResponse(c, http.StatusOK, "Gallery updated successfully", nil)
 }
 
@@ -209,18 +196,11 @@ func (h *GalleryHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeleteGallery(c.Request.Context(), uint(id)); err != nil {
+	if err := h.service.DeleteGallery(actorContext(c), uint(id)); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		entityID := uint(id)
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionDelete, "gallery", &entityID, nil, nil, c.ClientIP(), c.GetHeader("User-Agent"))
-	}
-
 	utils.SuccessResponse(c, http.StatusOK, "Gallery deleted successfully", nil)
 }
 
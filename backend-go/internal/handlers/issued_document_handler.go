@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"backend-go/internal/dto"
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IssuedDocumentHandler struct {
+	service services.IssuedDocumentService
+}
+
+func NewIssuedDocumentHandler(service services.IssuedDocumentService) *IssuedDocumentHandler {
+	return &IssuedDocumentHandler{service}
+}
+
+// Verify godoc
+// @Summary      Verify a PSB document
+// @Description  Check the token from the QR code printed on a registration card or acceptance letter (public, rate-limited)
+// @Tags         verification
+// @Produce      json
+// @Param        token  path      string  true  "Verification token"
+// @Success      200    {object}  utils.APIResponse{data=dto.DocumentVerificationResponse}
+// @Failure      404    {object}  utils.APIResponse
+// @Failure      429    {object}  utils.APIResponse
+// @Router       /verify/{token} [get]
+func (h *IssuedDocumentHandler) Verify(c *gin.Context) {
+	result, err := h.service.Verify(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	message := "Document is valid"
+	if !result.Valid {
+		message = "Document has been revoked"
+	}
+	utils.SuccessResponse(c, http.StatusOK, message, result)
+}
+
+// GetByRegistrant godoc
+// @Summary      Get issued documents of a registrant
+// @Description  Get the generated documents of a registrant with their revocation state (admin only)
+// @Tags         verification
+// @Produce      json
+// @Param        id   path      int  true  "Registrant ID"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/registrants/{id}/issued-documents [get]
+func (h *IssuedDocumentHandler) GetByRegistrant(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	documents, err := h.service.GetBySantri(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Issued documents fetched successfully", documents)
+}
+
+// Revoke godoc
+// @Summary      Revoke an issued document
+// @Description  Revoke a generated document so its QR code no longer verifies, e.g. when a santri withdraws (admin only)
+// @Tags         verification
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                              true  "Issued document ID"
+// @Param        input  body      dto.RevokeIssuedDocumentRequest  true  "Revocation reason"
+// @Success      200    {object}  utils.APIResponse
+// @Failure      400    {object}  utils.APIResponse
+// @Failure      401    {object}  utils.APIResponse
+// @Failure      404    {object}  utils.APIResponse
+// @Failure      409    {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/issued-documents/{id}/revoke [put]
+func (h *IssuedDocumentHandler) Revoke(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var input dto.RevokeIssuedDocumentRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uint)
+
+	document, err := h.service.Revoke(actorContext(c), uint(id), input.Reason, uid)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Document revoked successfully", document)
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	service services.NotificationService
+}
+
+func NewNotificationHandler(service services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{service}
+}
+
+// GetByRegistrant godoc
+// @Summary      Get registrant notifications
+// @Description  Get the notifications sent to a registrant's parent with their delivery status per channel (EMAIL, WHATSAPP, SMS), newest first (admin only)
+// @Tags         psb
+// @Produce      json
+// @Param        id   path      int  true  "Registrant ID"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/registrants/{id}/notifications [get]
+func (h *NotificationHandler) GetByRegistrant(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	deliveries, err := h.service.GetDeliveries(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Notifications fetched successfully", deliveries)
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"backend-go/internal/dto"
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationTemplateHandler struct {
+	service services.NotificationTemplateService
+}
+
+func NewNotificationTemplateHandler(service services.NotificationTemplateService) *NotificationTemplateHandler {
+	return &NotificationTemplateHandler{service}
+}
+
+// GetAll godoc
+// @Summary      Get notification templates
+// @Description  Get the template in use for every notification event and locale (id, en), with the data fields each template can use (admin only)
+// @Tags         notification-templates
+// @Produce      json
+// @Success      200  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /notification-templates [get]
+func (h *NotificationTemplateHandler) GetAll(c *gin.Context) {
+	templates, err := h.service.GetTemplates(c.Request.Context())
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Notification templates fetched successfully", templates)
+}
+
+// GetByKey godoc
+// @Summary      Get notification template
+// @Description  Get the template in use for an event and locale (admin only)
+// @Tags         notification-templates
+// @Produce      json
+// @Param        event   path      string  true  "Event (psb.registered, psb.status_updated, psb.test_session_reminder, admin.welcome, auth.password_reset)"
+// @Param        locale  path      string  true  "Locale (id, en)"
+// @Success      200     {object}  utils.APIResponse
+// @Failure      400     {object}  utils.APIResponse
+// @Failure      404     {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /notification-templates/{event}/{locale} [get]
+func (h *NotificationTemplateHandler) GetByKey(c *gin.Context) {
+	template, err := h.service.GetTemplate(c.Request.Context(), c.Param("event"), c.Param("locale"))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Notification template fetched successfully", template)
+}
+
+// Update godoc
+// @Summary      Update notification template
+// @Description  Customize the template for an event and locale. Subject and text use Go text/template syntax, the HTML body html/template; data is referenced as {{.SantriName}}. The account emails admin.welcome and auth.password_reset are read-only (admin only)
+// @Tags         notification-templates
+// @Accept       json
+// @Produce      json
+// @Param        event   path      string                                  true  "Event"
+// @Param        locale  path      string                                  true  "Locale (id, en)"
+// @Param        input   body      dto.UpdateNotificationTemplateRequest  true  "Template"
+// @Success      200     {object}  utils.APIResponse
+// @Failure      400     {object}  utils.APIResponse  "Invalid template"
+// @Failure      403     {object}  utils.APIResponse  "Read-only template"
+// @Failure      404     {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /notification-templates/{event}/{locale} [put]
+func (h *NotificationTemplateHandler) Update(c *gin.Context) {
+	var input dto.UpdateNotificationTemplateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uint)
+
+	template, err := h.service.UpdateTemplate(actorContext(c), c.Param("event"), c.Param("locale"), &input, uid)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Notification template updated successfully", template)
+}
+
+// Reset godoc
+// @Summary      Reset notification template
+// @Description  Remove the customization of an event and locale so the built-in template is used again (admin only)
+// @Tags         notification-templates
+// @Produce      json
+// @Param        event   path      string  true  "Event"
+// @Param        locale  path      string  true  "Locale (id, en)"
+// @Success      200     {object}  utils.APIResponse
+// @Failure      400     {object}  utils.APIResponse
+// @Failure      404     {object}  utils.APIResponse  "Template is not customized"
+// @Security     BearerAuth
+// @Router       /notification-templates/{event}/{locale} [delete]
+func (h *NotificationTemplateHandler) Reset(c *gin.Context) {
+	event, locale := c.Param("event"), c.Param("locale")
+	if err := h.service.ResetTemplate(actorContext(c), event, locale); err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Notification template reset to default", nil)
+}
+
+// Preview godoc
+// @Summary      Preview notification template
+// @Description  Render a draft, or the current template for parts left empty, with sample data (admin only)
+// @Tags         notification-templates
+// @Accept       json
+// @Produce      json
+// @Param        event   path      string                                   true   "Event"
+// @Param        locale  path      string                                   true   "Locale (id, en)"
+// @Param        input   body      dto.PreviewNotificationTemplateRequest  false  "Draft template"
+// @Success      200     {object}  utils.APIResponse
+// @Failure      400     {object}  utils.APIResponse  "Invalid template"
+// @Failure      404     {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /notification-templates/{event}/{locale}/preview [post]
+func (h *NotificationTemplateHandler) Preview(c *gin.Context) {
+	var input dto.PreviewNotificationTemplateRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+			return
+		}
+	}
+
+	preview, err := h.service.Preview(c.Request.Context(), c.Param("event"), c.Param("locale"), &input)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Notification template rendered successfully", preview)
+}
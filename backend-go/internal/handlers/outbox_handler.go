@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OutboxHandler struct {
+	service services.OutboxService
+}
+
+func NewOutboxHandler(service services.OutboxService) *OutboxHandler {
+	return &OutboxHandler{service}
+}
+
+// GetAll godoc
+// @Summary      Get outbox jobs
+// @Description  Get paginated background jobs, newest first (super_admin only)
+// @Tags         outbox
+// @Produce      json
+// @Param        page    query     int     false  "Page number (default: 1)"
+// @Param        limit   query     int     false  "Items per page (default: 20)"
+// @Param        status  query     string  false  "Filter by status (PENDING, PROCESSING, DONE, DEAD)"
+// @Param        type    query     string  false  "Filter by job type (notification.deliver, activity_log.record)"
+// @Success      200     {object}  utils.APIResponse
+// @Failure      401     {object}  utils.APIResponse
+// @Failure      403     {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /outbox/jobs [get]
+func (h *OutboxHandler) GetAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	jobs, total, err := h.service.GetJobs(c.Request.Context(), c.Query("status"), c.Query("type"), page, limit)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	response := gin.H{
+		"items": jobs,
+		"meta": gin.H{
+			"page":        page,
+			"limit":       limit,
+			"total_items": total,
+			"total_pages": totalPages,
+		},
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Outbox jobs fetched successfully", response)
+}
+
+// GetByID godoc
+// @Summary      Get outbox job
+// @Description  Get a background job with its payload and last error (super_admin only)
+// @Tags         outbox
+// @Produce      json
+// @Param        id   path      int  true  "Job ID"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /outbox/jobs/{id} [get]
+func (h *OutboxHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	job, err := h.service.GetJob(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Outbox job fetched successfully", job)
+}
+
+// Replay godoc
+// @Summary      Replay dead job
+// @Description  Put a dead-lettered job back in the queue with a fresh set of attempts (super_admin only)
+// @Tags         outbox
+// @Produce      json
+// @Param        id   path      int  true  "Job ID"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      409  {object}  utils.APIResponse  "Job is not dead"
+// @Security     BearerAuth
+// @Router       /outbox/jobs/{id}/replay [post]
+func (h *OutboxHandler) Replay(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	if err := h.service.Replay(actorContext(c), uint(id)); err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Outbox job queued for replay", nil)
+}
+
+// ReplayDead godoc
+// @Summary      Replay all dead jobs
+// @Description  Put every dead-lettered job, optionally of one type, back in the queue (super_admin only)
+// @Tags         outbox
+// @Produce      json
+// @Param        type  query     string  false  "Only replay jobs of this type"
+// @Success      200   {object}  utils.APIResponse
+// @Failure      401   {object}  utils.APIResponse
+// @Failure      403   {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /outbox/jobs/replay-dead [post]
+func (h *OutboxHandler) ReplayDead(c *gin.Context) {
+	jobType := c.Query("type")
+
+	replayed, err := h.service.ReplayDead(actorContext(c), jobType)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Dead outbox jobs queued for replay", gin.H{"replayed": replayed})
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"backend-go/internal/dto"
+	"backend-go/internal/models"
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PSBDocumentHandler struct {
+	service services.SantriDocumentService
+}
+
+func NewPSBDocumentHandler(service services.SantriDocumentService) *PSBDocumentHandler {
+	return &PSBDocumentHandler{service}
+}
+
+// Upload godoc
+// @Summary      Upload a registration document
+// @Description  Upload a PSB document (KK, akta kelahiran, ijazah) for a registration identified by its registration code and birth date (public, max 5MB, JPEG/PNG/WebP/GIF/PDF)
+// @Tags         psb-documents
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        registration_code  formData  string  true  "Registration code (e.g. PSB-7KQ2M9XA)"
+// @Param        birth_date         formData  string  true  "Registrant birth date (YYYY-MM-DD)"
+// @Param        type               formData  string  true  "Document type (KARTU_KELUARGA, AKTA_KELAHIRAN, IJAZAH)"
+// @Param        file               formData  file    true  "Document file"
+// @Success      201                {object}  utils.APIResponse
+// @Failure      400                {object}  utils.APIResponse
+// @Failure      404                {object}  utils.APIResponse
+// @Failure      409                {object}  utils.APIResponse
+// @Router       /psb/documents [post]
+func (h *PSBDocumentHandler) Upload(c *gin.Context) {
+	var input dto.UploadSantriDocumentRequest
+	if err := c.ShouldBind(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	birthDate, err := time.Parse("2006-01-02", input.BirthDate)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid birth date format", "Use YYYY-MM-DD format")
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "No file uploaded", err.Error())
+		return
+	}
+	defer file.Close()
+
+	document, err := h.service.UploadDocument(c.Request.Context(), input.RegistrationCode, birthDate, models.DocumentType(input.Type), file, header)
+	if err != nil {
+		if errors.Is(err, services.ErrFileTooLarge) || errors.Is(err, services.ErrInvalidDocumentType) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "File validation failed", err.Error())
+			return
+		}
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Document uploaded successfully", document)
+}
+
+// GetByRegistrant godoc
+// @Summary      Get registrant documents
+// @Description  Get all documents uploaded by a registrant with their verification state (admin only)
+// @Tags         psb-documents
+// @Produce      json
+// @Param        id   path      int  true  "Registrant ID"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/registrants/{id}/documents [get]
+func (h *PSBDocumentHandler) GetByRegistrant(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	documents, err := h.service.GetDocuments(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Documents fetched successfully", documents)
+}
+
+// Verify godoc
+// @Summary      Verify a registrant document
+// @Description  Approve a document or ask the registrant to re-upload it with a note (admin only)
+// @Tags         psb-documents
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                              true  "Document ID"
+// @Param        input  body      dto.VerifySantriDocumentRequest  true  "Verification result"
+// @Success      200    {object}  utils.APIResponse
+// @Failure      400    {object}  utils.APIResponse
+// @Failure      401    {object}  utils.APIResponse
+// @Failure      404    {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/documents/{id}/verify [put]
+func (h *PSBDocumentHandler) Verify(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var input dto.VerifySantriDocumentRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uint)
+
+	document, err := h.service.VerifyDocument(actorContext(c), uint(id), models.DocumentStatus(input.Status), input.Note, uid)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Document verified successfully", document)
+}
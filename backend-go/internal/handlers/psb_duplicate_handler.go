@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"backend-go/internal/dto"
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PSBDuplicateHandler struct {
+	service services.SantriDuplicateService
+}
+
+func NewPSBDuplicateHandler(service services.SantriDuplicateService) *PSBDuplicateHandler {
+	return &PSBDuplicateHandler{service}
+}
+
+// GetPossibleDuplicates godoc
+// @Summary      Get possible duplicate registrations
+// @Description  Score other registrants by name, birth date, birth place, parent phone and NIK similarity and return the likely duplicates, highest score first (admin only)
+// @Tags         psb
+// @Produce      json
+// @Param        id   path      int  true  "Registrant ID"
+// @Success      200  {object}  utils.APIResponse{data=[]dto.PossibleDuplicate}
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/registrants/{id}/possible-duplicates [get]
+func (h *PSBDuplicateHandler) GetPossibleDuplicates(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	duplicates, err := h.service.FindPossibleDuplicates(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Possible duplicates fetched successfully", duplicates)
+}
+
+// Merge godoc
+// @Summary      Merge a duplicate registration
+// @Description  Merge the duplicate registration into this one, keeping the documents and status history of both. The duplicate is deleted (admin only)
+// @Tags         psb
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                     true  "Registrant ID to keep"
+// @Param        input  body      dto.MergeSantriRequest  true  "Duplicate to merge"
+// @Success      200    {object}  utils.APIResponse
+// @Failure      400    {object}  utils.APIResponse
+// @Failure      401    {object}  utils.APIResponse
+// @Failure      404    {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/registrants/{id}/merge [post]
+func (h *PSBDuplicateHandler) Merge(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var input dto.MergeSantriRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uint)
+
+	santri, err := h.service.Merge(actorContext(c), uint(id), input.DuplicateID, uid)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Registrations merged successfully", santri)
+}
@@ -5,6 +5,7 @@ import (
 	"backend-go/internal/models"
 	"backend-go/internal/services"
 	"backend-go/internal/utils"
+	"bytes"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -14,22 +15,24 @@ import (
 )
 
 type PSBHandler struct {
-	service services.PSBService
+	service    services.PSBService
+	pdfService services.PDFService
 }
 
-func NewPSBHandler(service services.PSBService) *PSBHandler {
-	return &PSBHandler{service}
+func NewPSBHandler(service services.PSBService, pdfService services.PDFService) *PSBHandler {
+	return &PSBHandler{service, pdfService}
 }
 
 // Register godoc
 // @Summary      Register new santri
-// @Description  Register a new santri for PSB (public)
+// @Description  Register a new santri for PSB in the currently open admission wave. The NIK must agree with the birth date and gender (public)
 // @Tags         psb
 // @Accept       json
 // @Produce      json
 // @Param        santri  body      dto.RegisterSantriRequest  true  "Registration data"
 // @Success      201     {object}  utils.APIResponse
 // @Failure      400     {object}  utils.APIResponse
+// @Failure      409     {object}  utils.APIResponse
 // @Router       /psb/register [post]
 func (h *PSBHandler) Register(c *gin.Context) {
 	var input dto.RegisterSantriRequest
@@ -45,6 +48,12 @@ func (h *PSBHandler) Register(c *gin.Context) {
 		return
 	}
 
+	// The NIK encodes the birth date and gender, so they have to agree
+	if errs := utils.ValidateNIK(input.NIK, birthDate, input.Gender); len(errs) > 0 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", errs)
+		return
+	}
+
 	// Convert DTO to Model
 	santri := &models.Santri{
 		FullName:    input.FullName,
@@ -55,35 +64,73 @@ func (h *PSBHandler) Register(c *gin.Context) {
 		Address:     input.Address,
 		ParentName:  input.FatherName, // Using father name as parent name
 		ParentPhone: input.ParentPhone,
+		ParentEmail: input.ParentEmail,
+		Locale:      input.Locale,
 		PhotoURL:    input.PhotoURL,
 		Status:      models.StatusPending,
 	}
+	if santri.Locale == "" {
+		santri.Locale = models.LocaleID
+	}
+	santri.Guardians, santri.PriorEducation = santriBackgroundFromInput(
+		input.FatherName, input.FatherJob, input.MotherName, input.MotherJob,
+		input.SchoolOrigin, input.SchoolAddress, input.GraduationYear,
+	)
 
 	if err := h.service.RegisterSantri(c.Request.Context(), santri); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Send confirmation email asynchronously
-	if input.ParentPhone != "" {
-		registrationID := fmt.Sprintf("REG-%d", santri.ID)
-		services.SendPSBConfirmationAsync(input.ParentPhone, input.FullName, registrationID)
+	utils.SuccessResponse(c, http.StatusCreated, "Registration successful", santri)
+}
+
+// GetStatus godoc
+// @Summary      Check registration status
+// @Description  Look up a registration by its code and the santri's birth date. Returns the status, outstanding documents, admin notes and test schedule (public, rate-limited)
+// @Tags         psb
+// @Produce      json
+// @Param        code        query     string  true  "Registration code (e.g. PSB-7KQ2M9XA)"
+// @Param        birth_date  query     string  true  "Santri birth date (YYYY-MM-DD)"
+// @Success      200         {object}  utils.APIResponse{data=dto.PSBStatusResponse}
+// @Failure      400         {object}  utils.APIResponse
+// @Failure      404         {object}  utils.APIResponse
+// @Failure      429         {object}  utils.APIResponse
+// @Router       /psb/status [get]
+func (h *PSBHandler) GetStatus(c *gin.Context) {
+	var input dto.PSBStatusQuery
+	if err := c.ShouldBindQuery(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	birthDate, err := time.Parse("2006-01-02", input.BirthDate)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid birth date format", "Use YYYY-MM-DD format")
+		return
 	}
 
-	utils.SuccessResponse(c, http.StatusCreated, "Registration successful", santri)
+	status, err := h.service.GetPublicStatus(c.Request.Context(), input.Code, birthDate)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Registration status fetched successfully", status)
 }
 
 // GetAll godoc
 // @Summary      Get all registrants
-// @Description  Get all PSB registrants with optional status filter and pagination (admin only)
+// @Description  Get all PSB registrants with optional status and wave filters and pagination (admin only)
 // @Tags         psb
 // @Produce      json
-// @Param        status  query     string  false  "Filter by status (PENDING, VERIFIED, ACCEPTED, REJECTED)"
-// @Param        page    query     int     false  "Page number (default: 1)"
-// @Param        limit   query     int     false  "Items per page (default: 10)"
-// @Success      200     {object}  utils.APIResponse
-// @Failure      400     {object}  utils.APIResponse
-// @Failure      401     {object}  utils.APIResponse
+// @Param        status   query     string  false  "Filter by status (PENDING, VERIFIED, ACCEPTED, REJECTED)"
+// @Param        wave_id  query     int     false  "Filter by admission wave ID"
+// @Param        page     query     int     false  "Page number (default: 1)"
+// @Param        limit    query     int     false  "Items per page (default: 10)"
+// @Success      200      {object}  utils.APIResponse
+// @Failure      400      {object}  utils.APIResponse
+// @Failure      401      {object}  utils.APIResponse
 // @Security     BearerAuth
 // @Router       /psb/registrants [get]
 func (h *PSBHandler) GetAll(c *gin.Context) {
@@ -108,9 +155,15 @@ func (h *PSBHandler) GetAll(c *gin.Context) {
 		}
 	}
 
+	waveID, err := parseWaveIDQuery(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wave ID", err.Error())
+		return
+	}
+
 	// If pagination params provided, use paginated query
 	if page > 0 && limit > 0 {
-		santris, total, err := h.service.GetRegistrantsPaginated(c.Request.Context(), page, limit, status)
+		santris, total, err := h.service.GetRegistrantsPaginated(c.Request.Context(), page, limit, status, waveID)
 		if err != nil {
 			utils.ResponseWithError(c, err)
 			return
@@ -139,15 +192,7 @@ func (h *PSBHandler) GetAll(c *gin.Context) {
 	}
 
 	// Non-paginated query (backward compatible)
-	var santris []models.Santri
-	var err error
-
-	if status != "" {
-		santris, err = h.service.GetRegistrantsByStatus(c.Request.Context(), status)
-	} else {
-		santris, err = h.service.GetAllRegistrants(c.Request.Context())
-	}
-
+	santris, err := h.service.GetRegistrants(c.Request.Context(), status, waveID)
 	if err != nil {
 		utils.ResponseWithError(c, err)
 		return
@@ -156,13 +201,50 @@ func (h *PSBHandler) GetAll(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, "Data fetched successfully", santris)
 }
 
+// santriBackgroundFromInput maps the PSB form's parent and school fields to guardian and prior education records.
+// Guardians without a name and an empty school are left out, so partial updates only touch what was sent.
+func santriBackgroundFromInput(fatherName, fatherJob, motherName, motherJob, schoolOrigin, schoolAddress, graduationYear string) ([]models.Guardian, *models.PriorEducation) {
+	var guardians []models.Guardian
+	if fatherName != "" || fatherJob != "" {
+		guardians = append(guardians, models.Guardian{Relation: models.GuardianFather, Name: fatherName, Job: fatherJob})
+	}
+	if motherName != "" || motherJob != "" {
+		guardians = append(guardians, models.Guardian{Relation: models.GuardianMother, Name: motherName, Job: motherJob})
+	}
+
+	var education *models.PriorEducation
+	if schoolOrigin != "" || schoolAddress != "" || graduationYear != "" {
+		year, _ := strconv.Atoi(graduationYear) // Already validated as numeric by the DTO
+		education = &models.PriorEducation{
+			SchoolName:     schoolOrigin,
+			SchoolAddress:  schoolAddress,
+			GraduationYear: year,
+		}
+	}
+
+	return guardians, education
+}
+
+// parseWaveIDQuery reads the optional wave_id query parameter, returning 0 when absent
+func parseWaveIDQuery(c *gin.Context) (uint, error) {
+	waveIDStr := c.Query("wave_id")
+	if waveIDStr == "" {
+		return 0, nil
+	}
+	waveID, err := strconv.ParseUint(waveIDStr, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(waveID), nil
+}
+
 // GetDetail godoc
 // @Summary      Get registrant by ID
-// @Description  Get a single registrant's detail (admin only)
+// @Description  Get a single registrant's detail, with warnings where the stored NIK disagrees with the birth date or gender (admin only)
 // @Tags         psb
 // @Produce      json
 // @Param        id   path      int  true  "Registrant ID"
-// @Success      200  {object}  utils.APIResponse
+// @Success      200  {object}  utils.APIResponse{data=dto.SantriDetailResponse}
 // @Failure      400  {object}  utils.APIResponse
 // @Failure      401  {object}  utils.APIResponse
 // @Failure      404  {object}  utils.APIResponse
@@ -181,7 +263,11 @@ func (h *PSBHandler) GetDetail(c *gin.Context) {
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Detail fetched successfully", santri)
+	// Records stored before NIK validation may disagree with their NIK; show that instead of failing
+	utils.SuccessResponse(c, http.StatusOK, "Detail fetched successfully", dto.SantriDetailResponse{
+		Santri:   santri,
+		Warnings: append([]utils.FieldError{}, utils.ValidateNIK(santri.NIK, santri.BirthDate, santri.Gender)...),
+	})
 }
 
 // Update godoc
@@ -219,8 +305,19 @@ func (h *PSBHandler) Update(c *gin.Context) {
 		Address:     input.Address,
 		ParentName:  input.ParentName,
 		ParentPhone: input.ParentPhone,
+		ParentEmail: input.ParentEmail,
+		Locale:      input.Locale,
 		PhotoURL:    input.PhotoURL,
 	}
+	santri.Guardians, santri.PriorEducation = santriBackgroundFromInput(
+		input.FatherName, input.FatherJob, input.MotherName, input.MotherJob,
+		input.SchoolOrigin, input.SchoolAddress, input.GraduationYear,
+	)
+
+	// Keep the legacy parent name in sync with the father's name
+	if santri.ParentName == "" && input.FatherName != "" {
+		santri.ParentName = input.FatherName
+	}
 
 	// Parse birth date if provided
 	if input.BirthDate != "" {
@@ -232,16 +329,32 @@ func (h *PSBHandler) Update(c *gin.Context) {
 		santri.BirthDate = birthDate
 	}
 
-	if err := h.service.UpdateSantri(c.Request.Context(), uint(id), santri); err != nil {
-		utils.ResponseWithError(c, err)
-		return
+	// Changing the NIK, birth date or gender must leave the three consistent
+	if input.NIK != "" || input.BirthDate != "" || input.Gender != "" {
+		existing, err := h.service.GetRegistrantByID(c.Request.Context(), uint(id))
+		if err != nil {
+			utils.ResponseWithError(c, err)
+			return
+		}
+		nik, birthDate, gender := existing.NIK, existing.BirthDate, existing.Gender
+		if input.NIK != "" {
+			nik = input.NIK
+		}
+		if input.BirthDate != "" {
+			birthDate = santri.BirthDate
+		}
+		if input.Gender != "" {
+			gender = input.Gender
+		}
+		if errs := utils.ValidateNIK(nik, birthDate, gender); len(errs) > 0 {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", errs)
+			return
+		}
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		entityID := uint(id)
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionUpdate, "santri", &entityID, nil, santri, c.ClientIP(), c.GetHeader("User-Agent"))
+	if err := h.service.UpdateSantri(actorContext(c), uint(id), santri); err != nil {
+		utils.ResponseWithError(c, err)
+		return
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, "Santri updated successfully", nil)
@@ -266,18 +379,11 @@ func (h *PSBHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeleteSantri(c.Request.Context(), uint(id)); err != nil {
+	if err := h.service.DeleteSantri(actorContext(c), uint(id)); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		entityID := uint(id)
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionDelete, "santri", &entityID, nil, nil, c.ClientIP(), c.GetHeader("User-Agent"))
-	}
-
 	utils.SuccessResponse(c, http.StatusOK, "Santri deleted successfully", nil)
 }
 
@@ -308,41 +414,32 @@ func (h *PSBHandler) UpdateStatus(c *gin.Context) {
 		return
 	}
 
-	// Get santri data for email notification before update
-	santri, _ := h.service.GetRegistrantByID(c.Request.Context(), uint(id))
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uint)
 
-	if err := h.service.UpdateStatus(c.Request.Context(), uint(id), input.Status); err != nil {
+	if err := h.service.UpdateStatus(actorContext(c), uint(id), input.Status, uid, input.Reason); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity and send email notification
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		entityID := uint(id)
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionUpdate, "santri", &entityID, nil, map[string]string{"status": input.Status}, c.ClientIP(), c.GetHeader("User-Agent"))
-	}
-
-	// Send status update email if santri data is available
-	if santri != nil && santri.ParentPhone != "" {
-		services.SendStatusUpdateAsync(santri.ParentPhone, santri.FullName, input.Status)
-	}
-
 	utils.SuccessResponse(c, http.StatusOK, "Status updated successfully", nil)
 }
 
 // Verify godoc
 // @Summary      Verify and accept santri
-// @Description  Verify a registrant and accept them as santri (admin only)
+// @Description  Accept a verified registrant as santri, assigning class, entry year and a generated NIS. Send an Idempotency-Key header to make retries safe (admin only)
 // @Tags         psb
 // @Accept       json
 // @Produce      json
-// @Param        id     path      int                      true  "Registrant ID"
-// @Param        input  body      dto.VerifySantriRequest  true  "Verification data"
-// @Success      200    {object}  utils.APIResponse
-// @Failure      400    {object}  utils.APIResponse
-// @Failure      401    {object}  utils.APIResponse
-// @Failure      404    {object}  utils.APIResponse
+// @Param        id               path      int                      true   "Registrant ID"
+// @Param        Idempotency-Key  header    string                   false  "Unique key per acceptance attempt"
+// @Param        input            body      dto.VerifySantriRequest  true   "Verification data"
+// @Success      200              {object}  utils.APIResponse
+// @Failure      400              {object}  utils.APIResponse
+// @Failure      401              {object}  utils.APIResponse
+// @Failure      404              {object}  utils.APIResponse
+// @Failure      409              {object}  utils.APIResponse
+// @Failure      422              {object}  utils.APIResponse
 // @Security     BearerAuth
 // @Router       /psb/registrants/{id}/verify [put]
 func (h *PSBHandler) Verify(c *gin.Context) {
@@ -358,17 +455,159 @@ func (h *PSBHandler) Verify(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.VerifySantri(c.Request.Context(), uint(id), input.NIS, input.Class, input.EntryYear); err != nil {
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uint)
+
+	santri, err := h.service.VerifySantri(actorContext(c), uint(id), input.Class, input.EntryYear, uid)
+	if err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		entityID := uint(id)
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionVerify, "santri", &entityID, nil, map[string]string{"nis": input.NIS, "class": input.Class}, c.ClientIP(), c.GetHeader("User-Agent"))
+	utils.SuccessResponse(c, http.StatusOK, "Santri verified and accepted successfully", santri)
+}
+
+// GetStatusHistory godoc
+// @Summary      Get registrant status history
+// @Description  Get the status change history of a registrant, newest first (admin only)
+// @Tags         psb
+// @Produce      json
+// @Param        id   path      int  true  "Registrant ID"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/registrants/{id}/history [get]
+func (h *PSBHandler) GetStatusHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	history, err := h.service.GetStatusHistory(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Status history fetched successfully", history)
+}
+
+// DownloadPublicRegistrationCard godoc
+// @Summary      Download registration card
+// @Description  Download the PDF registration card using the registration code and the santri's birth date (public, rate-limited)
+// @Tags         psb
+// @Produce      application/pdf
+// @Param        code        query     string  true  "Registration code (e.g. PSB-7KQ2M9XA)"
+// @Param        birth_date  query     string  true  "Santri birth date (YYYY-MM-DD)"
+// @Success      200         {file}    file
+// @Failure      400         {object}  utils.APIResponse
+// @Failure      404         {object}  utils.APIResponse
+// @Failure      429         {object}  utils.APIResponse
+// @Router       /psb/status/card [get]
+func (h *PSBHandler) DownloadPublicRegistrationCard(c *gin.Context) {
+	var input dto.PSBStatusQuery
+	if err := c.ShouldBindQuery(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	birthDate, err := time.Parse("2006-01-02", input.BirthDate)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid birth date format", "Use YYYY-MM-DD format")
+		return
+	}
+
+	santri, err := h.service.LookupRegistration(c.Request.Context(), input.Code, birthDate)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	buf, err := h.pdfService.GenerateRegistrationCard(c.Request.Context(), santri)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	sendPDF(c, fmt.Sprintf("kartu_pendaftaran_%s.pdf", santri.RegistrationCode), buf)
+}
+
+// DownloadRegistrationCard godoc
+// @Summary      Download registrant registration card
+// @Description  Download the PDF registration card of a registrant (admin only)
+// @Tags         psb
+// @Produce      application/pdf
+// @Param        id   path      int  true  "Registrant ID"
+// @Success      200  {file}    file
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/registrants/{id}/registration-card [get]
+func (h *PSBHandler) DownloadRegistrationCard(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	santri, err := h.service.GetRegistrantByID(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	buf, err := h.pdfService.GenerateRegistrationCard(c.Request.Context(), santri)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	sendPDF(c, fmt.Sprintf("kartu_pendaftaran_%s.pdf", santri.RegistrationCode), buf)
+}
+
+// DownloadAcceptanceLetter godoc
+// @Summary      Download acceptance letter
+// @Description  Download the PDF acceptance letter of an accepted registrant (admin only)
+// @Tags         psb
+// @Produce      application/pdf
+// @Param        id   path      int  true  "Registrant ID"
+// @Success      200  {file}    file
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/registrants/{id}/acceptance-letter [get]
+func (h *PSBHandler) DownloadAcceptanceLetter(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	santri, err := h.service.GetRegistrantByID(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Santri verified and accepted successfully", nil)
+	buf, err := h.pdfService.GenerateAcceptanceLetter(c.Request.Context(), santri)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	sendPDF(c, fmt.Sprintf("surat_penerimaan_%s.pdf", *santri.NIS), buf)
+}
+
+// sendPDF writes a generated PDF as a file download
+func sendPDF(c *gin.Context, filename string, buf *bytes.Buffer) {
+	c.Header("Content-Description", "File Transfer")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Header("Content-Transfer-Encoding", "binary")
+
+	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
 }
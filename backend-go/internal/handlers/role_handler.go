@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"backend-go/internal/dto"
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoleHandler struct {
+	service services.RoleService
+}
+
+func NewRoleHandler(service services.RoleService) *RoleHandler {
+	return &RoleHandler{service}
+}
+
+// GetAll godoc
+// @Summary      Get roles
+// @Description  List all roles with their permissions
+// @Tags         roles
+// @Produce      json
+// @Success      200  {object}  utils.APIResponse{data=[]models.Role}
+// @Failure      403  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /roles [get]
+func (h *RoleHandler) GetAll(c *gin.Context) {
+	roles, err := h.service.GetAll(c.Request.Context())
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Roles fetched successfully", roles)
+}
+
+// GetByID godoc
+// @Summary      Get role
+// @Description  Get a role with its permissions
+// @Tags         roles
+// @Produce      json
+// @Param        id   path      int  true  "Role ID"
+// @Success      200  {object}  utils.APIResponse{data=models.Role}
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /roles/{id} [get]
+func (h *RoleHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	role, err := h.service.GetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Role fetched successfully", role)
+}
+
+// Create godoc
+// @Summary      Create role
+// @Description  Create a role from permission codes, e.g. psb.read. The name may only contain lowercase letters, digits and underscores.
+// @Tags         roles
+// @Accept       json
+// @Produce      json
+// @Param        input  body      dto.CreateRoleRequest  true  "Role"
+// @Success      201    {object}  utils.APIResponse{data=models.Role}
+// @Failure      400    {object}  utils.APIResponse
+// @Failure      409    {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /roles [post]
+func (h *RoleHandler) Create(c *gin.Context) {
+	var input dto.CreateRoleRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	role, err := h.service.Create(actorContext(c), input)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Role created successfully", role)
+}
+
+// Update godoc
+// @Summary      Update role
+// @Description  Replace the description and permissions of a role. Changing the permissions logs out the admins with the role, so they sign in again with the new permissions. The permissions of super_admin cannot be changed.
+// @Tags         roles
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                    true  "Role ID"
+// @Param        input  body      dto.UpdateRoleRequest  true  "Role"
+// @Success      200    {object}  utils.APIResponse{data=models.Role}
+// @Failure      400    {object}  utils.APIResponse
+// @Failure      404    {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /roles/{id} [put]
+func (h *RoleHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var input dto.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	role, err := h.service.Update(actorContext(c), uint(id), input)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Role updated successfully", role)
+}
+
+// Delete godoc
+// @Summary      Delete role
+// @Description  Delete a role that no admin has. Built-in roles cannot be deleted.
+// @Tags         roles
+// @Produce      json
+// @Param        id   path      int  true  "Role ID"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      409  {object}  utils.APIResponse  "Role is still assigned"
+// @Security     BearerAuth
+// @Router       /roles/{id} [delete]
+func (h *RoleHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	if err := h.service.Delete(actorContext(c), uint(id)); err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Role deleted successfully", nil)
+}
+
+// GetPermissions godoc
+// @Summary      Get permissions
+// @Description  List the permissions that can be granted to roles
+// @Tags         roles
+// @Produce      json
+// @Success      200  {object}  utils.APIResponse{data=[]models.Permission}
+// @Failure      403  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /permissions [get]
+func (h *RoleHandler) GetPermissions(c *gin.Context) {
+	permissions, err := h.service.GetPermissions(c.Request.Context())
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Permissions fetched successfully", permissions)
+}
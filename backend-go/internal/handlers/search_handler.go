@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"backend-go/internal/consts"
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"net/http"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxSearchQueryLength keeps cache keys and query plans bounded
+const maxSearchQueryLength = 100
+
+type SearchHandler struct {
+	service services.SearchService
+}
+
+func NewSearchHandler(service services.SearchService) *SearchHandler {
+	return &SearchHandler{service}
+}
+
+// Search godoc
+// @Summary      Search the site
+// @Description  Full-text search across published articles, galleries, photo captions, videos and achievements, most relevant first. Each hit has a type, an ID (articles also a slug, photos the ID of their gallery), a thumbnail and a snippet with the matches wrapped in <mark>. Facets count the hits of every type, even when type limits the items to one.
+// @Tags         search
+// @Produce      json
+// @Param        q      query     string  true   "Search query"
+// @Param        type   query     string  false  "Only return hits of this type (article, gallery, photo, video, achievement)"
+// @Param        page   query     int     false  "Page number (default: 1)"
+// @Param        limit  query     int     false  "Items per page (default: 10)"
+// @Success      200    {object}  utils.APIResponse{data=utils.PaginatedData{items=[]models.SearchHit}}
+// @Failure      400    {object}  utils.APIResponse
+// @Router       /search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	query := c.Query("q")
+	if query == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Query parameter 'q' is required", nil)
+		return
+	}
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Query is too long", "q may be at most 100 characters")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.Query("page"))
+	if page < 1 {
+		page = consts.DefaultPage
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	if limit < 1 {
+		limit = consts.DefaultPageLimit
+	} else if limit > consts.MaxPageLimit {
+		limit = consts.MaxPageLimit
+	}
+
+	result, err := h.service.Search(c.Request.Context(), query, c.Query("type"), page, limit)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponsePaginatedWithFacets(c, http.StatusOK, "Search results fetched successfully", result.Hits, result.Facets, page, limit, result.Total)
+}
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"backend-go/internal/dto"
+	"backend-go/internal/models"
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SelectionHandler struct {
+	service services.SelectionService
+}
+
+func NewSelectionHandler(service services.SelectionService) *SelectionHandler {
+	return &SelectionHandler{service}
+}
+
+// GetRubric godoc
+// @Summary      Get a wave's selection rubric
+// @Description  Get the weighted scoring components used to rank the registrants of an admission wave (admin only)
+// @Tags         psb-selection
+// @Produce      json
+// @Param        id   path      int  true  "Wave ID"
+// @Success      200  {object}  utils.APIResponse{data=[]models.SelectionComponent}
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/waves/{id}/rubric [get]
+func (h *SelectionHandler) GetRubric(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	components, err := h.service.GetRubric(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Selection rubric retrieved successfully", components)
+}
+
+// UpdateRubric godoc
+// @Summary      Update a wave's selection rubric
+// @Description  Replace the scoring components of an admission wave. Weights are percentages and must add up to 100. Components left out are removed together with their scores (admin only)
+// @Tags         psb-selection
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                               true  "Wave ID"
+// @Param        input  body      dto.UpdateSelectionRubricRequest  true  "Rubric components"
+// @Success      200    {object}  utils.APIResponse{data=[]models.SelectionComponent}
+// @Failure      400    {object}  utils.APIResponse
+// @Failure      401    {object}  utils.APIResponse
+// @Failure      404    {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/waves/{id}/rubric [put]
+func (h *SelectionHandler) UpdateRubric(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var input dto.UpdateSelectionRubricRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	components := make([]models.SelectionComponent, 0, len(input.Components))
+	for _, component := range input.Components {
+		components = append(components, models.SelectionComponent{
+			ID:       component.ID,
+			Name:     component.Name,
+			Weight:   component.Weight,
+			MaxScore: component.MaxScore,
+		})
+	}
+
+	updated, err := h.service.UpdateRubric(actorContext(c), uint(id), components)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Selection rubric updated successfully", updated)
+}
+
+// GetScores godoc
+// @Summary      Get a registrant's selection scores
+// @Description  Get the scores entered for a registrant, one per rubric component (admin only)
+// @Tags         psb-selection
+// @Produce      json
+// @Param        id   path      int  true  "Registrant ID"
+// @Success      200  {object}  utils.APIResponse{data=[]models.SelectionScore}
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/registrants/{id}/scores [get]
+func (h *SelectionHandler) GetScores(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	scores, err := h.service.GetScores(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Selection scores retrieved successfully", scores)
+}
+
+// UpdateScores godoc
+// @Summary      Enter a registrant's selection scores
+// @Description  Enter or correct scores for the rubric components of the registrant's wave. Only allowed while the registration is pending or verified (admin only)
+// @Tags         psb-selection
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                               true  "Registrant ID"
+// @Param        input  body      dto.UpdateSelectionScoresRequest  true  "Scores"
+// @Success      200    {object}  utils.APIResponse{data=[]models.SelectionScore}
+// @Failure      400    {object}  utils.APIResponse
+// @Failure      401    {object}  utils.APIResponse
+// @Failure      404    {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/registrants/{id}/scores [put]
+func (h *SelectionHandler) UpdateScores(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var input dto.UpdateSelectionScoresRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uint)
+
+	scores := make([]models.SelectionScore, 0, len(input.Scores))
+	for _, score := range input.Scores {
+		scores = append(scores, models.SelectionScore{ComponentID: score.ComponentID, Score: *score.Score})
+	}
+
+	updated, err := h.service.UpdateScores(actorContext(c), uint(id), scores, uid)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Selection scores updated successfully", updated)
+}
+
+// GetRanking godoc
+// @Summary      Get a wave's selection ranking
+// @Description  Rank the verified registrants of a wave by weighted score, separately for each gender, and mark who falls within the remaining quota (admin only)
+// @Tags         psb-selection
+// @Produce      json
+// @Param        id   path      int  true  "Wave ID"
+// @Success      200  {object}  utils.APIResponse{data=dto.WaveRankingResponse}
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/waves/{id}/ranking [get]
+func (h *SelectionHandler) GetRanking(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	ranking, err := h.service.GetRanking(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Selection ranking retrieved successfully", ranking)
+}
+
+// RunSelection godoc
+// @Summary      Run the selection for a wave
+// @Description  Accept the top N verified registrants of each gender (by default the remaining quota) with generated NIS numbers, and optionally reject the rest, in a single transaction. Send an Idempotency-Key header to make retries safe (admin only)
+// @Tags         psb-selection
+// @Accept       json
+// @Produce      json
+// @Param        id               path      int                      true   "Wave ID"
+// @Param        Idempotency-Key  header    string                   false  "Unique key for safe retries"
+// @Param        input            body      dto.RunSelectionRequest  true   "Selection options"
+// @Success      200              {object}  utils.APIResponse{data=dto.SelectionResult}
+// @Failure      400              {object}  utils.APIResponse
+// @Failure      401              {object}  utils.APIResponse
+// @Failure      404              {object}  utils.APIResponse
+// @Failure      409              {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/waves/{id}/selection [post]
+func (h *SelectionHandler) RunSelection(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var input dto.RunSelectionRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uint)
+
+	result, err := h.service.RunSelection(actorContext(c), uint(id), input, uid)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Selection completed successfully", result)
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SessionHandler struct {
+	service services.SessionService
+}
+
+func NewSessionHandler(service services.SessionService) *SessionHandler {
+	return &SessionHandler{service}
+}
+
+// GetMine godoc
+// @Summary      Get my sessions
+// @Description  List the logged-in admin's active sessions, one per login, with the device they were started from. The session of this request is marked as current.
+// @Tags         sessions
+// @Produce      json
+// @Success      200  {object}  utils.APIResponse{data=[]dto.SessionResponse}
+// @Failure      401  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /me/sessions [get]
+func (h *SessionHandler) GetMine(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uint)
+
+	sessions, err := h.service.GetActiveSessions(c.Request.Context(), uid, c.GetString("session_id"))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Sessions fetched successfully", sessions)
+}
+
+// Revoke godoc
+// @Summary      Revoke my session
+// @Description  Log out one of the logged-in admin's sessions, e.g. a lost device. Its tokens stop working immediately.
+// @Tags         sessions
+// @Produce      json
+// @Param        id   path      string  true  "Session ID"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /me/sessions/{id} [delete]
+func (h *SessionHandler) Revoke(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uint)
+	sessionID := c.Param("id")
+
+	if err := h.service.Revoke(actorContext(c), uid, sessionID); err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Session revoked successfully", nil)
+}
+
+// RevokeOthers godoc
+// @Summary      Revoke my other sessions
+// @Description  Log out every session of the logged-in admin except the one of this request
+// @Tags         sessions
+// @Produce      json
+// @Success      200  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /me/sessions [delete]
+func (h *SessionHandler) RevokeOthers(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uint)
+
+	revoked, err := h.service.RevokeOthers(actorContext(c), uid, c.GetString("session_id"))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Other sessions revoked successfully", gin.H{"revoked": revoked})
+}
+
+// RevokeAllForUser godoc
+// @Summary      Revoke all sessions of an admin
+// @Description  Log an admin out everywhere, e.g. when the account is compromised. Their tokens stop working immediately (Super Admin only)
+// @Tags         sessions
+// @Produce      json
+// @Param        id   path      int  true  "Admin ID"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      403  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /admins/{id}/sessions [delete]
+func (h *SessionHandler) RevokeAllForUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	revoked, err := h.service.RevokeAllForUser(actorContext(c), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Sessions revoked successfully", gin.H{"revoked": revoked})
+}
@@ -42,17 +42,11 @@ func (h *TagHandler) Create(c *gin.Context) {
 		Name: input.Name,
 	}
 
-	if err := h.service.CreateTag(c.Request.Context(), tag); err != nil {
+	if err := h.service.CreateTag(actorContext(c), tag); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionCreate, "tag", &tag.ID, nil, tag, c.ClientIP(), c.GetHeader("User-Agent"))
-	}
-
 	utils.SuccessResponse(c, http.StatusCreated, "Tag created successfully", tag)
 }
 
@@ -129,18 +123,11 @@ func (h *TagHandler) Update(c *gin.Context) {
 		Name: input.Name,
 	}
 
-	if err := h.service.UpdateTag(c.Request.Context(), uint(id), tag); err != nil {
+	if err := h.service.UpdateTag(actorContext(c), uint(id), tag); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		entityID := uint(id)
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionUpdate, "tag", &entityID, nil, tag, c.ClientIP(), c.GetHeader("User-Agent"))
-	}
-
 	utils.SuccessResponse(c, http.StatusOK, "Tag updated successfully", nil)
 }
 
@@ -163,17 +150,10 @@ func (h *TagHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeleteTag(c.Request.Context(), uint(id)); err != nil {
+	if err := h.service.DeleteTag(actorContext(c), uint(id)); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		entityID := uint(id)
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionDelete, "tag", &entityID, nil, nil, c.ClientIP(), c.GetHeader("User-Agent"))
-	}
-
 	utils.SuccessResponse(c, http.StatusOK, "Tag deleted successfully", nil)
 }
@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	"backend-go/internal/dto"
+	"backend-go/internal/models"
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TestSessionHandler struct {
+	service services.TestSessionService
+}
+
+func NewTestSessionHandler(service services.TestSessionService) *TestSessionHandler {
+	return &TestSessionHandler{service}
+}
+
+// Create godoc
+// @Summary      Create a test session
+// @Description  Schedule an entrance test or interview session for an admission wave. Leave gender empty for a mixed session (admin only)
+// @Tags         psb-test-sessions
+// @Accept       json
+// @Produce      json
+// @Param        session  body      dto.CreateTestSessionRequest  true  "Session data"
+// @Success      201      {object}  utils.APIResponse{data=models.TestSession}
+// @Failure      400      {object}  utils.APIResponse
+// @Failure      401      {object}  utils.APIResponse
+// @Failure      404      {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/test-sessions [post]
+func (h *TestSessionHandler) Create(c *gin.Context) {
+	var req dto.CreateTestSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	session := &models.TestSession{
+		WaveID:   req.WaveID,
+		Kind:     models.TestSessionKind(req.Kind),
+		Name:     req.Name,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Room:     req.Room,
+		Capacity: req.Capacity,
+		Gender:   req.Gender,
+		Examiner: req.Examiner,
+		Notes:    req.Notes,
+	}
+
+	if err := h.service.CreateSession(actorContext(c), session); err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Test session created successfully", session)
+}
+
+// GetAll godoc
+// @Summary      Get test sessions
+// @Description  Get the test and interview sessions of an admission wave with the number of assigned registrants (admin only)
+// @Tags         psb-test-sessions
+// @Produce      json
+// @Param        wave_id  query     int     true   "Admission wave ID"
+// @Param        kind     query     string  false  "Filter by kind (TEST, INTERVIEW)"
+// @Success      200      {object}  utils.APIResponse{data=[]models.TestSession}
+// @Failure      400      {object}  utils.APIResponse
+// @Failure      401      {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/test-sessions [get]
+func (h *TestSessionHandler) GetAll(c *gin.Context) {
+	waveID, err := strconv.Atoi(c.Query("wave_id"))
+	if err != nil || waveID <= 0 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wave_id", "wave_id is required")
+		return
+	}
+
+	kind := c.Query("kind")
+	if kind != "" && kind != string(models.TestSessionWritten) && kind != string(models.TestSessionInterview) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid kind", "kind must be TEST or INTERVIEW")
+		return
+	}
+
+	sessions, err := h.service.GetSessions(c.Request.Context(), uint(waveID), kind)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Test sessions retrieved successfully", sessions)
+}
+
+// GetByID godoc
+// @Summary      Get test session by ID
+// @Description  Get a test or interview session with its assigned registrants (admin only)
+// @Tags         psb-test-sessions
+// @Produce      json
+// @Param        id   path      int  true  "Session ID"
+// @Success      200  {object}  utils.APIResponse{data=models.TestSession}
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/test-sessions/{id} [get]
+func (h *TestSessionHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	session, err := h.service.GetSessionByID(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Test session retrieved successfully", session)
+}
+
+// Update godoc
+// @Summary      Update a test session
+// @Description  Replace the details of a test session. The capacity and gender cannot leave out registrants that are already assigned (admin only)
+// @Tags         psb-test-sessions
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                           true  "Session ID"
+// @Param        session  body      dto.UpdateTestSessionRequest  true  "Session data"
+// @Success      200      {object}  utils.APIResponse{data=models.TestSession}
+// @Failure      400      {object}  utils.APIResponse
+// @Failure      401      {object}  utils.APIResponse
+// @Failure      404      {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/test-sessions/{id} [put]
+func (h *TestSessionHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req dto.UpdateTestSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	session, err := h.service.UpdateSession(actorContext(c), uint(id), &models.TestSession{
+		Kind:     models.TestSessionKind(req.Kind),
+		Name:     req.Name,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Room:     req.Room,
+		Capacity: req.Capacity,
+		Gender:   req.Gender,
+		Examiner: req.Examiner,
+		Notes:    req.Notes,
+	})
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Test session updated successfully", session)
+}
+
+// Delete godoc
+// @Summary      Delete a test session
+// @Description  Delete a test session. Its registrants become unassigned (admin only)
+// @Tags         psb-test-sessions
+// @Produce      json
+// @Param        id   path      int  true  "Session ID"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/test-sessions/{id} [delete]
+func (h *TestSessionHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	if err := h.service.DeleteSession(actorContext(c), uint(id)); err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Test session deleted successfully", nil)
+}
+
+// AutoAssign godoc
+// @Summary      Auto-assign registrants to sessions
+// @Description  Place the wave's verified registrants that have no session of the given kind yet into upcoming sessions, in registration order, respecting gender and capacity (admin only)
+// @Tags         psb-test-sessions
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                                true  "Wave ID"
+// @Param        input  body      dto.AutoAssignTestSessionsRequest  true  "Session kind"
+// @Success      200    {object}  utils.APIResponse{data=dto.AutoAssignResult}
+// @Failure      400    {object}  utils.APIResponse
+// @Failure      401    {object}  utils.APIResponse
+// @Failure      404    {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/waves/{id}/test-sessions/assign [post]
+func (h *TestSessionHandler) AutoAssign(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var input dto.AutoAssignTestSessionsRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	result, err := h.service.AutoAssign(actorContext(c), uint(id), models.TestSessionKind(input.Kind))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, fmt.Sprintf("%d registrants assigned", result.Assigned), result)
+}
+
+// Assign godoc
+// @Summary      Assign a registrant to a session
+// @Description  Place a verified registrant in the session, moving them out of their current session of the same kind (admin only)
+// @Tags         psb-test-sessions
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                           true  "Session ID"
+// @Param        input  body      dto.AssignTestSessionRequest  true  "Registrant"
+// @Success      200    {object}  utils.APIResponse{data=models.TestSessionAssignment}
+// @Failure      400    {object}  utils.APIResponse
+// @Failure      401    {object}  utils.APIResponse
+// @Failure      404    {object}  utils.APIResponse
+// @Failure      409    {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/test-sessions/{id}/assignments [post]
+func (h *TestSessionHandler) Assign(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var input dto.AssignTestSessionRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	assignment, err := h.service.Assign(actorContext(c), uint(id), input.SantriID)
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Registrant assigned successfully", assignment)
+}
+
+// Unassign godoc
+// @Summary      Remove a registrant from a session
+// @Description  Remove a registrant from the session (admin only)
+// @Tags         psb-test-sessions
+// @Produce      json
+// @Param        id         path      int  true  "Session ID"
+// @Param        santri_id  path      int  true  "Registrant ID"
+// @Success      200        {object}  utils.APIResponse
+// @Failure      400        {object}  utils.APIResponse
+// @Failure      401        {object}  utils.APIResponse
+// @Failure      404        {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/test-sessions/{id}/assignments/{santri_id} [delete]
+func (h *TestSessionHandler) Unassign(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+	santriID, err := strconv.Atoi(c.Param("santri_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid registrant ID", err.Error())
+		return
+	}
+
+	if err := h.service.Unassign(actorContext(c), uint(id), uint(santriID)); err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Registrant removed from session successfully", nil)
+}
+
+// ExportCalendar godoc
+// @Summary      Export a test session as iCalendar
+// @Description  Download the session as an .ics file with the assigned registrants in the description (admin only)
+// @Tags         psb-test-sessions
+// @Produce      text/calendar
+// @Param        id   path      int  true  "Session ID"
+// @Success      200  {file}    file
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Security     BearerAuth
+// @Router       /psb/test-sessions/{id}/calendar.ics [get]
+func (h *TestSessionHandler) ExportCalendar(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	ics, filename, err := h.service.ExportCalendar(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.ResponseWithError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", ics)
+}
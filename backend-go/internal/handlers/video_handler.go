@@ -44,17 +44,11 @@ func (h *VideoHandler) Create(c *gin.Context) {
 		Thumbnail: input.Thumbnail,
 	}
 
-	if err := h.service.CreateVideo(c.Request.Context(), video); err != nil {
+	if err := h.service.CreateVideo(actorContext(c), video); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionCreate, "video", &video.ID, nil, video, c.ClientIP(), c.GetHeader("User-Agent"))
-	}
-
 	utils.SuccessResponse(c, http.StatusCreated, "Video created successfully", video)
 }
 
@@ -135,18 +129,11 @@ func (h *VideoHandler) Update(c *gin.Context) {
 		Thumbnail: input.Thumbnail,
 	}
 
-	if err := h.service.UpdateVideo(c.Request.Context(), uint(id), video); err != nil {
+	if err := h.service.UpdateVideo(actorContext(c), uint(id), video); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		entityID := uint(id)
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionUpdate, "video", &entityID, nil, video, c.ClientIP(), c.GetHeader("User-Agent"))
-	}
-
 	utils.SuccessResponse(c, http.StatusOK, "Video updated successfully", nil)
 }
 
@@ -169,17 +156,10 @@ func (h *VideoHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeleteVideo(c.Request.Context(), uint(id)); err != nil {
+	if err := h.service.DeleteVideo(actorContext(c), uint(id)); err != nil {
 		utils.ResponseWithError(c, err)
 		return
 	}
 
-	// Log activity
-	userID, _ := c.Get("user_id")
-	if uid, ok := userID.(uint); ok {
-		entityID := uint(id)
-		services.LogActivityAsync(c.Request.Context(), uid, models.ActionDelete, "video", &entityID, nil, nil, c.ClientIP(), c.GetHeader("User-Agent"))
-	}
-
 	utils.SuccessResponse(c, http.StatusOK, "Video deleted successfully", nil)
 }
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"backend-go/config"
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -10,7 +11,24 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+// SessionValidator reports whether the login session a token belongs to is still active
+type SessionValidator interface {
+	IsActive(ctx context.Context, sessionID string) bool
+}
+
+// AuthMiddleware requires a valid access token of an active session. Admins who still have to change their
+// password or enroll in two-factor authentication are refused until they have done so.
+func AuthMiddleware(sessions SessionValidator) gin.HandlerFunc {
+	return authenticate(sessions, false)
+}
+
+// AccountSetupAuthMiddleware is AuthMiddleware for the endpoints where admins change their password and enroll
+// in two-factor authentication, which have to stay reachable while that is required
+func AccountSetupAuthMiddleware(sessions SessionValidator) gin.HandlerFunc {
+	return authenticate(sessions, true)
+}
+
+func authenticate(sessions SessionValidator, allowAccountSetup bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var tokenString string
 		authHeader := c.GetHeader("Authorization")
@@ -44,13 +62,50 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// Refresh tokens live longer and carry none of the account setup claims, so only access tokens are accepted
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if tokenType, _ := claims["type"].(string); !ok || tokenType != "access" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			c.Abort()
+			return
+		}
+
 		// Store user info in context
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			if userID, ok := claims["user_id"].(float64); ok {
-				c.Set("user_id", uint(userID))
+		if userID, ok := claims["user_id"].(float64); ok {
+			c.Set("user_id", uint(userID))
+		}
+		if role, ok := claims["role"].(string); ok {
+			c.Set("role", role)
+		}
+		if list, ok := claims["permissions"].([]interface{}); ok {
+			permissions := make([]string, 0, len(list))
+			for _, p := range list {
+				if permission, ok := p.(string); ok {
+					permissions = append(permissions, permission)
+				}
+			}
+			c.Set("permissions", permissions)
+		}
+
+		// Revoked sessions end before their access tokens expire
+		sessionID, _ := claims["sid"].(string)
+		if !sessions.IsActive(c.Request.Context(), sessionID) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has ended, please log in again", "code": "SESSION_REVOKED"})
+			c.Abort()
+			return
+		}
+		c.Set("session_id", sessionID)
+
+		if !allowAccountSetup {
+			if mustChange, _ := claims["must_change_password"].(bool); mustChange {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Password change required", "code": "PASSWORD_CHANGE_REQUIRED"})
+				c.Abort()
+				return
 			}
-			if role, ok := claims["role"].(string); ok {
-				c.Set("role", role)
+			if setupRequired, _ := claims["two_factor_setup_required"].(bool); setupRequired {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Two-factor authentication setup required", "code": "TWO_FACTOR_SETUP_REQUIRED"})
+				c.Abort()
+				return
 			}
 		}
 
@@ -58,24 +113,29 @@ func AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
-// RBACMiddleware enforces role-based access control
-func RBACMiddleware(allowedRoles ...string) gin.HandlerFunc {
+// RequirePermission allows the request if the access token grants at least one of the permissions
+func RequirePermission(permissions ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userRole, exists := c.Get("role")
-		if !exists {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Role not found"})
-			c.Abort()
-			return
-		}
-
-		for _, role := range allowedRoles {
-			if role == userRole.(string) {
+		for _, permission := range permissions {
+			if HasPermission(c, permission) {
 				c.Next()
 				return
 			}
 		}
 
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied", "code": "PERMISSION_DENIED"})
 		c.Abort()
 	}
 }
+
+// HasPermission reports whether the access token of the request grants the permission
+func HasPermission(c *gin.Context, permission string) bool {
+	permissions, _ := c.Get("permissions")
+	granted, _ := permissions.([]string)
+	for _, p := range granted {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
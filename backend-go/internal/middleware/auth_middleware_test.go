@@ -0,0 +1,60 @@
+package middleware_test
+
+import (
+	"backend-go/config"
+	"backend-go/internal/middleware"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type activeSessions struct{}
+
+func (activeSessions) IsActive(ctx context.Context, sessionID string) bool { return true }
+
+func signToken(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(config.AppConfig.JWTSecret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestAuthMiddleware_TokenType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.AppConfig.JWTSecret = "test-secret"
+
+	router := gin.New()
+	router.GET("/me/sessions", middleware.AuthMiddleware(activeSessions{}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	exp := time.Now().Add(time.Hour).Unix()
+	cases := []struct {
+		name   string
+		claims jwt.MapClaims
+		status int
+	}{
+		{"access token", jwt.MapClaims{"user_id": 1, "sid": "s1", "type": "access", "exp": exp}, http.StatusOK},
+		{"refresh token", jwt.MapClaims{"user_id": 1, "sid": "s1", "type": "refresh", "exp": exp}, http.StatusUnauthorized},
+		{"no type", jwt.MapClaims{"user_id": 1, "sid": "s1", "exp": exp}, http.StatusUnauthorized},
+		{"password change required", jwt.MapClaims{"user_id": 1, "sid": "s1", "type": "access", "must_change_password": true, "exp": exp}, http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/me/sessions", nil)
+		r.Header.Set("Authorization", "Bearer "+signToken(t, tc.claims))
+		router.ServeHTTP(w, r)
+
+		if w.Code != tc.status {
+			t.Errorf("%s: expected %d, got %d", tc.name, tc.status, w.Code)
+		}
+	}
+}
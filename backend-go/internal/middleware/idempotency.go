@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"backend-go/config"
+	"backend-go/internal/logger"
+	"backend-go/internal/utils"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header clients use to make a retried request safe
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	idempotencyProcessing = "processing"
+	idempotencyCompleted  = "completed"
+	maxIdempotencyKeyLen  = 255
+)
+
+// idempotencyRecord is what is stored in Redis for each Idempotency-Key
+type idempotencyRecord struct {
+	State       string `json:"state"`
+	Fingerprint string `json:"fingerprint"`
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// idempotencyWriter captures the response so it can be replayed for retries
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware makes a route safe to retry. When the request carries an Idempotency-Key header,
+// the first response is stored for ttl and replayed for later requests with the same key, user and route.
+// Reusing a key with a different body is rejected, and a retry that arrives while the first request is
+// still running gets a 409. Requests without the header, and all requests while Redis is down, pass through.
+// It must run after AuthMiddleware so keys are scoped per user.
+func IdempotencyMiddleware(ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || config.RedisClient == nil {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid Idempotency-Key", fmt.Sprintf("Key must be at most %d characters", maxIdempotencyKeyLen))
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Failed to read request body", err.Error())
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		sum := sha256.Sum256(body)
+		fingerprint := hex.EncodeToString(sum[:])
+		userID, _ := c.Get("user_id")
+		redisKey := fmt.Sprintf(utils.CacheKeyIdempotencyPattern, userID, c.Request.Method+" "+c.Request.URL.Path, key)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+
+		// Claim the key; only one request per key gets to run the handler
+		claim, _ := json.Marshal(idempotencyRecord{State: idempotencyProcessing, Fingerprint: fingerprint})
+		claimed, err := config.RedisClient.SetNX(ctx, redisKey, claim, ttl).Result()
+		if err != nil {
+			logger.Warn("Redis SETNX failed, continuing without idempotency", zap.String("key", redisKey), zap.Error(err))
+			c.Next()
+			return
+		}
+
+		if !claimed {
+			replayIdempotentResponse(ctx, c, redisKey, fingerprint)
+			return
+		}
+
+		// Release the claim whenever no response ends up stored, including when the handler panics, so the client
+		// can retry instead of getting a 409 until the key expires
+		stored := false
+		defer func() {
+			if stored {
+				return
+			}
+			releaseCtx, releaseCancel := context.WithTimeout(context.Background(), 3*time.Second)
+			defer releaseCancel()
+			if err := config.RedisClient.Del(releaseCtx, redisKey).Err(); err != nil {
+				logger.Warn("Redis DELETE failed for idempotency key", zap.String("key", redisKey), zap.Error(err))
+			}
+		}()
+
+		writer := &idempotencyWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		// Server errors are not stored so the client can retry them
+		if writer.Status() >= http.StatusInternalServerError {
+			return
+		}
+
+		storeCtx, storeCancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer storeCancel()
+
+		record, _ := json.Marshal(idempotencyRecord{
+			State:       idempotencyCompleted,
+			Fingerprint: fingerprint,
+			Status:      writer.Status(),
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		})
+		if err := config.RedisClient.Set(storeCtx, redisKey, record, ttl).Err(); err != nil {
+			logger.Warn("Redis SET failed for idempotency key", zap.String("key", redisKey), zap.Error(err))
+			return
+		}
+		stored = true
+	}
+}
+
+// replayIdempotentResponse answers a request whose key was already claimed
+func replayIdempotentResponse(ctx context.Context, c *gin.Context, redisKey, fingerprint string) {
+	raw, err := config.RedisClient.Get(ctx, redisKey).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			// The first request failed with a server error or panicked and released the key in the meantime
+			utils.ErrorResponse(c, http.StatusConflict, "Previous request failed", "The earlier request with this Idempotency-Key failed, please retry")
+		} else {
+			utils.ErrorResponse(c, http.StatusServiceUnavailable, "Failed to check Idempotency-Key", err.Error())
+		}
+		c.Abort()
+		return
+	}
+
+	var record idempotencyRecord
+	if err := json.Unmarshal(raw, &record); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to read stored response", err.Error())
+		c.Abort()
+		return
+	}
+
+	if record.Fingerprint != fingerprint {
+		utils.ErrorResponse(c, http.StatusUnprocessableEntity, "Idempotency-Key reused", "This Idempotency-Key was already used with a different request body")
+		c.Abort()
+		return
+	}
+
+	if record.State != idempotencyCompleted {
+		utils.ErrorResponse(c, http.StatusConflict, "Request is still being processed", "A request with this Idempotency-Key is in progress, please retry later")
+		c.Abort()
+		return
+	}
+
+	c.Header("Idempotent-Replayed", "true")
+	c.Data(record.Status, record.ContentType, record.Body)
+	c.Abort()
+}
@@ -8,12 +8,23 @@ import (
 type ActivityAction string
 
 const (
-	ActionCreate ActivityAction = "CREATE"
-	ActionUpdate ActivityAction = "UPDATE"
-	ActionDelete ActivityAction = "DELETE"
-	ActionLogin  ActivityAction = "LOGIN"
-	ActionLogout ActivityAction = "LOGOUT"
-	ActionVerify ActivityAction = "VERIFY"
+	ActionCreate    ActivityAction = "CREATE"
+	ActionUpdate    ActivityAction = "UPDATE"
+	ActionDelete    ActivityAction = "DELETE"
+	ActionLogin     ActivityAction = "LOGIN"
+	ActionLogout    ActivityAction = "LOGOUT"
+	ActionVerify    ActivityAction = "VERIFY"
+	ActionRevoke    ActivityAction = "REVOKE"
+	ActionMerge     ActivityAction = "MERGE"
+	ActionSelection ActivityAction = "SELECTION"
+	ActionRestore   ActivityAction = "RESTORE"
+
+	ActionPasswordChange   ActivityAction = "PASSWORD_CHANGE"
+	ActionTwoFactorEnable  ActivityAction = "TWO_FACTOR_ENABLE"
+	ActionTwoFactorDisable ActivityAction = "TWO_FACTOR_DISABLE"
+	ActionRecoveryCodeUse  ActivityAction = "RECOVERY_CODE_USE"
+	ActionAccountLock      ActivityAction = "ACCOUNT_LOCK"
+	ActionAccountUnlock    ActivityAction = "ACCOUNT_UNLOCK"
 )
 
 // ActivityLog represents an audit log entry
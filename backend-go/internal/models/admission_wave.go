@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AdmissionWave is a PSB intake period (gelombang). Registration is only accepted while a wave is open.
+type AdmissionWave struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	Name            string    `gorm:"type:varchar(100);not null" json:"name"`
+	AcademicYear    string    `gorm:"type:varchar(9);not null;index" json:"academic_year"` // E.g., "2025/2026"
+	StartDate       time.Time `gorm:"type:date;not null" json:"start_date"`
+	EndDate         time.Time `gorm:"type:date;not null" json:"end_date"` // Inclusive
+	QuotaMale       int       `gorm:"default:0" json:"quota_male"`        // 0 means unlimited
+	QuotaFemale     int       `gorm:"default:0" json:"quota_female"`      // 0 means unlimited
+	RegistrationFee int64     `gorm:"default:0" json:"registration_fee"`  // In Rupiah
+	IsActive        bool      `gorm:"default:true" json:"is_active"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// IsOpen reports whether the wave accepts registrations at the given time
+func (w *AdmissionWave) IsOpen(at time.Time) bool {
+	if !w.IsActive {
+		return false
+	}
+	return !at.Before(w.StartDate) && at.Before(w.EndDate.AddDate(0, 0, 1))
+}
+
+// QuotaFor returns the quota for the given gender (L/P), where 0 means unlimited
+func (w *AdmissionWave) QuotaFor(gender string) int {
+	if gender == "P" {
+		return w.QuotaFemale
+	}
+	return w.QuotaMale
+}
@@ -0,0 +1,37 @@
+package models_test
+
+import (
+	"backend-go/internal/models"
+	"testing"
+	"time"
+)
+
+func TestAdmissionWave_IsOpen(t *testing.T) {
+	wave := &models.AdmissionWave{
+		StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+		IsActive:  true,
+	}
+
+	cases := []struct {
+		name string
+		at   time.Time
+		want bool
+	}{
+		{"before start", time.Date(2024, 12, 31, 23, 59, 0, 0, time.UTC), false},
+		{"on start", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"during last day", time.Date(2025, 3, 31, 18, 0, 0, 0, time.UTC), true},
+		{"after end", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tc := range cases {
+		if got := wave.IsOpen(tc.at); got != tc.want {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+
+	wave.IsActive = false
+	if wave.IsOpen(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("inactive wave should not be open")
+	}
+}
@@ -6,13 +6,40 @@ import (
 	"gorm.io/gorm"
 )
 
+type ArticleStatus string
+
+const (
+	ArticleDraft     ArticleStatus = "draft"
+	ArticleInReview  ArticleStatus = "in_review"
+	ArticleScheduled ArticleStatus = "scheduled"
+	ArticlePublished ArticleStatus = "published"
+	ArticleArchived  ArticleStatus = "archived"
+)
+
+// IsValid reports whether the status is one of the editorial workflow states
+func (s ArticleStatus) IsValid() bool {
+	switch s {
+	case ArticleDraft, ArticleInReview, ArticleScheduled, ArticlePublished, ArticleArchived:
+		return true
+	}
+	return false
+}
+
+// IsPublishing reports whether the status makes the article public, now or at its PublishAt time. Moving an
+// article into or out of these states requires the article.publish permission.
+func (s ArticleStatus) IsPublishing() bool {
+	return s == ArticleScheduled || s == ArticlePublished
+}
+
 type Article struct {
 	ID           uint           `gorm:"primaryKey" json:"id"`
 	Title        string         `gorm:"not null" json:"title"`
 	Slug         string         `gorm:"unique;index" json:"slug"`
 	Content      string         `gorm:"type:text" json:"content"`
 	ThumbnailURL string         `json:"thumbnail_url"`
-	IsPublished  bool           `gorm:"default:false" json:"is_published"`
+	IsPublished  bool           `gorm:"default:false" json:"is_published"` // Kept in sync with Status
+	Status       ArticleStatus  `gorm:"size:20;not null;default:draft" json:"status"`
+	PublishAt    *time.Time     `json:"publish_at"` // When a scheduled article goes live, or when it went live
 	AuthorID     uint           `json:"author_id"`
 	Author       User           `json:"author" gorm:"foreignKey:AuthorID"`
 	CategoryID   *uint          `json:"category_id"`
@@ -22,3 +49,22 @@ type Article struct {
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
 }
+
+// ArticleSearchHit is an article found by full-text search
+type ArticleSearchHit struct {
+	Article
+	Rank     float64 `json:"rank"`
+	Headline string  `json:"headline"` // Escaped text of the content around the matches, with the matches wrapped in <mark>
+}
+
+// BeforeSave keeps IsPublished in step with Status, so readers of the flag see the same public articles
+func (a *Article) BeforeSave(tx *gorm.DB) error {
+	if a.Status == "" {
+		a.Status = ArticleDraft
+		if a.IsPublished {
+			a.Status = ArticlePublished
+		}
+	}
+	a.IsPublished = a.Status == ArticlePublished
+	return nil
+}
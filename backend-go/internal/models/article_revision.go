@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+// ArticleRevision is a snapshot of an article as it was saved. Revisions are numbered from 1 per article; a
+// restore writes a new revision that records which one it came from.
+type ArticleRevision struct {
+	ID           uint          `gorm:"primaryKey" json:"id"`
+	ArticleID    uint          `gorm:"not null;uniqueIndex:idx_article_revision" json:"article_id"`
+	Revision     int           `gorm:"not null;uniqueIndex:idx_article_revision" json:"revision"`
+	Title        string        `gorm:"not null" json:"title"`
+	Content      string        `gorm:"type:text" json:"content,omitempty"`
+	ThumbnailURL string        `json:"thumbnail_url"`
+	IsPublished  bool          `json:"is_published"`
+	Status       ArticleStatus `gorm:"size:20" json:"status"`
+	EditorID     *uint         `json:"editor_id"`
+	Editor       *User         `json:"editor,omitempty" gorm:"foreignKey:EditorID"`
+	RestoredFrom *int          `json:"restored_from,omitempty"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
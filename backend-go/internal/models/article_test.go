@@ -0,0 +1,50 @@
+package models_test
+
+import (
+	"backend-go/internal/models"
+	"testing"
+)
+
+func TestArticle_BeforeSave(t *testing.T) {
+	cases := []struct {
+		name        string
+		article     models.Article
+		wantStatus  models.ArticleStatus
+		wantVisible bool
+	}{
+		{"published", models.Article{Status: models.ArticlePublished}, models.ArticlePublished, true},
+		{"scheduled", models.Article{Status: models.ArticleScheduled, IsPublished: true}, models.ArticleScheduled, false},
+		{"archived", models.Article{Status: models.ArticleArchived, IsPublished: true}, models.ArticleArchived, false},
+		{"legacy published flag", models.Article{IsPublished: true}, models.ArticlePublished, true},
+		{"no status", models.Article{}, models.ArticleDraft, false},
+	}
+	for _, tc := range cases {
+		article := tc.article
+		if err := article.BeforeSave(nil); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if article.Status != tc.wantStatus || article.IsPublished != tc.wantVisible {
+			t.Errorf("%s: expected %s/%v, got %s/%v", tc.name, tc.wantStatus, tc.wantVisible, article.Status, article.IsPublished)
+		}
+	}
+}
+
+func TestArticleStatus_IsPublishing(t *testing.T) {
+	for status, want := range map[models.ArticleStatus]bool{
+		models.ArticleDraft:     false,
+		models.ArticleInReview:  false,
+		models.ArticleScheduled: true,
+		models.ArticlePublished: true,
+		models.ArticleArchived:  false,
+	} {
+		if !status.IsValid() {
+			t.Errorf("expected %s to be valid", status)
+		}
+		if status.IsPublishing() != want {
+			t.Errorf("%s: expected IsPublishing %v", status, want)
+		}
+	}
+	if models.ArticleStatus("deleted").IsValid() {
+		t.Error("expected an unknown status to be invalid")
+	}
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+type GuardianRelation string
+
+const (
+	GuardianFather GuardianRelation = "FATHER"
+	GuardianMother GuardianRelation = "MOTHER"
+)
+
+// Guardian is a parent or guardian of a santri as submitted on the PSB form
+type Guardian struct {
+	ID        uint             `gorm:"primaryKey" json:"id"`
+	SantriID  uint             `gorm:"not null;uniqueIndex:idx_santri_guardians_relation" json:"santri_id"`
+	Relation  GuardianRelation `gorm:"size:10;not null;uniqueIndex:idx_santri_guardians_relation" json:"relation"`
+	Name      string           `gorm:"type:varchar(100);not null" json:"name"`
+	Job       string           `gorm:"type:varchar(100)" json:"job"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+func (Guardian) TableName() string {
+	return "santri_guardians"
+}
+
+// PriorEducation is the school a santri attended before applying
+type PriorEducation struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	SantriID       uint      `gorm:"not null;uniqueIndex" json:"santri_id"`
+	SchoolName     string    `gorm:"type:varchar(100);not null" json:"school_name"`
+	SchoolAddress  string    `gorm:"type:text" json:"school_address"`
+	GraduationYear int       `json:"graduation_year"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func (PriorEducation) TableName() string {
+	return "santri_prior_educations"
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+)
+
+type IssuedDocumentType string
+
+const (
+	IssuedRegistrationCard IssuedDocumentType = "REGISTRATION_CARD"
+	IssuedAcceptanceLetter IssuedDocumentType = "ACCEPTANCE_LETTER"
+)
+
+// IssuedDocument is a generated PSB document (registration card, acceptance letter) that carries a signed
+// verification token in its QR code. A santri has at most one active document per type; revoking it makes
+// printed copies fail verification and the next download issues a new token.
+type IssuedDocument struct {
+	ID           uint               `gorm:"primaryKey" json:"id"`
+	SantriID     uint               `gorm:"not null;index" json:"santri_id"`
+	Santri       *Santri            `json:"santri,omitempty" gorm:"foreignKey:SantriID"`
+	DocumentType IssuedDocumentType `gorm:"size:30;not null" json:"document_type"`
+	Token        string             `gorm:"type:varchar(100);uniqueIndex;not null" json:"-"`
+	RevokedAt    *time.Time         `json:"revoked_at"`
+	RevokedBy    *uint              `json:"revoked_by"`
+	Revoker      *User              `json:"revoker,omitempty" gorm:"foreignKey:RevokedBy"`
+	RevokeReason string             `gorm:"type:text" json:"revoke_reason"`
+	CreatedAt    time.Time          `json:"created_at"`
+}
+
+func (IssuedDocument) TableName() string {
+	return "issued_documents"
+}
+
+// IsRevoked reports whether the document has been revoked
+func (d *IssuedDocument) IsRevoked() bool {
+	return d.RevokedAt != nil
+}
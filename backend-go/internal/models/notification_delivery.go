@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+)
+
+type NotificationChannel string
+
+const (
+	ChannelEmail    NotificationChannel = "EMAIL"
+	ChannelWhatsApp NotificationChannel = "WHATSAPP"
+	ChannelSMS      NotificationChannel = "SMS"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending DeliveryStatus = "PENDING"
+	DeliverySent    DeliveryStatus = "SENT"
+	DeliveryFailed  DeliveryStatus = "FAILED"  // The last attempt failed, the outbox may still retry it
+	DeliverySkipped DeliveryStatus = "SKIPPED" // The channel has no provider configured, so nothing was sent
+)
+
+// NotificationDelivery tracks one notification sent to a registrant's parent on one channel
+type NotificationDelivery struct {
+	ID        uint                `gorm:"primaryKey" json:"id"`
+	SantriID  *uint               `gorm:"index" json:"santri_id"`
+	Event     string              `gorm:"type:varchar(50);not null;index" json:"event"`
+	Channel   NotificationChannel `gorm:"type:varchar(20);not null" json:"channel"`
+	Recipient string              `gorm:"type:varchar(100);not null" json:"recipient"`
+	Status    DeliveryStatus      `gorm:"type:varchar(20);not null;default:PENDING" json:"status"`
+	Attempts  int                 `gorm:"not null;default:0" json:"attempts"`
+	LastError string              `gorm:"type:text" json:"last_error,omitempty"`
+	SentAt    *time.Time          `json:"sent_at"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+func (NotificationDelivery) TableName() string {
+	return "notification_deliveries"
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+)
+
+// Locales supported by notification templates, matching the frontend's i18n
+const (
+	LocaleID = "id"
+	LocaleEN = "en"
+)
+
+var SupportedLocales = []string{LocaleID, LocaleEN}
+
+// NotificationTemplate is an admin override of the built-in template for one event and locale.
+// Subject and TextBody are text templates, HTMLBody an HTML template that escapes its data.
+type NotificationTemplate struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Event       string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_notification_templates_event_locale" json:"event"`
+	Locale      string    `gorm:"type:varchar(5);not null;uniqueIndex:idx_notification_templates_event_locale" json:"locale"`
+	Subject     string    `gorm:"type:text;not null" json:"subject"`
+	TextBody    string    `gorm:"type:text;not null" json:"text_body"`
+	HTMLBody    string    `gorm:"type:text;not null" json:"html_body"`
+	UpdatedByID *uint     `json:"updated_by_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (NotificationTemplate) TableName() string {
+	return "notification_templates"
+}
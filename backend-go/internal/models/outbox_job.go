@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxPending    OutboxStatus = "PENDING"
+	OutboxProcessing OutboxStatus = "PROCESSING"
+	OutboxDone       OutboxStatus = "DONE"
+	OutboxDead       OutboxStatus = "DEAD" // Gave up after MaxAttempts, waiting for an admin to replay it
+)
+
+// Retry delays double from outboxBaseDelay up to outboxMaxDelay
+const (
+	outboxBaseDelay = 30 * time.Second
+	outboxMaxDelay  = 6 * time.Hour
+)
+
+// OutboxJob is a unit of background work (an email, an activity log entry) stored in the same transaction as the
+// change that caused it, so it is never lost and can be retried until it succeeds
+type OutboxJob struct {
+	ID          uint         `gorm:"primaryKey" json:"id"`
+	Type        string       `gorm:"type:varchar(100);not null;index" json:"type"`
+	Payload     string       `gorm:"type:jsonb;not null" json:"payload"`
+	Status      OutboxStatus `gorm:"type:varchar(20);not null;default:PENDING;index" json:"status"`
+	Attempts    int          `gorm:"not null;default:0" json:"attempts"`
+	MaxAttempts int          `gorm:"not null;default:8" json:"max_attempts"`
+	AvailableAt time.Time    `gorm:"not null;index" json:"available_at"` // Not picked up before this time
+	LockedUntil *time.Time   `json:"locked_until"`                       // Lease of the worker processing it
+	LastError   string       `gorm:"type:text" json:"last_error,omitempty"`
+	ProcessedAt *time.Time   `json:"processed_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+func (OutboxJob) TableName() string {
+	return "outbox_jobs"
+}
+
+// NextAttemptAt returns when a job that just failed its Attempts-th attempt should be retried,
+// or false if it has used up its attempts and should be dead-lettered
+func (j *OutboxJob) NextAttemptAt(now time.Time) (time.Time, bool) {
+	if j.Attempts >= j.MaxAttempts {
+		return time.Time{}, false
+	}
+
+	delay := outboxBaseDelay
+	for i := 1; i < j.Attempts && delay < outboxMaxDelay; i++ {
+		delay *= 2
+	}
+	return now.Add(min(delay, outboxMaxDelay)), true
+}
@@ -0,0 +1,35 @@
+package models_test
+
+import (
+	"backend-go/internal/models"
+	"testing"
+	"time"
+)
+
+func TestOutboxJob_NextAttemptAt(t *testing.T) {
+	now := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		attempts int
+		want     time.Duration
+		retry    bool
+	}{
+		{1, 30 * time.Second, true},
+		{2, time.Minute, true},
+		{4, 4 * time.Minute, true},
+		{12, 6 * time.Hour, true},
+		{20, 0, false},
+	}
+
+	for _, tc := range cases {
+		job := &models.OutboxJob{Attempts: tc.attempts, MaxAttempts: 20}
+		next, retry := job.NextAttemptAt(now)
+		if retry != tc.retry {
+			t.Errorf("attempt %d: expected retry=%v, got %v", tc.attempts, tc.retry, retry)
+			continue
+		}
+		if retry && next.Sub(now) != tc.want {
+			t.Errorf("attempt %d: expected a delay of %s, got %s", tc.attempts, tc.want, next.Sub(now))
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+)
+
+// Role is a named set of permissions. Users refer to their role by name.
+type Role struct {
+	ID          uint         `gorm:"primaryKey" json:"id"`
+	Name        string       `gorm:"size:50;unique;not null" json:"name"`
+	Description string       `gorm:"size:255;not null;default:''" json:"description"`
+	IsSystem    bool         `gorm:"not null;default:false" json:"is_system"` // built-in roles cannot be deleted
+	Permissions []Permission `gorm:"many2many:role_permissions;" json:"permissions"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// Permission is a single ability checked by the API, e.g. psb.read. Permissions are created by migrations.
+type Permission struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	Code        string `gorm:"size:50;unique;not null" json:"code"`
+	Description string `gorm:"size:255;not null;default:''" json:"description"`
+}
+
+// Permission codes checked by the API
+const (
+	PermPSBRead        = "psb.read" // includes NIK and contact data
+	PermPSBUpdate      = "psb.update"
+	PermPSBVerify      = "psb.verify"
+	PermPSBDelete      = "psb.delete"
+	PermPSBManage      = "psb.manage"
+	PermPSBExport      = "psb.export"
+	PermArticleWrite   = "article.write"
+	PermArticlePublish = "article.publish"
+	PermArticleDelete  = "article.delete"
+	PermMediaManage    = "media.manage"
+	PermMessageRead    = "message.read"
+	PermMessageDelete  = "message.delete"
+	PermDashboardRead  = "dashboard.read"
+	PermAdminManage    = "admin.manage"
+	PermActivityRead   = "activity.read"
+	PermSystemManage   = "system.manage"
+)
@@ -15,6 +15,32 @@ const (
 	StatusRejected SantriStatus = "REJECTED"
 )
 
+// santriStatusTransitions is the PSB lifecycle: the statuses a registrant may move to from each status.
+// VERIFIED -> PENDING and REJECTED -> PENDING are the explicit re-open paths (e.g. incomplete data or a
+// successful appeal), and ACCEPTED -> REJECTED covers a santri who withdraws or is cancelled after acceptance.
+var santriStatusTransitions = map[SantriStatus][]SantriStatus{
+	StatusPending:  {StatusVerified, StatusRejected},
+	StatusVerified: {StatusAccepted, StatusRejected, StatusPending},
+	StatusAccepted: {StatusRejected},
+	StatusRejected: {StatusPending},
+}
+
+// IsValid reports whether the status is one of the known PSB statuses
+func (s SantriStatus) IsValid() bool {
+	_, ok := santriStatusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether the lifecycle allows moving from s to next
+func (s SantriStatus) CanTransitionTo(next SantriStatus) bool {
+	for _, allowed := range santriStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
 type Santri struct {
 	ID          uint      `gorm:"primaryKey" json:"id"`
 	FullName    string    `gorm:"not null" json:"full_name"`
@@ -25,15 +51,38 @@ type Santri struct {
 	Address     string    `json:"address"`
 	ParentName  string    `json:"parent_name"`
 	ParentPhone string    `json:"parent_phone"`
-	PhotoURL    string    `json:"photo_url"` // New field for pas foto
+	ParentEmail string    `gorm:"type:varchar(100)" json:"parent_email"`    // Optional, used for schedule reminders
+	Locale      string    `gorm:"type:varchar(5);default:id" json:"locale"` // Language of messages to the parents (id/en)
+	PhotoURL    string    `json:"photo_url"`                                // New field for pas foto
+
+	// Non-sequential code given to parents for the public status lookup
+	RegistrationCode string `gorm:"type:varchar(20);uniqueIndex" json:"registration_code"`
+
+	// Family and school background from the PSB form
+	Guardians      []Guardian      `gorm:"foreignKey:SantriID" json:"guardians,omitempty"`
+	PriorEducation *PriorEducation `gorm:"foreignKey:SantriID" json:"prior_education,omitempty"`
 
 	// Academic Info (Filled after Acceptance)
 	NIS       *string `gorm:"unique" json:"nis"`
 	Class     *string `json:"class"`
 	EntryYear int     `json:"entry_year"`
 
+	// Admission wave the registrant applied in
+	WaveID *uint          `gorm:"index" json:"wave_id"`
+	Wave   *AdmissionWave `gorm:"foreignKey:WaveID" json:"wave,omitempty"`
+
 	Status    SantriStatus   `gorm:"default:PENDING" json:"status"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
+
+// GuardianByRelation returns the guardian with the given relation, or nil if none was recorded
+func (s *Santri) GuardianByRelation(relation GuardianRelation) *Guardian {
+	for i := range s.Guardians {
+		if s.Guardians[i].Relation == relation {
+			return &s.Guardians[i]
+		}
+	}
+	return nil
+}
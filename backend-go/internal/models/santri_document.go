@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+)
+
+type DocumentType string
+
+const (
+	DocumentKartuKeluarga DocumentType = "KARTU_KELUARGA"
+	DocumentAktaKelahiran DocumentType = "AKTA_KELAHIRAN"
+	DocumentIjazah        DocumentType = "IJAZAH"
+)
+
+// RequiredSantriDocuments are the documents every registrant has to submit
+var RequiredSantriDocuments = []DocumentType{
+	DocumentKartuKeluarga,
+	DocumentAktaKelahiran,
+	DocumentIjazah,
+}
+
+type DocumentStatus string
+
+const (
+	DocumentPending       DocumentStatus = "PENDING"
+	DocumentApproved      DocumentStatus = "APPROVED"
+	DocumentNeedsReupload DocumentStatus = "NEEDS_REUPLOAD"
+)
+
+// SantriDocument is a supporting document uploaded by a registrant.
+// There is one row per registrant and document type; a re-upload replaces the file and resets verification.
+type SantriDocument struct {
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	SantriID   uint           `gorm:"not null;uniqueIndex:idx_santri_documents_type" json:"santri_id"`
+	Type       DocumentType   `gorm:"size:30;not null;uniqueIndex:idx_santri_documents_type" json:"type"`
+	FileURL    string         `gorm:"type:text;not null" json:"file_url"`
+	FileName   string         `gorm:"type:varchar(255)" json:"file_name"`
+	MimeType   string         `gorm:"type:varchar(100)" json:"mime_type"`
+	Status     DocumentStatus `gorm:"size:20;default:PENDING" json:"status"`
+	Note       string         `gorm:"type:text" json:"note"`
+	VerifiedBy *uint          `json:"verified_by"`
+	Verifier   *User          `json:"verifier,omitempty" gorm:"foreignKey:VerifiedBy"`
+	VerifiedAt *time.Time     `json:"verified_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+func (SantriDocument) TableName() string {
+	return "santri_documents"
+}
+
+// IsValidDocumentType reports whether t is one of the accepted document types
+func IsValidDocumentType(t DocumentType) bool {
+	for _, required := range RequiredSantriDocuments {
+		if required == t {
+			return true
+		}
+	}
+	return false
+}
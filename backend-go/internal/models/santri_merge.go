@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+// SantriMerge records that a duplicate registration was merged into another one.
+// Snapshot keeps the duplicate as it was before the merge, including documents that were replaced.
+type SantriMerge struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	PrimaryID  uint      `gorm:"not null;index" json:"primary_id"`
+	MergedID   uint      `gorm:"not null;index" json:"merged_id"`
+	MergedCode string    `gorm:"type:varchar(20)" json:"merged_code"`
+	MergedBy   *uint     `json:"merged_by"`
+	Merger     *User     `json:"merger,omitempty" gorm:"foreignKey:MergedBy"`
+	Snapshot   string    `gorm:"type:jsonb" json:"snapshot"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (SantriMerge) TableName() string {
+	return "santri_merges"
+}
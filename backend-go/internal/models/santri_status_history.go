@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// SantriStatusHistory records a single status transition of a PSB registrant
+type SantriStatusHistory struct {
+	ID         uint         `gorm:"primaryKey" json:"id"`
+	SantriID   uint         `gorm:"not null;index" json:"santri_id"`
+	FromStatus SantriStatus `gorm:"size:20" json:"from_status"`
+	ToStatus   SantriStatus `gorm:"size:20;not null" json:"to_status"`
+	ActorID    *uint        `json:"actor_id"`
+	Actor      *User        `json:"actor,omitempty" gorm:"foreignKey:ActorID"`
+	Reason     string       `gorm:"type:text" json:"reason"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+func (SantriStatusHistory) TableName() string {
+	return "santri_status_history"
+}
@@ -0,0 +1,31 @@
+package models_test
+
+import (
+	"backend-go/internal/models"
+	"testing"
+)
+
+func TestSantriStatus_CanTransitionTo(t *testing.T) {
+	cases := []struct {
+		from, to models.SantriStatus
+		want     bool
+	}{
+		{models.StatusPending, models.StatusVerified, true},
+		{models.StatusPending, models.StatusRejected, true},
+		{models.StatusPending, models.StatusAccepted, false},
+		{models.StatusVerified, models.StatusAccepted, true},
+		{models.StatusVerified, models.StatusPending, true},
+		{models.StatusAccepted, models.StatusRejected, true},
+		{models.StatusAccepted, models.StatusPending, false},
+		{models.StatusRejected, models.StatusPending, true},
+		{models.StatusRejected, models.StatusAccepted, false},
+		{models.StatusPending, models.StatusPending, false},
+		{models.StatusPending, models.SantriStatus("UNKNOWN"), false},
+	}
+
+	for _, tc := range cases {
+		if got := tc.from.CanTransitionTo(tc.to); got != tc.want {
+			t.Errorf("%s -> %s: expected %v, got %v", tc.from, tc.to, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// Kinds of content returned by the site-wide search
+const (
+	SearchTypeArticle     = "article"
+	SearchTypeGallery     = "gallery"
+	SearchTypePhoto       = "photo"
+	SearchTypeVideo       = "video"
+	SearchTypeAchievement = "achievement"
+)
+
+// SearchTypes lists the kinds of content in the order their facets are shown
+var SearchTypes = []string{SearchTypeArticle, SearchTypeGallery, SearchTypePhoto, SearchTypeVideo, SearchTypeAchievement}
+
+// SearchHit is a piece of public content matching a site-wide search
+type SearchHit struct {
+	Type         string    `json:"type" example:"article"`
+	ID           uint      `json:"id"`
+	ParentID     *uint     `json:"parent_id,omitempty"` // The gallery of a photo
+	Title        string    `json:"title"`
+	Slug         string    `json:"slug,omitempty"` // Only articles have one; the other types are addressed by ID
+	ThumbnailURL string    `json:"thumbnail_url"`
+	Snippet      string    `json:"snippet"` // Escaped text around the matches, with the matches wrapped in <mark>
+	Rank         float64   `json:"rank"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+)
+
+// SelectionComponent is one weighted part of a wave's selection rubric, e.g. Quran reading test or interview.
+// The weights of a wave's components add up to 100.
+type SelectionComponent struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	WaveID    uint      `gorm:"not null;index" json:"wave_id"`
+	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
+	Weight    int       `gorm:"not null" json:"weight"`                // Percentage of the total score
+	MaxScore  float64   `gorm:"not null;default:100" json:"max_score"` // Highest score an examiner can give
+	SortOrder int       `gorm:"default:0" json:"sort_order"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (SelectionComponent) TableName() string {
+	return "selection_components"
+}
+
+// SelectionScore is the score a registrant got for one rubric component
+type SelectionScore struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	SantriID    uint      `gorm:"not null;uniqueIndex:idx_selection_scores_component" json:"santri_id"`
+	ComponentID uint      `gorm:"not null;uniqueIndex:idx_selection_scores_component" json:"component_id"`
+	Score       float64   `gorm:"not null" json:"score"`
+	ScoredBy    *uint     `json:"scored_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (SelectionScore) TableName() string {
+	return "selection_scores"
+}
+
+// WeightedScore converts a raw score to its share of the 0-100 total
+func (c *SelectionComponent) WeightedScore(score float64) float64 {
+	if c.MaxScore <= 0 {
+		return 0
+	}
+	return score / c.MaxScore * float64(c.Weight)
+}
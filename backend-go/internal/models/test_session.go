@@ -0,0 +1,89 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TestSessionKind string
+
+const (
+	TestSessionWritten   TestSessionKind = "TEST"
+	TestSessionInterview TestSessionKind = "INTERVIEW"
+)
+
+// TestSession is a scheduled entrance test or interview slot of an admission wave
+type TestSession struct {
+	ID       uint            `gorm:"primaryKey" json:"id"`
+	WaveID   uint            `gorm:"not null;index" json:"wave_id"`
+	Kind     TestSessionKind `gorm:"type:varchar(20);not null" json:"kind"`
+	Name     string          `gorm:"type:varchar(100);not null" json:"name"`
+	StartsAt time.Time       `gorm:"not null;index" json:"starts_at"`
+	EndsAt   time.Time       `gorm:"not null" json:"ends_at"`
+	Room     string          `gorm:"type:varchar(100);not null" json:"room"`
+	Capacity int             `gorm:"not null" json:"capacity"`
+	Gender   string          `gorm:"type:varchar(1);default:''" json:"gender"` // L/P, empty for mixed sessions
+	Examiner string          `gorm:"type:varchar(100)" json:"examiner"`
+	Notes    string          `gorm:"type:text" json:"notes"`
+
+	Assignments []TestSessionAssignment `gorm:"foreignKey:SessionID" json:"assignments,omitempty"`
+	// Number of registrants assigned, filled by the repository
+	AssignedCount int `gorm:"-" json:"assigned_count"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// Accepts reports whether a registrant of the given gender (L/P) may sit in the session
+func (s *TestSession) Accepts(gender string) bool {
+	return s.Gender == "" || s.Gender == gender
+}
+
+// TestSessionAssignment places a registrant in a session. A registrant has at most one session of each kind.
+type TestSessionAssignment struct {
+	ID             uint            `gorm:"primaryKey" json:"id"`
+	SessionID      uint            `gorm:"not null;index" json:"session_id"`
+	Session        *TestSession    `gorm:"foreignKey:SessionID" json:"session,omitempty"`
+	SantriID       uint            `gorm:"not null;uniqueIndex:idx_test_session_assignments_kind" json:"santri_id"`
+	Santri         *Santri         `gorm:"foreignKey:SantriID" json:"santri,omitempty"`
+	Kind           TestSessionKind `gorm:"type:varchar(20);not null;uniqueIndex:idx_test_session_assignments_kind" json:"kind"`
+	ReminderSentAt *time.Time      `json:"reminder_sent_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// AssignToSessions places registrants, in the given order, into the earliest session that accepts their gender
+// and still has room. AssignedCount of the sessions is updated as seats fill up. Registrants that do not fit
+// anywhere are returned separately.
+func AssignToSessions(sessions []TestSession, registrants []Santri) (assigned []TestSessionAssignment, unplaced []Santri) {
+	sorted := make([]*TestSession, len(sessions))
+	for i := range sessions {
+		sorted[i] = &sessions[i]
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartsAt.Before(sorted[j].StartsAt)
+	})
+
+	for _, registrant := range registrants {
+		placed := false
+		for _, session := range sorted {
+			if !session.Accepts(registrant.Gender) || session.AssignedCount >= session.Capacity {
+				continue
+			}
+			session.AssignedCount++
+			assigned = append(assigned, TestSessionAssignment{
+				SessionID: session.ID,
+				SantriID:  registrant.ID,
+				Kind:      session.Kind,
+			})
+			placed = true
+			break
+		}
+		if !placed {
+			unplaced = append(unplaced, registrant)
+		}
+	}
+	return assigned, unplaced
+}
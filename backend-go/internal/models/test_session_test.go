@@ -0,0 +1,47 @@
+package models_test
+
+import (
+	"backend-go/internal/models"
+	"testing"
+	"time"
+)
+
+func TestAssignToSessions(t *testing.T) {
+	day := time.Date(2025, 3, 10, 1, 0, 0, 0, time.UTC)
+	sessions := []models.TestSession{
+		{ID: 1, Kind: models.TestSessionWritten, StartsAt: day.Add(2 * time.Hour), Capacity: 2, Gender: "L"},
+		{ID: 2, Kind: models.TestSessionWritten, StartsAt: day, Capacity: 1, Gender: "L", AssignedCount: 0},
+		{ID: 3, Kind: models.TestSessionWritten, StartsAt: day.Add(4 * time.Hour), Capacity: 2, AssignedCount: 1}, // Mixed
+		{ID: 4, Kind: models.TestSessionWritten, StartsAt: day, Capacity: 1, Gender: "P"},
+	}
+	registrants := []models.Santri{
+		{ID: 10, Gender: "L"},
+		{ID: 11, Gender: "P"},
+		{ID: 12, Gender: "L"},
+		{ID: 13, Gender: "P"},
+		{ID: 14, Gender: "L"},
+		{ID: 15, Gender: "L"},
+	}
+
+	assigned, unplaced := models.AssignToSessions(sessions, registrants)
+
+	want := map[uint]uint{10: 2, 11: 4, 12: 1, 13: 3, 14: 1}
+	if len(assigned) != len(want) {
+		t.Fatalf("expected %d assignments, got %+v", len(want), assigned)
+	}
+	for _, assignment := range assigned {
+		if want[assignment.SantriID] != assignment.SessionID {
+			t.Errorf("registrant %d: expected session %d, got %d", assignment.SantriID, want[assignment.SantriID], assignment.SessionID)
+		}
+		if assignment.Kind != models.TestSessionWritten {
+			t.Errorf("registrant %d: expected kind %s, got %s", assignment.SantriID, models.TestSessionWritten, assignment.Kind)
+		}
+	}
+
+	if len(unplaced) != 1 || unplaced[0].ID != 15 {
+		t.Errorf("expected only registrant 15 to be left without a session, got %+v", unplaced)
+	}
+	if sessions[2].AssignedCount != 2 {
+		t.Errorf("expected the mixed session to be full, got %d assigned", sessions[2].AssignedCount)
+	}
+}
@@ -7,16 +7,36 @@ import (
 )
 
 type User struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	Username  string         `gorm:"unique;not null" json:"username"`
-	Password  string         `gorm:"not null" json:"-"`           // Hide password in JSON
-	Role      string         `gorm:"default:'admin'" json:"role"` // 'super_admin' or 'admin'
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                 uint           `gorm:"primaryKey" json:"id"`
+	Username           string         `gorm:"unique;not null" json:"username"`
+	Email              string         `gorm:"size:255;not null;default:''" json:"email"`
+	Password           string         `gorm:"not null" json:"-"`                            // Hide password in JSON
+	Role               string         `gorm:"size:50;not null;default:'admin'" json:"role"` // name of a Role
+	MustChangePassword bool           `gorm:"not null;default:false" json:"must_change_password"`
+	TOTPSecret         string         `gorm:"column:totp_secret;size:64;not null;default:''" json:"-"`
+	TOTPEnabled        bool           `gorm:"column:totp_enabled;not null;default:false" json:"totp_enabled"`
+	TOTPEnabledAt      *time.Time     `gorm:"column:totp_enabled_at" json:"totp_enabled_at,omitempty"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
+// Built-in roles. Super admins hold every permission; admins everything but managing admins and the system.
 const (
 	RoleSuperAdmin = "super_admin"
 	RoleAdmin      = "admin"
 )
+
+// RecoveryCode is a single-use code that stands in for a TOTP code when the authenticator is lost.
+// Only the SHA-256 hash of the code is stored.
+type RecoveryCode struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"size:64;not null" json:"-"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (RecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
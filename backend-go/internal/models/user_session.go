@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// UserSession is one login, usually one device. Both tokens carry its ID as the sid claim, so revoking the session
+// ends the login however many times its tokens have been refreshed.
+type UserSession struct {
+	ID         string     `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID     uint       `gorm:"not null;index" json:"user_id"`
+	IPAddress  string     `gorm:"size:45" json:"ip_address"`
+	UserAgent  string     `gorm:"size:255" json:"user_agent"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt time.Time  `json:"last_used_at"`
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+func (UserSession) TableName() string {
+	return "user_sessions"
+}
+
+// IsActive reports whether the session can still be used at the given time
+func (s *UserSession) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
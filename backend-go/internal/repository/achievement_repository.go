@@ -26,32 +26,32 @@ func NewAchievementRepository(db *gorm.DB) AchievementRepository {
 }
 
 func (r *achievementRepository) Create(ctx context.Context, achievement *models.Achievement) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Create(achievement).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Create(achievement).Error)
 }
 
 func (r *achievementRepository) FindAll(ctx context.Context) ([]models.Achievement, error) {
 	var achievements []models.Achievement
-	err := r.db.WithContext(ctx).Order("created_at desc").Find(&achievements).Error
+	err := dbFrom(ctx, r.db).Order("created_at desc").Find(&achievements).Error
 	return achievements, utils.HandleDBError(err)
 }
 
 func (r *achievementRepository) FindByID(ctx context.Context, id uint) (*models.Achievement, error) {
 	var achievement models.Achievement
-	err := r.db.WithContext(ctx).First(&achievement, id).Error
+	err := dbFrom(ctx, r.db).First(&achievement, id).Error
 	return &achievement, utils.HandleDBError(err)
 }
 
 func (r *achievementRepository) Update(ctx context.Context, achievement *models.Achievement) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Save(achievement).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Save(achievement).Error)
 }
 
 func (r *achievementRepository) Delete(ctx context.Context, id uint) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Delete(&models.Achievement{}, id).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Delete(&models.Achievement{}, id).Error)
 }
 
 func (r *achievementRepository) Count(ctx context.Context) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&models.Achievement{}).Count(&count).Error
+	err := dbFrom(ctx, r.db).Model(&models.Achievement{}).Count(&count).Error
 	return count, utils.HandleDBError(err)
 }
 
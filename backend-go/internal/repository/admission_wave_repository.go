@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/utils"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AdmissionWaveRepository interface {
+	Create(ctx context.Context, wave *models.AdmissionWave) error
+	FindAll(ctx context.Context, academicYear string) ([]models.AdmissionWave, error)
+	FindByID(ctx context.Context, id uint) (*models.AdmissionWave, error)
+	FindOpen(ctx context.Context, at time.Time) (*models.AdmissionWave, error)
+	Update(ctx context.Context, wave *models.AdmissionWave) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type admissionWaveRepository struct {
+	db *gorm.DB
+}
+
+func NewAdmissionWaveRepository(db *gorm.DB) AdmissionWaveRepository {
+	return &admissionWaveRepository{db}
+}
+
+func (r *admissionWaveRepository) Create(ctx context.Context, wave *models.AdmissionWave) error {
+	return utils.HandleDBError(dbFrom(ctx, r.db).Create(wave).Error)
+}
+
+func (r *admissionWaveRepository) FindAll(ctx context.Context, academicYear string) ([]models.AdmissionWave, error) {
+	var waves []models.AdmissionWave
+	query := dbFrom(ctx, r.db)
+	if academicYear != "" {
+		query = query.Where("academic_year = ?", academicYear)
+	}
+	err := query.Order("start_date desc").Find(&waves).Error
+	return waves, utils.HandleDBError(err)
+}
+
+func (r *admissionWaveRepository) FindByID(ctx context.Context, id uint) (*models.AdmissionWave, error) {
+	var wave models.AdmissionWave
+	err := dbFrom(ctx, r.db).First(&wave, id).Error
+	return &wave, utils.HandleDBError(err)
+}
+
+// FindOpen returns the active wave whose window contains the given date.
+// If windows overlap, the one closing first is returned.
+func (r *admissionWaveRepository) FindOpen(ctx context.Context, at time.Time) (*models.AdmissionWave, error) {
+	var wave models.AdmissionWave
+	day := at.Format("2006-01-02")
+	err := dbFrom(ctx, r.db).
+		Where("is_active = ? AND start_date <= ? AND end_date >= ?", true, day, day).
+		Order("end_date asc, id asc").
+		First(&wave).Error
+	return &wave, utils.HandleDBError(err)
+}
+
+func (r *admissionWaveRepository) Update(ctx context.Context, wave *models.AdmissionWave) error {
+	return utils.HandleDBError(dbFrom(ctx, r.db).Save(wave).Error)
+}
+
+func (r *admissionWaveRepository) Delete(ctx context.Context, id uint) error {
+	return utils.HandleDBError(dbFrom(ctx, r.db).Delete(&models.AdmissionWave{}, id).Error)
+}
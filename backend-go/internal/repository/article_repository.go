@@ -4,19 +4,24 @@ import (
 	"backend-go/internal/models"
 	"backend-go/internal/utils"
 	"context"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ArticleRepository interface {
 	Create(ctx context.Context, article *models.Article) error
 	FindAll(ctx context.Context) ([]models.Article, error)
 	FindByID(ctx context.Context, id uint) (*models.Article, error)
+	FindPublishedByID(ctx context.Context, id uint) (*models.Article, error)
+	FindAllByStatus(ctx context.Context, status models.ArticleStatus, page, limit int) ([]models.Article, int64, error)
+	PublishDue(ctx context.Context, now time.Time) ([]models.Article, error)
 	Update(ctx context.Context, article *models.Article) error
 	Delete(ctx context.Context, id uint) error
 	FindBySlug(ctx context.Context, slug string) (*models.Article, error)
 	FindAllPaginated(ctx context.Context, page, limit int) ([]models.Article, int64, error)
-	Search(ctx context.Context, query string, page, limit int) ([]models.Article, int64, error)
+	Search(ctx context.Context, query string, page, limit int) ([]models.ArticleSearchHit, int64, error)
 	FindByCategory(ctx context.Context, categoryID uint, page, limit int) ([]models.Article, int64, error)
 	FindByTag(ctx context.Context, tagID uint, page, limit int) ([]models.Article, int64, error)
 	Count(ctx context.Context) (int64, error)
@@ -30,25 +35,37 @@ func NewArticleRepository(db *gorm.DB) ArticleRepository {
 	return &articleRepository{db}
 }
 
+// published limits a query to the articles the public may see. FindByID, FindAllByStatus and Count see every
+// article; the other finders only published ones.
+func published(db *gorm.DB) *gorm.DB {
+	return db.Where("articles.status = ?", models.ArticlePublished)
+}
+
 func (r *articleRepository) Create(ctx context.Context, article *models.Article) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Create(article).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Create(article).Error)
 }
 
 func (r *articleRepository) FindAll(ctx context.Context) ([]models.Article, error) {
 	var articles []models.Article
-	err := r.db.WithContext(ctx).Preload("Author").Preload("Category").Preload("Tags").Order("created_at desc").Find(&articles).Error
+	err := dbFrom(ctx, r.db).Scopes(published).Preload("Author").Preload("Category").Preload("Tags").Order("created_at desc").Find(&articles).Error
 	return articles, utils.HandleDBError(err)
 }
 
 func (r *articleRepository) FindByID(ctx context.Context, id uint) (*models.Article, error) {
 	var article models.Article
-	err := r.db.WithContext(ctx).Preload("Author").Preload("Category").Preload("Tags").First(&article, id).Error
+	err := dbFrom(ctx, r.db).Preload("Author").Preload("Category").Preload("Tags").First(&article, id).Error
+	return &article, utils.HandleDBError(err)
+}
+
+func (r *articleRepository) FindPublishedByID(ctx context.Context, id uint) (*models.Article, error) {
+	var article models.Article
+	err := dbFrom(ctx, r.db).Scopes(published).Preload("Author").Preload("Category").Preload("Tags").First(&article, id).Error
 	return &article, utils.HandleDBError(err)
 }
 
 func (r *articleRepository) FindBySlug(ctx context.Context, slug string) (*models.Article, error) {
 	var article models.Article
-	err := r.db.WithContext(ctx).Preload("Author").Preload("Category").Preload("Tags").Where("slug = ?", slug).First(&article).Error
+	err := dbFrom(ctx, r.db).Scopes(published).Preload("Author").Preload("Category").Preload("Tags").Where("slug = ?", slug).First(&article).Error
 	return &article, utils.HandleDBError(err)
 }
 
@@ -59,39 +76,80 @@ func (r *articleRepository) FindAllPaginated(ctx context.Context, page, limit in
 	offset := (page - 1) * limit
 
 	// Count total
-	if err := r.db.WithContext(ctx).Model(&models.Article{}).Count(&total).Error; err != nil {
+	if err := dbFrom(ctx, r.db).Model(&models.Article{}).Scopes(published).Count(&total).Error; err != nil {
 		return nil, 0, utils.HandleDBError(err)
 	}
 
 	// Fetch paginated
-	err := r.db.WithContext(ctx).Preload("Author").Preload("Category").Preload("Tags").Order("created_at desc").
+	err := dbFrom(ctx, r.db).Scopes(published).Preload("Author").Preload("Category").Preload("Tags").Order("created_at desc").
 		Offset(offset).Limit(limit).Find(&articles).Error
 
 	return articles, total, utils.HandleDBError(err)
 }
 
-func (r *articleRepository) Search(ctx context.Context, query string, page, limit int) ([]models.Article, int64, error) {
-	var articles []models.Article
+// textSearchConfig is the text search configuration created by the search migration: Indonesian where the
+// server has it, simple otherwise
+const textSearchConfig = "article_search"
+
+// articleHeadlineOptions marks the matches and keeps snippets short enough for a result list
+const articleHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=\" … \""
+
+// Search finds published articles with full-text search, most relevant first. The query uses web search syntax,
+// so "quoted phrases", OR and -excluded words work.
+func (r *articleRepository) Search(ctx context.Context, query string, page, limit int) ([]models.ArticleSearchHit, int64, error) {
 	var total int64
 
 	offset := (page - 1) * limit
-	searchPattern := "%" + query + "%"
-
-	baseQuery := r.db.WithContext(ctx).Model(&models.Article{}).
-		Where("title ILIKE ? OR content ILIKE ?", searchPattern, searchPattern)
+	matches := func() *gorm.DB {
+		return dbFrom(ctx, r.db).Model(&models.Article{}).Scopes(published).
+			Where("articles.search_vector @@ websearch_to_tsquery(?::regconfig, ?)", textSearchConfig, query)
+	}
 
 	// Count total
-	if err := baseQuery.Count(&total).Error; err != nil {
+	if err := matches().Count(&total).Error; err != nil {
 		return nil, 0, utils.HandleDBError(err)
 	}
 
-	// Fetch paginated
-	err := r.db.WithContext(ctx).Preload("Author").Preload("Category").Preload("Tags").
-		Where("title ILIKE ? OR content ILIKE ?", searchPattern, searchPattern).
-		Order("created_at desc").
-		Offset(offset).Limit(limit).Find(&articles).Error
+	// Rank and highlight the page of matches
+	var ranked []struct {
+		ID       uint
+		Rank     float64
+		Headline string
+	}
+	err := matches().
+		Select(`articles.id,
+			ts_rank(articles.search_vector, websearch_to_tsquery(?::regconfig, ?)) AS rank,
+			ts_headline(?::regconfig, regexp_replace(articles.content, '<[^>]*>', ' ', 'g'), websearch_to_tsquery(?::regconfig, ?), ?) AS headline`,
+			textSearchConfig, query, textSearchConfig, textSearchConfig, query, articleHeadlineOptions).
+		Order("rank desc, articles.created_at desc").
+		Offset(offset).Limit(limit).
+		Scan(&ranked).Error
+	if err != nil || len(ranked) == 0 {
+		return []models.ArticleSearchHit{}, total, utils.HandleDBError(err)
+	}
 
-	return articles, total, utils.HandleDBError(err)
+	// Load the articles with their relations and put them in rank order
+	ids := make([]uint, len(ranked))
+	for i, match := range ranked {
+		ids[i] = match.ID
+	}
+	var articles []models.Article
+	if err := dbFrom(ctx, r.db).Preload("Author").Preload("Category").Preload("Tags").
+		Where("id IN ?", ids).Find(&articles).Error; err != nil {
+		return nil, 0, utils.HandleDBError(err)
+	}
+	byID := make(map[uint]models.Article, len(articles))
+	for _, article := range articles {
+		byID[article.ID] = article
+	}
+
+	hits := make([]models.ArticleSearchHit, 0, len(ranked))
+	for _, match := range ranked {
+		if article, ok := byID[match.ID]; ok {
+			hits = append(hits, models.ArticleSearchHit{Article: article, Rank: match.Rank, Headline: match.Headline})
+		}
+	}
+	return hits, total, nil
 }
 
 func (r *articleRepository) FindByCategory(ctx context.Context, categoryID uint, page, limit int) ([]models.Article, int64, error) {
@@ -100,7 +158,7 @@ func (r *articleRepository) FindByCategory(ctx context.Context, categoryID uint,
 
 	offset := (page - 1) * limit
 
-	baseQuery := r.db.WithContext(ctx).Model(&models.Article{}).Where("category_id = ?", categoryID)
+	baseQuery := dbFrom(ctx, r.db).Model(&models.Article{}).Scopes(published).Where("category_id = ?", categoryID)
 
 	// Count total
 	if err := baseQuery.Count(&total).Error; err != nil {
@@ -108,7 +166,7 @@ func (r *articleRepository) FindByCategory(ctx context.Context, categoryID uint,
 	}
 
 	// Fetch paginated
-	err := r.db.WithContext(ctx).Preload("Author").Preload("Category").Preload("Tags").
+	err := dbFrom(ctx, r.db).Scopes(published).Preload("Author").Preload("Category").Preload("Tags").
 		Where("category_id = ?", categoryID).
 		Order("created_at desc").
 		Offset(offset).Limit(limit).Find(&articles).Error
@@ -123,7 +181,7 @@ func (r *articleRepository) FindByTag(ctx context.Context, tagID uint, page, lim
 	offset := (page - 1) * limit
 
 	// Count total through join
-	countQuery := r.db.WithContext(ctx).Model(&models.Article{}).
+	countQuery := dbFrom(ctx, r.db).Model(&models.Article{}).Scopes(published).
 		Joins("JOIN article_tags ON article_tags.article_id = articles.id").
 		Where("article_tags.tag_id = ?", tagID)
 
@@ -132,7 +190,7 @@ func (r *articleRepository) FindByTag(ctx context.Context, tagID uint, page, lim
 	}
 
 	// Fetch paginated
-	err := r.db.WithContext(ctx).Preload("Author").Preload("Category").Preload("Tags").
+	err := dbFrom(ctx, r.db).Scopes(published).Preload("Author").Preload("Category").Preload("Tags").
 		Joins("JOIN article_tags ON article_tags.article_id = articles.id").
 		Where("article_tags.tag_id = ?", tagID).
 		Order("articles.created_at desc").
@@ -141,16 +199,50 @@ func (r *articleRepository) FindByTag(ctx context.Context, tagID uint, page, lim
 	return articles, total, utils.HandleDBError(err)
 }
 
+// FindAllByStatus lists articles in any state for the admin, optionally only those with the given status
+func (r *articleRepository) FindAllByStatus(ctx context.Context, status models.ArticleStatus, page, limit int) ([]models.Article, int64, error) {
+	var articles []models.Article
+	var total int64
+
+	query := dbFrom(ctx, r.db).Model(&models.Article{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, utils.HandleDBError(err)
+	}
+
+	err := query.Preload("Author").Preload("Category").Preload("Tags").
+		Order("updated_at desc").
+		Offset((page - 1) * limit).Limit(limit).Find(&articles).Error
+
+	return articles, total, utils.HandleDBError(err)
+}
+
+// PublishDue publishes the scheduled articles whose time has come and returns them as they are now
+func (r *articleRepository) PublishDue(ctx context.Context, now time.Time) ([]models.Article, error) {
+	var articles []models.Article
+	err := dbFrom(ctx, r.db).Model(&articles).Clauses(clause.Returning{}).
+		Where("status = ? AND publish_at <= ?", models.ArticleScheduled, now).
+		Updates(map[string]interface{}{
+			"status":       models.ArticlePublished,
+			"is_published": true,
+			"updated_at":   now,
+		}).Error
+	return articles, utils.HandleDBError(err)
+}
+
 func (r *articleRepository) Update(ctx context.Context, article *models.Article) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Save(article).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Save(article).Error)
 }
 
 func (r *articleRepository) Delete(ctx context.Context, id uint) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Delete(&models.Article{}, id).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Delete(&models.Article{}, id).Error)
 }
 
 func (r *articleRepository) Count(ctx context.Context) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&models.Article{}).Count(&count).Error
+	err := dbFrom(ctx, r.db).Model(&models.Article{}).Count(&count).Error
 	return count, utils.HandleDBError(err)
 }
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/utils"
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ArticleRevisionRepository interface {
+	Create(ctx context.Context, revision *models.ArticleRevision) error
+	FindByArticle(ctx context.Context, articleID uint) ([]models.ArticleRevision, error)
+	FindByRevision(ctx context.Context, articleID uint, revision int) (*models.ArticleRevision, error)
+}
+
+type articleRevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewArticleRevisionRepository(db *gorm.DB) ArticleRevisionRepository {
+	return &articleRevisionRepository{db}
+}
+
+// Create numbers the revision after the latest one of its article. The article row is locked while numbering, so
+// concurrent saves of one article cannot pick the same number.
+func (r *articleRevisionRepository) Create(ctx context.Context, revision *models.ArticleRevision) error {
+	return utils.HandleDBError(dbFrom(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var article models.Article
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&article, revision.ArticleID).Error; err != nil {
+			return err
+		}
+
+		var latest int
+		if err := tx.Model(&models.ArticleRevision{}).
+			Where("article_id = ?", revision.ArticleID).
+			Select("COALESCE(MAX(revision), 0)").
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+
+		revision.Revision = latest + 1
+		return tx.Omit("Editor").Create(revision).Error
+	}))
+}
+
+// FindByArticle lists the revisions of an article, newest first, without their content
+func (r *articleRevisionRepository) FindByArticle(ctx context.Context, articleID uint) ([]models.ArticleRevision, error) {
+	var revisions []models.ArticleRevision
+	err := dbFrom(ctx, r.db).
+		Omit("content").
+		Preload("Editor").
+		Where("article_id = ?", articleID).
+		Order("revision desc").
+		Find(&revisions).Error
+	return revisions, utils.HandleDBError(err)
+}
+
+func (r *articleRevisionRepository) FindByRevision(ctx context.Context, articleID uint, revision int) (*models.ArticleRevision, error) {
+	var result models.ArticleRevision
+	err := dbFrom(ctx, r.db).
+		Preload("Editor").
+		Where("article_id = ? AND revision = ?", articleID, revision).
+		First(&result).Error
+	if err != nil {
+		return nil, utils.HandleDBError(err)
+	}
+	return &result, nil
+}
@@ -27,37 +27,37 @@ func NewCategoryRepository(db *gorm.DB) CategoryRepository {
 }
 
 func (r *categoryRepository) Create(ctx context.Context, category *models.Category) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Create(category).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Create(category).Error)
 }
 
 func (r *categoryRepository) FindAll(ctx context.Context) ([]models.Category, error) {
 	var categories []models.Category
-	err := r.db.WithContext(ctx).Order("name asc").Find(&categories).Error
+	err := dbFrom(ctx, r.db).Order("name asc").Find(&categories).Error
 	return categories, utils.HandleDBError(err)
 }
 
 func (r *categoryRepository) FindByID(ctx context.Context, id uint) (*models.Category, error) {
 	var category models.Category
-	err := r.db.WithContext(ctx).First(&category, id).Error
+	err := dbFrom(ctx, r.db).First(&category, id).Error
 	return &category, utils.HandleDBError(err)
 }
 
 func (r *categoryRepository) FindBySlug(ctx context.Context, slug string) (*models.Category, error) {
 	var category models.Category
-	err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&category).Error
+	err := dbFrom(ctx, r.db).Where("slug = ?", slug).First(&category).Error
 	return &category, utils.HandleDBError(err)
 }
 
 func (r *categoryRepository) Update(ctx context.Context, category *models.Category) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Save(category).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Save(category).Error)
 }
 
 func (r *categoryRepository) Delete(ctx context.Context, id uint) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Delete(&models.Category{}, id).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Delete(&models.Category{}, id).Error)
 }
 
 func (r *categoryRepository) Count(ctx context.Context) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&models.Category{}).Count(&count).Error
+	err := dbFrom(ctx, r.db).Model(&models.Category{}).Count(&count).Error
 	return count, utils.HandleDBError(err)
 }
@@ -32,20 +32,20 @@ func NewGalleryRepository(db *gorm.DB) GalleryRepository {
 }
 
 func (r *galleryRepository) Create(ctx context.Context, gallery *models.Gallery) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Create(gallery).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Create(gallery).Error)
 }
 
 // FindAll returns all galleries with their photos (use for detail views)
 func (r *galleryRepository) FindAll(ctx context.Context) ([]models.Gallery, error) {
 	var galleries []models.Gallery
-	err := r.db.WithContext(ctx).Preload("Photos").Order("created_at desc").Find(&galleries).Error
+	err := dbFrom(ctx, r.db).Preload("Photos").Order("created_at desc").Find(&galleries).Error
 	return galleries, utils.HandleDBError(err)
 }
 
 // FindAllSummary returns galleries with photo count only (optimized for list views)
 func (r *galleryRepository) FindAllSummary(ctx context.Context) ([]models.GallerySummary, error) {
 	var summaries []models.GallerySummary
-	err := r.db.WithContext(ctx).
+	err := dbFrom(ctx, r.db).
 		Table("galleries").
 		Select("galleries.id, galleries.title, galleries.description, galleries.cover_image, galleries.created_at, galleries.updated_at, COUNT(photos.id) as photo_count").
 		Joins("LEFT JOIN photos ON photos.gallery_id = galleries.id").
@@ -57,35 +57,35 @@ func (r *galleryRepository) FindAllSummary(ctx context.Context) ([]models.Galler
 
 func (r *galleryRepository) FindByID(ctx context.Context, id uint) (*models.Gallery, error) {
 	var gallery models.Gallery
-	err := r.db.WithContext(ctx).Preload("Photos").First(&gallery, id).Error
+	err := dbFrom(ctx, r.db).Preload("Photos").First(&gallery, id).Error
 	return &gallery, utils.HandleDBError(err)
 }
 
 func (r *galleryRepository) Update(ctx context.Context, gallery *models.Gallery) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Save(gallery).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Save(gallery).Error)
 }
 
 func (r *galleryRepository) Delete(ctx context.Context, id uint) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Delete(&models.Gallery{}, id).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Delete(&models.Gallery{}, id).Error)
 }
 
 func (r *galleryRepository) Count(ctx context.Context) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&models.Gallery{}).Count(&count).Error
+	err := dbFrom(ctx, r.db).Model(&models.Gallery{}).Count(&count).Error
 	return count, utils.HandleDBError(err)
 }
 
 func (r *galleryRepository) AddPhoto(ctx context.Context, photo *models.Photo) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Create(photo).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Create(photo).Error)
 }
 
 func (r *galleryRepository) FindPhotoByID(ctx context.Context, id uint) (*models.Photo, error) {
 	var photo models.Photo
-	err := r.db.WithContext(ctx).First(&photo, id).Error
+	err := dbFrom(ctx, r.db).First(&photo, id).Error
 	return &photo, utils.HandleDBError(err)
 }
 
 func (r *galleryRepository) DeletePhoto(ctx context.Context, id uint) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Delete(&models.Photo{}, id).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Delete(&models.Photo{}, id).Error)
 }
 
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/utils"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IssuedDocumentRepository interface {
+	Create(ctx context.Context, document *models.IssuedDocument) error
+	FindActive(ctx context.Context, santriID uint, docType models.IssuedDocumentType) (*models.IssuedDocument, error)
+	FindByToken(ctx context.Context, token string) (*models.IssuedDocument, error)
+	FindByID(ctx context.Context, id uint) (*models.IssuedDocument, error)
+	FindBySantriID(ctx context.Context, santriID uint) ([]models.IssuedDocument, error)
+	Revoke(ctx context.Context, id uint, revokedBy uint, reason string) error
+}
+
+type issuedDocumentRepository struct {
+	db *gorm.DB
+}
+
+func NewIssuedDocumentRepository(db *gorm.DB) IssuedDocumentRepository {
+	return &issuedDocumentRepository{db}
+}
+
+func (r *issuedDocumentRepository) Create(ctx context.Context, document *models.IssuedDocument) error {
+	return utils.HandleDBError(dbFrom(ctx, r.db).Omit("Santri", "Revoker").Create(document).Error)
+}
+
+func (r *issuedDocumentRepository) FindActive(ctx context.Context, santriID uint, docType models.IssuedDocumentType) (*models.IssuedDocument, error) {
+	var document models.IssuedDocument
+	err := dbFrom(ctx, r.db).
+		Where("santri_id = ? AND document_type = ? AND revoked_at IS NULL", santriID, docType).
+		First(&document).Error
+	return &document, utils.HandleDBError(err)
+}
+
+func (r *issuedDocumentRepository) FindByToken(ctx context.Context, token string) (*models.IssuedDocument, error) {
+	var document models.IssuedDocument
+	err := dbFrom(ctx, r.db).Preload("Santri").Where("token = ?", token).First(&document).Error
+	return &document, utils.HandleDBError(err)
+}
+
+func (r *issuedDocumentRepository) FindByID(ctx context.Context, id uint) (*models.IssuedDocument, error) {
+	var document models.IssuedDocument
+	err := dbFrom(ctx, r.db).First(&document, id).Error
+	return &document, utils.HandleDBError(err)
+}
+
+func (r *issuedDocumentRepository) FindBySantriID(ctx context.Context, santriID uint) ([]models.IssuedDocument, error) {
+	var documents []models.IssuedDocument
+	err := dbFrom(ctx, r.db).
+		Preload("Revoker").
+		Where("santri_id = ?", santriID).
+		Order("created_at desc, id desc").
+		Find(&documents).Error
+	return documents, utils.HandleDBError(err)
+}
+
+// Revoke marks a document as revoked. Revoking an already revoked document is a conflict.
+func (r *issuedDocumentRepository) Revoke(ctx context.Context, id uint, revokedBy uint, reason string) error {
+	result := dbFrom(ctx, r.db).Model(&models.IssuedDocument{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at":    time.Now(),
+			"revoked_by":    revokedBy,
+			"revoke_reason": reason,
+		})
+	if result.Error != nil {
+		return utils.HandleDBError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return utils.NewAppError(409, "Document is already revoked")
+	}
+	return nil
+}
+
+// revokeIssuedDocuments revokes every active document of a santri. It is used inside the status
+// transition transaction so a rejected santri can no longer present a valid card or letter.
+func revokeIssuedDocuments(tx *gorm.DB, santriID uint, revokedBy *uint, reason string) error {
+	err := tx.Model(&models.IssuedDocument{}).
+		Where("santri_id = ? AND revoked_at IS NULL", santriID).
+		Updates(map[string]interface{}{
+			"revoked_at":    time.Now(),
+			"revoked_by":    revokedBy,
+			"revoke_reason": reason,
+		}).Error
+	return utils.HandleDBError(err)
+}
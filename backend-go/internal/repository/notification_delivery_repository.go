@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/utils"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type NotificationDeliveryRepository interface {
+	Create(ctx context.Context, delivery *models.NotificationDelivery) error
+	FindByID(ctx context.Context, id uint) (*models.NotificationDelivery, error)
+	FindBySantri(ctx context.Context, santriID uint) ([]models.NotificationDelivery, error)
+	MarkSent(ctx context.Context, id uint, at time.Time) error
+	MarkFailed(ctx context.Context, id uint, errMsg string) error
+	MarkSkipped(ctx context.Context, id uint, reason string) error
+}
+
+type notificationDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationDeliveryRepository(db *gorm.DB) NotificationDeliveryRepository {
+	return &notificationDeliveryRepository{db}
+}
+
+func (r *notificationDeliveryRepository) Create(ctx context.Context, delivery *models.NotificationDelivery) error {
+	return utils.HandleDBError(dbFrom(ctx, r.db).Create(delivery).Error)
+}
+
+func (r *notificationDeliveryRepository) FindByID(ctx context.Context, id uint) (*models.NotificationDelivery, error) {
+	var delivery models.NotificationDelivery
+	if err := dbFrom(ctx, r.db).First(&delivery, id).Error; err != nil {
+		return nil, utils.HandleDBError(err)
+	}
+	return &delivery, nil
+}
+
+func (r *notificationDeliveryRepository) FindBySantri(ctx context.Context, santriID uint) ([]models.NotificationDelivery, error) {
+	var deliveries []models.NotificationDelivery
+	err := dbFrom(ctx, r.db).Where("santri_id = ?", santriID).Order("created_at desc, id desc").Find(&deliveries).Error
+	return deliveries, utils.HandleDBError(err)
+}
+
+func (r *notificationDeliveryRepository) MarkSent(ctx context.Context, id uint, at time.Time) error {
+	err := dbFrom(ctx, r.db).Model(&models.NotificationDelivery{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     models.DeliverySent,
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": "",
+		"sent_at":    at,
+	}).Error
+	return utils.HandleDBError(err)
+}
+
+func (r *notificationDeliveryRepository) MarkFailed(ctx context.Context, id uint, errMsg string) error {
+	err := dbFrom(ctx, r.db).Model(&models.NotificationDelivery{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     models.DeliveryFailed,
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": errMsg,
+	}).Error
+	return utils.HandleDBError(err)
+}
+
+func (r *notificationDeliveryRepository) MarkSkipped(ctx context.Context, id uint, reason string) error {
+	err := dbFrom(ctx, r.db).Model(&models.NotificationDelivery{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     models.DeliverySkipped,
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": reason,
+	}).Error
+	return utils.HandleDBError(err)
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/utils"
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationTemplateRepository interface {
+	FindAll(ctx context.Context) ([]models.NotificationTemplate, error)
+	Find(ctx context.Context, event, locale string) (*models.NotificationTemplate, error)
+	Upsert(ctx context.Context, template *models.NotificationTemplate) error
+	Delete(ctx context.Context, event, locale string) error
+}
+
+type notificationTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationTemplateRepository(db *gorm.DB) NotificationTemplateRepository {
+	return &notificationTemplateRepository{db}
+}
+
+func (r *notificationTemplateRepository) FindAll(ctx context.Context) ([]models.NotificationTemplate, error) {
+	var templates []models.NotificationTemplate
+	err := dbFrom(ctx, r.db).Order("event, locale").Find(&templates).Error
+	return templates, utils.HandleDBError(err)
+}
+
+func (r *notificationTemplateRepository) Find(ctx context.Context, event, locale string) (*models.NotificationTemplate, error) {
+	var template models.NotificationTemplate
+	if err := dbFrom(ctx, r.db).Where("event = ? AND locale = ?", event, locale).Take(&template).Error; err != nil {
+		return nil, utils.HandleDBError(err)
+	}
+	return &template, nil
+}
+
+// Upsert stores the override for the template's event and locale, replacing an earlier one
+func (r *notificationTemplateRepository) Upsert(ctx context.Context, template *models.NotificationTemplate) error {
+	err := dbFrom(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"subject", "text_body", "html_body", "updated_by_id", "updated_at"}),
+	}).Create(template).Error
+	return utils.HandleDBError(err)
+}
+
+func (r *notificationTemplateRepository) Delete(ctx context.Context, event, locale string) error {
+	result := dbFrom(ctx, r.db).Where("event = ? AND locale = ?", event, locale).Delete(&models.NotificationTemplate{})
+	if result.Error != nil {
+		return utils.HandleDBError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+	return nil
+}
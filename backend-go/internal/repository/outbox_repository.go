@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/utils"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepository interface {
+	Enqueue(ctx context.Context, job *models.OutboxJob) error
+	Claim(ctx context.Context, now time.Time, lease time.Duration) (*models.OutboxJob, error)
+	MarkDone(ctx context.Context, id uint, now time.Time) error
+	MarkFailed(ctx context.Context, job *models.OutboxJob, errMsg string, now time.Time) error
+	FindAll(ctx context.Context, status string, jobType string, page, limit int) ([]models.OutboxJob, int64, error)
+	FindByID(ctx context.Context, id uint) (*models.OutboxJob, error)
+	Replay(ctx context.Context, id uint, now time.Time) error
+	ReplayDead(ctx context.Context, jobType string, now time.Time) (int64, error)
+	DeleteDoneBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{db}
+}
+
+// Enqueue stores a job. Called with a context from TxManager, the job is only visible once that transaction commits.
+func (r *outboxRepository) Enqueue(ctx context.Context, job *models.OutboxJob) error {
+	return utils.HandleDBError(dbFrom(ctx, r.db).Create(job).Error)
+}
+
+// Claim leases the next job that is due, or whose previous worker's lease ran out, and counts the attempt.
+// It returns nil when there is nothing to do. SKIP LOCKED lets several workers and instances claim in parallel.
+func (r *outboxRepository) Claim(ctx context.Context, now time.Time, lease time.Duration) (*models.OutboxJob, error) {
+	var job models.OutboxJob
+
+	err := dbFrom(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("(status = ? AND available_at <= ?) OR (status = ? AND locked_until < ?)",
+				models.OutboxPending, now, models.OutboxProcessing, now).
+			Order("available_at, id").
+			Take(&job).Error
+		if err != nil {
+			return err
+		}
+
+		lockedUntil := now.Add(lease)
+		job.Status = models.OutboxProcessing
+		job.Attempts++
+		job.LockedUntil = &lockedUntil
+		return tx.Model(&job).Updates(map[string]interface{}{
+			"status":       job.Status,
+			"attempts":     job.Attempts,
+			"locked_until": job.LockedUntil,
+		}).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, utils.HandleDBError(err)
+	}
+	return &job, nil
+}
+
+func (r *outboxRepository) MarkDone(ctx context.Context, id uint, now time.Time) error {
+	err := dbFrom(ctx, r.db).Model(&models.OutboxJob{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       models.OutboxDone,
+		"locked_until": nil,
+		"processed_at": now,
+		"last_error":   "",
+	}).Error
+	return utils.HandleDBError(err)
+}
+
+// MarkFailed schedules the next attempt with exponential backoff, or dead-letters the job when it has no attempts left
+func (r *outboxRepository) MarkFailed(ctx context.Context, job *models.OutboxJob, errMsg string, now time.Time) error {
+	updates := map[string]interface{}{
+		"locked_until": nil,
+		"last_error":   errMsg,
+	}
+	if next, ok := job.NextAttemptAt(now); ok {
+		updates["status"] = models.OutboxPending
+		updates["available_at"] = next
+	} else {
+		updates["status"] = models.OutboxDead
+		updates["processed_at"] = now
+	}
+
+	err := dbFrom(ctx, r.db).Model(&models.OutboxJob{}).Where("id = ?", job.ID).Updates(updates).Error
+	return utils.HandleDBError(err)
+}
+
+func (r *outboxRepository) FindAll(ctx context.Context, status string, jobType string, page, limit int) ([]models.OutboxJob, int64, error) {
+	var jobs []models.OutboxJob
+	var total int64
+
+	query := dbFrom(ctx, r.db).Model(&models.OutboxJob{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if jobType != "" {
+		query = query.Where("type = ?", jobType)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, utils.HandleDBError(err)
+	}
+
+	err := query.Order("created_at desc, id desc").Offset((page - 1) * limit).Limit(limit).Find(&jobs).Error
+	return jobs, total, utils.HandleDBError(err)
+}
+
+func (r *outboxRepository) FindByID(ctx context.Context, id uint) (*models.OutboxJob, error) {
+	var job models.OutboxJob
+	if err := dbFrom(ctx, r.db).First(&job, id).Error; err != nil {
+		return nil, utils.HandleDBError(err)
+	}
+	return &job, nil
+}
+
+// Replay puts a dead job back in the queue with a fresh set of attempts
+func (r *outboxRepository) Replay(ctx context.Context, id uint, now time.Time) error {
+	result := dbFrom(ctx, r.db).Model(&models.OutboxJob{}).
+		Where("id = ? AND status = ?", id, models.OutboxDead).
+		Updates(replayUpdates(now))
+	if result.Error != nil {
+		return utils.HandleDBError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		if _, err := r.FindByID(ctx, id); err != nil {
+			return err
+		}
+		return utils.NewAppError(409, "Only dead jobs can be replayed")
+	}
+	return nil
+}
+
+// ReplayDead puts every dead job, optionally of one type, back in the queue
+func (r *outboxRepository) ReplayDead(ctx context.Context, jobType string, now time.Time) (int64, error) {
+	query := dbFrom(ctx, r.db).Model(&models.OutboxJob{}).Where("status = ?", models.OutboxDead)
+	if jobType != "" {
+		query = query.Where("type = ?", jobType)
+	}
+	result := query.Updates(replayUpdates(now))
+	return result.RowsAffected, utils.HandleDBError(result.Error)
+}
+
+// DeleteDoneBefore removes completed jobs processed before the given time. Dead jobs are kept for inspection.
+func (r *outboxRepository) DeleteDoneBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := dbFrom(ctx, r.db).
+		Where("status = ? AND processed_at < ?", models.OutboxDone, before).
+		Delete(&models.OutboxJob{})
+	return result.RowsAffected, utils.HandleDBError(result.Error)
+}
+
+func replayUpdates(now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"status":       models.OutboxPending,
+		"attempts":     0,
+		"available_at": now,
+		"locked_until": nil,
+		"processed_at": nil,
+	}
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/utils"
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type RoleRepository interface {
+	FindAll(ctx context.Context) ([]models.Role, error)
+	FindByID(ctx context.Context, id uint) (*models.Role, error)
+	FindByName(ctx context.Context, name string) (*models.Role, error)
+	Create(ctx context.Context, role *models.Role) error
+	Update(ctx context.Context, role *models.Role) error
+	Delete(ctx context.Context, id uint) error
+	CountUsers(ctx context.Context, name string) (int64, error)
+	FindUserIDs(ctx context.Context, name string) ([]uint, error)
+	FindPermissions(ctx context.Context) ([]models.Permission, error)
+	FindPermissionsByCodes(ctx context.Context, codes []string) ([]models.Permission, error)
+	FindPermissionCodes(ctx context.Context, roleName string) ([]string, error)
+}
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepository{db}
+}
+
+func (r *roleRepository) FindAll(ctx context.Context) ([]models.Role, error) {
+	var roles []models.Role
+	err := dbFrom(ctx, r.db).Preload("Permissions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("code asc")
+	}).Order("name asc").Find(&roles).Error
+	return roles, utils.HandleDBError(err)
+}
+
+func (r *roleRepository) FindByID(ctx context.Context, id uint) (*models.Role, error) {
+	var role models.Role
+	err := dbFrom(ctx, r.db).Preload("Permissions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("code asc")
+	}).First(&role, id).Error
+	if err != nil {
+		return nil, utils.HandleDBError(err)
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) FindByName(ctx context.Context, name string) (*models.Role, error) {
+	var role models.Role
+	if err := dbFrom(ctx, r.db).Where("name = ?", name).First(&role).Error; err != nil {
+		return nil, utils.HandleDBError(err)
+	}
+	return &role, nil
+}
+
+// Create saves the role with its permissions, which must already exist
+func (r *roleRepository) Create(ctx context.Context, role *models.Role) error {
+	return utils.HandleDBError(dbFrom(ctx, r.db).Omit("Permissions.*").Create(role).Error)
+}
+
+// Update saves the role and replaces its permissions with role.Permissions
+func (r *roleRepository) Update(ctx context.Context, role *models.Role) error {
+	return utils.HandleDBError(dbFrom(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Permissions").Save(role).Error; err != nil {
+			return err
+		}
+		return tx.Model(role).Omit("Permissions.*").Association("Permissions").Replace(role.Permissions)
+	}))
+}
+
+// Delete removes the role. The database refuses while any user, including a soft-deleted one, still holds it.
+func (r *roleRepository) Delete(ctx context.Context, id uint) error {
+	err := dbFrom(ctx, r.db).Delete(&models.Role{}, id).Error
+	if err != nil && (strings.Contains(err.Error(), "23503") || strings.Contains(err.Error(), "foreign key constraint")) {
+		return utils.NewAppError(409, "Role is still held by deleted admin accounts")
+	}
+	return utils.HandleDBError(err)
+}
+
+// CountUsers counts the active users with the role
+func (r *roleRepository) CountUsers(ctx context.Context, name string) (int64, error) {
+	var count int64
+	err := dbFrom(ctx, r.db).Model(&models.User{}).Where("role = ?", name).Count(&count).Error
+	return count, utils.HandleDBError(err)
+}
+
+// FindUserIDs returns the IDs of the active users with the role
+func (r *roleRepository) FindUserIDs(ctx context.Context, name string) ([]uint, error) {
+	var ids []uint
+	err := dbFrom(ctx, r.db).Model(&models.User{}).Where("role = ?", name).Order("id asc").Pluck("id", &ids).Error
+	return ids, utils.HandleDBError(err)
+}
+
+func (r *roleRepository) FindPermissions(ctx context.Context) ([]models.Permission, error) {
+	var permissions []models.Permission
+	err := dbFrom(ctx, r.db).Order("code asc").Find(&permissions).Error
+	return permissions, utils.HandleDBError(err)
+}
+
+func (r *roleRepository) FindPermissionsByCodes(ctx context.Context, codes []string) ([]models.Permission, error) {
+	var permissions []models.Permission
+	if len(codes) == 0 {
+		return permissions, nil
+	}
+	err := dbFrom(ctx, r.db).Where("code IN ?", codes).Order("code asc").Find(&permissions).Error
+	return permissions, utils.HandleDBError(err)
+}
+
+// FindPermissionCodes returns the permission codes of a role, for embedding in access tokens
+func (r *roleRepository) FindPermissionCodes(ctx context.Context, roleName string) ([]string, error) {
+	var codes []string
+	err := dbFrom(ctx, r.db).Table("permissions").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN roles ON roles.id = role_permissions.role_id").
+		Where("roles.name = ?", roleName).
+		Order("permissions.code asc").
+		Pluck("permissions.code", &codes).Error
+	return codes, utils.HandleDBError(err)
+}
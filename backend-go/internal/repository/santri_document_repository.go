@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/utils"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type SantriDocumentRepository interface {
+	Create(ctx context.Context, document *models.SantriDocument) error
+	FindBySantriID(ctx context.Context, santriID uint) ([]models.SantriDocument, error)
+	FindBySantriAndType(ctx context.Context, santriID uint, docType models.DocumentType) (*models.SantriDocument, error)
+	FindByID(ctx context.Context, id uint) (*models.SantriDocument, error)
+	Update(ctx context.Context, document *models.SantriDocument) error
+}
+
+type santriDocumentRepository struct {
+	db *gorm.DB
+}
+
+func NewSantriDocumentRepository(db *gorm.DB) SantriDocumentRepository {
+	return &santriDocumentRepository{db}
+}
+
+func (r *santriDocumentRepository) Create(ctx context.Context, document *models.SantriDocument) error {
+	return utils.HandleDBError(dbFrom(ctx, r.db).Create(document).Error)
+}
+
+func (r *santriDocumentRepository) FindBySantriID(ctx context.Context, santriID uint) ([]models.SantriDocument, error) {
+	var documents []models.SantriDocument
+	err := dbFrom(ctx, r.db).
+		Preload("Verifier").
+		Where("santri_id = ?", santriID).
+		Order("type").
+		Find(&documents).Error
+	return documents, utils.HandleDBError(err)
+}
+
+func (r *santriDocumentRepository) FindBySantriAndType(ctx context.Context, santriID uint, docType models.DocumentType) (*models.SantriDocument, error) {
+	var document models.SantriDocument
+	err := dbFrom(ctx, r.db).Where("santri_id = ? AND type = ?", santriID, docType).First(&document).Error
+	return &document, utils.HandleDBError(err)
+}
+
+func (r *santriDocumentRepository) FindByID(ctx context.Context, id uint) (*models.SantriDocument, error) {
+	var document models.SantriDocument
+	err := dbFrom(ctx, r.db).First(&document, id).Error
+	return &document, utils.HandleDBError(err)
+}
+
+func (r *santriDocumentRepository) Update(ctx context.Context, document *models.SantriDocument) error {
+	return utils.HandleDBError(dbFrom(ctx, r.db).Omit("Verifier").Save(document).Error)
+}
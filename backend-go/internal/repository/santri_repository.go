@@ -4,23 +4,37 @@ import (
 	"backend-go/internal/models"
 	"backend-go/internal/utils"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type SantriRepository interface {
 	Create(ctx context.Context, santri *models.Santri) error
+	CreateInWave(ctx context.Context, santri *models.Santri) error
 	FindAll(ctx context.Context) ([]models.Santri, error)
-	FindAllPaginated(ctx context.Context, page, limit int, status string) ([]models.Santri, int64, error)
+	FindFiltered(ctx context.Context, status string, waveID uint) ([]models.Santri, error)
+	FindAllPaginated(ctx context.Context, page, limit int, status string, waveID uint) ([]models.Santri, int64, error)
 	FindByStatus(ctx context.Context, status models.SantriStatus) ([]models.Santri, error)
 	FindByID(ctx context.Context, id uint) (*models.Santri, error)
+	FindByNIK(ctx context.Context, nik string) (*models.Santri, error)
+	FindByRegistrationCode(ctx context.Context, code string) (*models.Santri, error)
 	Update(ctx context.Context, santri *models.Santri) error
 	Delete(ctx context.Context, id uint) error
-	UpdateStatus(ctx context.Context, id uint, status models.SantriStatus) error
-	UpdateAcademicInfo(ctx context.Context, id uint, nis string, class string, entryYear int) error
-	VerifyAndAcceptSantri(ctx context.Context, id uint, nis string, class string, entryYear int) error
+	UpdateStatus(ctx context.Context, history *models.SantriStatusHistory) error
+	AcceptSantri(ctx context.Context, history *models.SantriStatusHistory, class string, entryYear int, nisPattern string) (string, error)
+	ApplySelection(ctx context.Context, waveID uint, accepts, rejects []*models.SantriStatusHistory, class string, entryYear int, nisPattern string) (map[uint]string, error)
+	FindStatusHistory(ctx context.Context, santriID uint) ([]models.SantriStatusHistory, error)
+	FindDuplicateCandidates(ctx context.Context, santri *models.Santri) ([]models.Santri, error)
+	Merge(ctx context.Context, primaryID, duplicateID uint, actorID *uint) error
 	Count(ctx context.Context) (int64, error)
 	CountByStatus(ctx context.Context, status models.SantriStatus) (int64, error)
+	CountByWave(ctx context.Context, waveID uint) (int64, error)
 }
 
 type santriRepository struct {
@@ -32,27 +46,57 @@ func NewSantriRepository(db *gorm.DB) SantriRepository {
 }
 
 func (r *santriRepository) Create(ctx context.Context, santri *models.Santri) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Create(santri).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Create(santri).Error)
+}
+
+// CreateInWave stores a registrant in santri.WaveID while enforcing the wave's per-gender quota.
+// The wave row is locked for the duration of the transaction so concurrent registrations
+// cannot both take the last seat. Rejected registrants do not count against the quota.
+func (r *santriRepository) CreateInWave(ctx context.Context, santri *models.Santri) error {
+	return dbFrom(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var wave models.AdmissionWave
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&wave, *santri.WaveID).Error; err != nil {
+			return utils.HandleDBError(err)
+		}
+
+		if quota := wave.QuotaFor(santri.Gender); quota > 0 {
+			var count int64
+			if err := tx.Model(&models.Santri{}).
+				Where("wave_id = ? AND gender = ? AND status <> ?", wave.ID, santri.Gender, models.StatusRejected).
+				Count(&count).Error; err != nil {
+				return utils.HandleDBError(err)
+			}
+			if count >= int64(quota) {
+				return utils.NewAppError(409, "Registration quota for this wave is full")
+			}
+		}
+
+		return utils.HandleDBError(tx.Create(santri).Error)
+	})
 }
 
 func (r *santriRepository) FindAll(ctx context.Context) ([]models.Santri, error) {
 	var santris []models.Santri
-	err := r.db.WithContext(ctx).Order("created_at desc").Find(&santris).Error
+	err := dbFrom(ctx, r.db).Order("created_at desc").Find(&santris).Error
 	return santris, utils.HandleDBError(err)
 }
 
-func (r *santriRepository) FindAllPaginated(ctx context.Context, page, limit int, status string) ([]models.Santri, int64, error) {
+// FindFiltered returns registrants (with wave, guardians and prior education) matching the optional status and wave filters
+func (r *santriRepository) FindFiltered(ctx context.Context, status string, waveID uint) ([]models.Santri, error) {
+	var santris []models.Santri
+	query := filterSantris(dbFrom(ctx, r.db).Model(&models.Santri{}), status, waveID)
+	err := query.Preload("Wave").Preload("Guardians").Preload("PriorEducation").Order("created_at desc").Find(&santris).Error
+	return santris, utils.HandleDBError(err)
+}
+
+func (r *santriRepository) FindAllPaginated(ctx context.Context, page, limit int, status string, waveID uint) ([]models.Santri, int64, error) {
 	var santris []models.Santri
 	var total int64
 
 	offset := (page - 1) * limit
 
-	query := r.db.WithContext(ctx).Model(&models.Santri{})
-
-	// Apply status filter if provided
-	if status != "" {
-		query = query.Where("status = ?", status)
-	}
+	// Apply status and wave filters if provided
+	query := filterSantris(dbFrom(ctx, r.db).Model(&models.Santri{}), status, waveID)
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
@@ -60,85 +104,406 @@ func (r *santriRepository) FindAllPaginated(ctx context.Context, page, limit int
 	}
 
 	// Fetch paginated
-	err := query.Order("created_at desc").Offset(offset).Limit(limit).Find(&santris).Error
+	err := query.Preload("Wave").Order("created_at desc").Offset(offset).Limit(limit).Find(&santris).Error
 	return santris, total, utils.HandleDBError(err)
 }
 
+// filterSantris applies the optional status and wave filters shared by the listing queries
+func filterSantris(query *gorm.DB, status string, waveID uint) *gorm.DB {
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if waveID != 0 {
+		query = query.Where("wave_id = ?", waveID)
+	}
+	return query
+}
+
 func (r *santriRepository) FindByStatus(ctx context.Context, status models.SantriStatus) ([]models.Santri, error) {
 	var santris []models.Santri
-	err := r.db.WithContext(ctx).Where("status = ?", status).Order("created_at desc").Find(&santris).Error
+	err := dbFrom(ctx, r.db).Where("status = ?", status).Order("created_at desc").Find(&santris).Error
 	return santris, utils.HandleDBError(err)
 }
 
 func (r *santriRepository) FindByID(ctx context.Context, id uint) (*models.Santri, error) {
 	var santri models.Santri
-	err := r.db.WithContext(ctx).First(&santri, id).Error
+	err := dbFrom(ctx, r.db).
+		Preload("Wave").
+		Preload("Guardians", func(db *gorm.DB) *gorm.DB { return db.Order("relation") }).
+		Preload("PriorEducation").
+		First(&santri, id).Error
+	return &santri, utils.HandleDBError(err)
+}
+
+func (r *santriRepository) FindByNIK(ctx context.Context, nik string) (*models.Santri, error) {
+	var santri models.Santri
+	err := dbFrom(ctx, r.db).Where("nik = ?", nik).First(&santri).Error
+	return &santri, utils.HandleDBError(err)
+}
+
+func (r *santriRepository) FindByRegistrationCode(ctx context.Context, code string) (*models.Santri, error) {
+	var santri models.Santri
+	err := dbFrom(ctx, r.db).Preload("Wave").Where("registration_code = ?", code).First(&santri).Error
 	return &santri, utils.HandleDBError(err)
 }
 
 func (r *santriRepository) Update(ctx context.Context, santri *models.Santri) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Save(santri).Error)
+	// Guardians and prior education are owned by the registrant and saved with it;
+	// the preloaded wave is reference data and is never written back
+	return utils.HandleDBError(dbFrom(ctx, r.db).
+		Session(&gorm.Session{FullSaveAssociations: true}).
+		Omit("Wave").
+		Save(santri).Error)
 }
 
 func (r *santriRepository) Delete(ctx context.Context, id uint) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Delete(&models.Santri{}, id).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Delete(&models.Santri{}, id).Error)
 }
 
-func (r *santriRepository) UpdateStatus(ctx context.Context, id uint, status models.SantriStatus) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Model(&models.Santri{}).Where("id = ?", id).Update("status", status).Error)
+// UpdateStatus moves a santri from history.FromStatus to history.ToStatus and records the transition
+// in the same transaction. The update is guarded on the current status, so two concurrent
+// transitions from the same state cannot both succeed.
+func (r *santriRepository) UpdateStatus(ctx context.Context, history *models.SantriStatusHistory) error {
+	return dbFrom(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return applyStatusTransition(tx, history, map[string]interface{}{"status": history.ToStatus})
+	})
 }
 
-func (r *santriRepository) UpdateAcademicInfo(ctx context.Context, id uint, nis string, class string, entryYear int) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Model(&models.Santri{}).Where("id = ?", id).Updates(map[string]interface{}{
+// AcceptSantri accepts a santri in one transaction: it locks the santri row, draws the next number
+// from the NIS sequence, and stores the NIS, class, entry year and status transition together.
+// The sequence is an upsert on nis_sequences, so concurrent acceptances never get the same number.
+// The santri's wave is locked first, as in ApplySelection, so a selection run counts this acceptance.
+func (r *santriRepository) AcceptSantri(ctx context.Context, history *models.SantriStatusHistory, class string, entryYear int, nisPattern string) (string, error) {
+	var nis string
+	err := dbFrom(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var santri models.Santri
+		if err := tx.Select("id", "wave_id").First(&santri, history.SantriID).Error; err != nil {
+			return utils.HandleDBError(err)
+		}
+		if santri.WaveID != nil {
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&models.AdmissionWave{}, *santri.WaveID).Error; err != nil {
+				return utils.HandleDBError(err)
+			}
+		}
+
+		var err error
+		nis, err = acceptSantri(tx, history, class, entryYear, nisPattern)
+		return err
+	})
+	return nis, err
+}
+
+// ApplySelection runs a wave's selection in one transaction: every registrant in accepts goes through the
+// acceptance flow of AcceptSantri and every one in rejects is rejected. The wave row is locked, the same lock
+// AcceptSantri takes, and the accepted registrants are recounted under it, so a selection run that would overfill
+// the quota because of a concurrent acceptance fails instead. It returns the NIS assigned to each accepted santri.
+func (r *santriRepository) ApplySelection(ctx context.Context, waveID uint, accepts, rejects []*models.SantriStatusHistory, class string, entryYear int, nisPattern string) (map[uint]string, error) {
+	assigned := make(map[uint]string, len(accepts))
+	err := dbFrom(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var wave models.AdmissionWave
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&wave, waveID).Error; err != nil {
+			return utils.HandleDBError(err)
+		}
+
+		for _, history := range accepts {
+			nis, err := acceptSantri(tx, history, class, entryYear, nisPattern)
+			if err != nil {
+				return err
+			}
+			assigned[history.SantriID] = nis
+		}
+		if err := checkAcceptedQuota(tx, &wave); err != nil {
+			return err
+		}
+
+		for _, history := range rejects {
+			if err := applyStatusTransition(tx, history, map[string]interface{}{"status": history.ToStatus}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assigned, nil
+}
+
+// checkAcceptedQuota fails when more registrants of the wave are accepted than its quota for their gender allows.
+// It must be called inside a transaction that holds the wave row lock.
+func checkAcceptedQuota(tx *gorm.DB, wave *models.AdmissionWave) error {
+	for _, gender := range []string{"L", "P"} {
+		quota := wave.QuotaFor(gender)
+		if quota <= 0 {
+			continue
+		}
+
+		query := tx.Model(&models.Santri{}).Where("wave_id = ? AND status = ?", wave.ID, models.StatusAccepted)
+		if gender == "P" {
+			query = query.Where("gender = ?", "P")
+		} else {
+			query = query.Where("gender <> ?", "P")
+		}
+		var count int64
+		if err := query.Count(&count).Error; err != nil {
+			return utils.HandleDBError(err)
+		}
+		if count > int64(quota) {
+			return utils.NewAppError(409, "Not enough seats are left in this wave anymore, please reload the ranking and try again")
+		}
+	}
+	return nil
+}
+
+// acceptSantri is the acceptance flow shared by AcceptSantri and ApplySelection. It must be called inside a transaction.
+func acceptSantri(tx *gorm.DB, history *models.SantriStatusHistory, class string, entryYear int, nisPattern string) (string, error) {
+	var santri models.Santri
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&santri, history.SantriID).Error; err != nil {
+		return "", utils.HandleDBError(err)
+	}
+	if santri.Status != history.FromStatus {
+		return "", utils.NewAppError(409, "Registrant status was changed by another request, please reload and try again")
+	}
+
+	// A santri accepted before (and later rejected and re-opened) keeps their NIS
+	if santri.NIS != nil && *santri.NIS != "" {
+		return *santri.NIS, applyStatusTransition(tx, history, map[string]interface{}{
+			"class":      class,
+			"entry_year": entryYear,
+			"status":     history.ToStatus,
+		})
+	}
+
+	var seq int64
+	err := tx.Raw(`INSERT INTO nis_sequences (scope, last_value) VALUES (?, 1)
+		ON CONFLICT (scope) DO UPDATE SET last_value = nis_sequences.last_value + 1, updated_at = CURRENT_TIMESTAMP
+		RETURNING last_value`, utils.NISSequenceScope(nisPattern, entryYear, santri.Gender)).Scan(&seq).Error
+	if err != nil {
+		return "", utils.HandleDBError(err)
+	}
+
+	nis, err := utils.FormatNIS(nisPattern, entryYear, santri.Gender, seq)
+	if err != nil {
+		return "", err
+	}
+
+	if err := applyStatusTransition(tx, history, map[string]interface{}{
 		"nis":        nis,
 		"class":      class,
 		"entry_year": entryYear,
-		"status":     models.StatusAccepted,
-	}).Error)
+		"status":     history.ToStatus,
+	}); err != nil {
+		if errors.Is(err, utils.ErrConflict) {
+			return "", utils.NewAppError(409, fmt.Sprintf("Generated NIS %s is already in use, please check the NIS pattern", nis))
+		}
+		return "", err
+	}
+
+	return nis, nil
 }
 
-// VerifyAndAcceptSantri performs atomic verification of a santri using a database transaction.
-// This ensures all updates succeed together or none at all.
-func (r *santriRepository) VerifyAndAcceptSantri(ctx context.Context, id uint, nis string, class string, entryYear int) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// First, verify the santri exists and is in a verifiable state
-		var santri models.Santri
-		if err := tx.First(&santri, id).Error; err != nil {
+func (r *santriRepository) FindStatusHistory(ctx context.Context, santriID uint) ([]models.SantriStatusHistory, error) {
+	var history []models.SantriStatusHistory
+	err := dbFrom(ctx, r.db).
+		Preload("Actor").
+		Where("santri_id = ?", santriID).
+		Order("created_at desc, id desc").
+		Find(&history).Error
+	return history, utils.HandleDBError(err)
+}
+
+// applyStatusTransition updates the santri row only if it is still in history.FromStatus,
+// then stores the history entry. Rejecting a santri also revokes their issued documents.
+// It must be called inside a transaction.
+func applyStatusTransition(tx *gorm.DB, history *models.SantriStatusHistory, updates map[string]interface{}) error {
+	result := tx.Model(&models.Santri{}).
+		Where("id = ? AND status = ?", history.SantriID, history.FromStatus).
+		Updates(updates)
+	if result.Error != nil {
+		return utils.HandleDBError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return utils.NewAppError(409, "Registrant status was changed by another request, please reload and try again")
+	}
+
+	if history.ToStatus == models.StatusRejected {
+		reason := "Registration rejected"
+		if history.Reason != "" {
+			reason = history.Reason
+		}
+		if err := revokeIssuedDocuments(tx, history.SantriID, history.ActorID, reason); err != nil {
+			return err
+		}
+	}
+
+	return utils.HandleDBError(tx.Create(history).Error)
+}
+
+// maxDuplicateCandidates caps how many registrants are scored as possible duplicates of one registrant
+const maxDuplicateCandidates = 50
+
+// FindDuplicateCandidates narrows the registrants down to those sharing a birth date (or with day and month
+// swapped), exact name or parent phone with santri. Scoring the candidates is up to the caller.
+func (r *santriRepository) FindDuplicateCandidates(ctx context.Context, santri *models.Santri) ([]models.Santri, error) {
+	birthDates := []string{santri.BirthDate.Format("2006-01-02")}
+	if santri.BirthDate.Day() <= 12 {
+		swapped := time.Date(santri.BirthDate.Year(), time.Month(santri.BirthDate.Day()), int(santri.BirthDate.Month()), 0, 0, 0, 0, time.UTC)
+		birthDates = append(birthDates, swapped.Format("2006-01-02"))
+	}
+
+	match := r.db.Where("DATE(birth_date) IN ?", birthDates).
+		Or("LOWER(TRIM(full_name)) = ?", strings.ToLower(strings.TrimSpace(santri.FullName)))
+	// Compare the last 9 digits so 08xx and +628xx numbers match
+	if phone := utils.NormalizePhone(santri.ParentPhone); len(phone) >= 9 {
+		match = match.Or("RIGHT(regexp_replace(parent_phone, '[^0-9]', '', 'g'), 9) = ?", phone[len(phone)-9:])
+	}
+
+	var santris []models.Santri
+	err := dbFrom(ctx, r.db).
+		Preload("Wave").
+		Where("id <> ?", santri.ID).
+		Where(match).
+		Order("created_at desc").
+		Limit(maxDuplicateCandidates).
+		Find(&santris).Error
+	return santris, utils.HandleDBError(err)
+}
+
+// Merge folds the duplicate registration into the primary one in a single transaction:
+//   - documents move to the primary; when both have the same type, an approved one wins, otherwise the primary's is kept
+//   - status history, guardians and prior education the primary lacks move to the primary
+//   - the duplicate's issued documents are revoked and the duplicate is soft-deleted
+//
+// A SantriMerge row keeps a snapshot of the duplicate and all its documents, including replaced ones.
+func (r *santriRepository) Merge(ctx context.Context, primaryID, duplicateID uint, actorID *uint) error {
+	return dbFrom(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Lock both rows in ID order so concurrent merges cannot deadlock
+		var locked []models.Santri
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", []uint{primaryID, duplicateID}).
+			Order("id").
+			Find(&locked).Error; err != nil {
+			return utils.HandleDBError(err)
+		}
+		if len(locked) != 2 {
+			return utils.ErrNotFound
+		}
+		primary, duplicate := locked[0], locked[1]
+		if primary.ID != primaryID {
+			primary, duplicate = duplicate, primary
+		}
+
+		var primaryDocs, duplicateDocs []models.SantriDocument
+		if err := tx.Where("santri_id = ?", primary.ID).Find(&primaryDocs).Error; err != nil {
+			return utils.HandleDBError(err)
+		}
+		if err := tx.Where("santri_id = ?", duplicate.ID).Find(&duplicateDocs).Error; err != nil {
 			return utils.HandleDBError(err)
 		}
 
-		// Check if already accepted
-		if santri.Status == models.StatusAccepted {
-			return utils.NewAppError(400, "Santri already accepted")
+		snapshot, err := json.Marshal(map[string]interface{}{
+			"santri":    duplicate,
+			"documents": duplicateDocs,
+		})
+		if err != nil {
+			return err
 		}
 
-		// Update to VERIFIED status first
-		if err := tx.Model(&santri).Update("status", models.StatusVerified).Error; err != nil {
+		// Documents
+		byType := make(map[models.DocumentType]models.SantriDocument, len(primaryDocs))
+		for _, doc := range primaryDocs {
+			byType[doc.Type] = doc
+		}
+		for _, doc := range duplicateDocs {
+			existing, conflict := byType[doc.Type]
+			if conflict {
+				if existing.Status == models.DocumentApproved || doc.Status != models.DocumentApproved {
+					continue
+				}
+				if err := tx.Delete(&existing).Error; err != nil {
+					return utils.HandleDBError(err)
+				}
+			}
+			if err := tx.Model(&doc).Update("santri_id", primary.ID).Error; err != nil {
+				return utils.HandleDBError(err)
+			}
+		}
+
+		// Status history
+		if err := tx.Model(&models.SantriStatusHistory{}).
+			Where("santri_id = ?", duplicate.ID).
+			Update("santri_id", primary.ID).Error; err != nil {
 			return utils.HandleDBError(err)
 		}
 
-		// Then update academic info and set to ACCEPTED
-		if err := tx.Model(&santri).Updates(map[string]interface{}{
-			"nis":        nis,
-			"class":      class,
-			"entry_year": entryYear,
-			"status":     models.StatusAccepted,
+		// Guardians and prior education the primary does not have yet
+		if err := tx.Model(&models.Guardian{}).
+			Where("santri_id = ? AND relation NOT IN (?)", duplicate.ID,
+				tx.Model(&models.Guardian{}).Select("relation").Where("santri_id = ?", primary.ID)).
+			Update("santri_id", primary.ID).Error; err != nil {
+			return utils.HandleDBError(err)
+		}
+		var priorCount int64
+		if err := tx.Model(&models.PriorEducation{}).Where("santri_id = ?", primary.ID).Count(&priorCount).Error; err != nil {
+			return utils.HandleDBError(err)
+		}
+		if priorCount == 0 {
+			if err := tx.Model(&models.PriorEducation{}).
+				Where("santri_id = ?", duplicate.ID).
+				Update("santri_id", primary.ID).Error; err != nil {
+				return utils.HandleDBError(err)
+			}
+		}
+
+		// Contact details the primary is missing
+		updates := map[string]interface{}{}
+		if primary.PhotoURL == "" && duplicate.PhotoURL != "" {
+			updates["photo_url"] = duplicate.PhotoURL
+		}
+		if primary.ParentPhone == "" && duplicate.ParentPhone != "" {
+			updates["parent_phone"] = duplicate.ParentPhone
+		}
+		if primary.ParentEmail == "" && duplicate.ParentEmail != "" {
+			updates["parent_email"] = duplicate.ParentEmail
+		}
+		if len(updates) > 0 {
+			if err := tx.Model(&models.Santri{}).Where("id = ?", primary.ID).Updates(updates).Error; err != nil {
+				return utils.HandleDBError(err)
+			}
+		}
+
+		if err := revokeIssuedDocuments(tx, duplicate.ID, actorID, "Merged into registration "+primary.RegistrationCode); err != nil {
+			return err
+		}
+
+		if err := tx.Create(&models.SantriMerge{
+			PrimaryID:  primary.ID,
+			MergedID:   duplicate.ID,
+			MergedCode: duplicate.RegistrationCode,
+			MergedBy:   actorID,
+			Snapshot:   string(snapshot),
 		}).Error; err != nil {
 			return utils.HandleDBError(err)
 		}
 
-		return nil
+		return utils.HandleDBError(tx.Delete(&models.Santri{}, duplicate.ID).Error)
 	})
 }
 
 func (r *santriRepository) Count(ctx context.Context) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&models.Santri{}).Count(&count).Error
+	err := dbFrom(ctx, r.db).Model(&models.Santri{}).Count(&count).Error
 	return count, utils.HandleDBError(err)
 }
 
 func (r *santriRepository) CountByStatus(ctx context.Context, status models.SantriStatus) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&models.Santri{}).Where("status = ?", status).Count(&count).Error
+	err := dbFrom(ctx, r.db).Model(&models.Santri{}).Where("status = ?", status).Count(&count).Error
+	return count, utils.HandleDBError(err)
+}
+
+func (r *santriRepository) CountByWave(ctx context.Context, waveID uint) (int64, error) {
+	var count int64
+	err := dbFrom(ctx, r.db).Model(&models.Santri{}).Where("wave_id = ?", waveID).Count(&count).Error
 	return count, utils.HandleDBError(err)
 }
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/utils"
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// SearchRepository searches all public content at once: published articles, galleries, photo captions, videos and
+// achievements
+type SearchRepository interface {
+	Search(ctx context.Context, query, searchType string, page, limit int) ([]models.SearchHit, map[string]int64, error)
+}
+
+type searchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) SearchRepository {
+	return &searchRepository{db}
+}
+
+// escapedText HTML-escapes a plain text column, so its headline is as safe to render as an article's
+func escapedText(column string) string {
+	return fmt.Sprintf("replace(replace(replace(COALESCE(%s, ''), '&', '&amp;'), '<', '&lt;'), '>', '&gt;')", column)
+}
+
+// searchDocument weighs the title of a row above the rest of its text
+func searchDocument(title string, body ...string) string {
+	doc := fmt.Sprintf("setweight(to_tsvector('%s', COALESCE(%s, '')), 'A')", textSearchConfig, title)
+	if len(body) > 0 {
+		doc += fmt.Sprintf(" || setweight(to_tsvector('%s', concat_ws(' ', %s)), 'B')", textSearchConfig, strings.Join(body, ", "))
+	}
+	return doc
+}
+
+func headline(text string) string {
+	return fmt.Sprintf("ts_headline('%s', %s, q.query, '%s')", textSearchConfig, text, articleHeadlineOptions)
+}
+
+// searchHits is every match of @query as (type, id, parent_id, title, slug, thumbnail_url, snippet, rank,
+// created_at). Only articles have a slug. Articles use their indexed search vector; the other tables are small enough
+// to match as they are.
+var searchHits = fmt.Sprintf(`WITH q AS (SELECT websearch_to_tsquery('%[1]s', @query) AS query),
+hits AS (
+	SELECT '%[2]s' AS type, a.id, NULL::integer AS parent_id, a.title, COALESCE(a.slug, '') AS slug, COALESCE(a.thumbnail_url, '') AS thumbnail_url,
+		%[7]s AS snippet, ts_rank(a.search_vector, q.query) AS rank, a.created_at
+	FROM articles a CROSS JOIN q
+	WHERE a.deleted_at IS NULL AND a.status = '%[8]s' AND a.search_vector @@ q.query
+	UNION ALL
+	SELECT '%[3]s', g.id, NULL, g.title, '', COALESCE(g.cover_url, ''),
+		%[9]s, ts_rank(%[10]s, q.query), g.created_at
+	FROM galleries g CROSS JOIN q
+	WHERE g.deleted_at IS NULL AND %[10]s @@ q.query
+	UNION ALL
+	SELECT '%[4]s', p.id, p.gallery_id, p.caption, '', p.photo_url,
+		%[11]s, ts_rank(%[12]s, q.query), p.created_at
+	FROM photos p JOIN galleries g ON g.id = p.gallery_id AND g.deleted_at IS NULL CROSS JOIN q
+	WHERE %[12]s @@ q.query
+	UNION ALL
+	SELECT '%[5]s', v.id, NULL, v.title, '', COALESCE(NULLIF(v.thumbnail, ''), 'https://img.youtube.com/vi/' || v.youtube_id || '/hqdefault.jpg'),
+		%[13]s, ts_rank(%[14]s, q.query), v.created_at
+	FROM videos v CROSS JOIN q
+	WHERE v.deleted_at IS NULL AND %[14]s @@ q.query
+	UNION ALL
+	SELECT '%[6]s', ac.id, NULL, ac.title, '', '',
+		%[15]s, ts_rank(%[16]s, q.query), ac.created_at::timestamptz
+	FROM achievements ac CROSS JOIN q
+	WHERE ac.deleted_at IS NULL AND %[16]s @@ q.query
+)`,
+	textSearchConfig,
+	models.SearchTypeArticle, models.SearchTypeGallery, models.SearchTypePhoto, models.SearchTypeVideo, models.SearchTypeAchievement,
+	headline("regexp_replace(a.content, '<[^>]*>', ' ', 'g')"), models.ArticlePublished,
+	headline(escapedText("g.description")), searchDocument("g.title", "g.description"),
+	headline(escapedText("p.caption")), searchDocument("p.caption"),
+	headline(escapedText("v.title")), searchDocument("v.title"),
+	headline(escapedText("concat_ws(' ', ac.subtitle, ac.description)")), searchDocument("ac.title", "ac.subtitle", "ac.description"),
+)
+
+// Search returns a page of hits, most relevant first, and the number of hits of each type. The facets count every
+// type even when searchType limits the hits to one.
+func (r *searchRepository) Search(ctx context.Context, query, searchType string, page, limit int) ([]models.SearchHit, map[string]int64, error) {
+	args := map[string]interface{}{"query": query, "type": searchType, "limit": limit, "offset": (page - 1) * limit}
+
+	var counts []struct {
+		Type  string
+		Count int64
+	}
+	if err := r.db.WithContext(ctx).Raw(searchHits+` SELECT type, COUNT(*) AS count FROM hits GROUP BY type`, args).
+		Scan(&counts).Error; err != nil {
+		return nil, nil, utils.HandleDBError(err)
+	}
+	facets := make(map[string]int64, len(models.SearchTypes))
+	for _, t := range models.SearchTypes {
+		facets[t] = 0
+	}
+	for _, count := range counts {
+		facets[count.Type] = count.Count
+	}
+
+	var hits []models.SearchHit
+	err := r.db.WithContext(ctx).Raw(searchHits+`
+		SELECT * FROM hits
+		WHERE @type = '' OR type = @type
+		ORDER BY rank DESC, created_at DESC
+		LIMIT @limit OFFSET @offset`, args).
+		Scan(&hits).Error
+	return hits, facets, utils.HandleDBError(err)
+}
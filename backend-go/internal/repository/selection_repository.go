@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/utils"
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SelectionRepository interface {
+	FindComponents(ctx context.Context, waveID uint) ([]models.SelectionComponent, error)
+	ReplaceComponents(ctx context.Context, waveID uint, components []models.SelectionComponent) error
+	FindScoresBySantri(ctx context.Context, santriID uint) ([]models.SelectionScore, error)
+	FindScoresByWave(ctx context.Context, waveID uint) ([]models.SelectionScore, error)
+	UpsertScores(ctx context.Context, scores []models.SelectionScore) error
+}
+
+type selectionRepository struct {
+	db *gorm.DB
+}
+
+func NewSelectionRepository(db *gorm.DB) SelectionRepository {
+	return &selectionRepository{db}
+}
+
+func (r *selectionRepository) FindComponents(ctx context.Context, waveID uint) ([]models.SelectionComponent, error) {
+	var components []models.SelectionComponent
+	err := dbFrom(ctx, r.db).Where("wave_id = ?", waveID).Order("sort_order, id").Find(&components).Error
+	return components, utils.HandleDBError(err)
+}
+
+// ReplaceComponents makes components the wave's rubric: components with an ID are updated, new ones are created,
+// and components left out are deleted together with their scores
+func (r *selectionRepository) ReplaceComponents(ctx context.Context, waveID uint, components []models.SelectionComponent) error {
+	return dbFrom(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		keep := []uint{0}
+		for _, component := range components {
+			if component.ID != 0 {
+				keep = append(keep, component.ID)
+			}
+		}
+		if err := tx.Where("wave_id = ? AND id NOT IN ?", waveID, keep).Delete(&models.SelectionComponent{}).Error; err != nil {
+			return utils.HandleDBError(err)
+		}
+
+		for i := range components {
+			components[i].WaveID = waveID
+			if components[i].ID == 0 {
+				if err := tx.Create(&components[i]).Error; err != nil {
+					return utils.HandleDBError(err)
+				}
+				continue
+			}
+
+			result := tx.Model(&models.SelectionComponent{}).
+				Where("id = ? AND wave_id = ?", components[i].ID, waveID).
+				Updates(map[string]interface{}{
+					"name":       components[i].Name,
+					"weight":     components[i].Weight,
+					"max_score":  components[i].MaxScore,
+					"sort_order": components[i].SortOrder,
+				})
+			if result.Error != nil {
+				return utils.HandleDBError(result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return utils.NewAppError(400, "Rubric component does not belong to this wave")
+			}
+		}
+		return nil
+	})
+}
+
+func (r *selectionRepository) FindScoresBySantri(ctx context.Context, santriID uint) ([]models.SelectionScore, error) {
+	var scores []models.SelectionScore
+	err := dbFrom(ctx, r.db).Where("santri_id = ?", santriID).Find(&scores).Error
+	return scores, utils.HandleDBError(err)
+}
+
+func (r *selectionRepository) FindScoresByWave(ctx context.Context, waveID uint) ([]models.SelectionScore, error) {
+	var scores []models.SelectionScore
+	err := dbFrom(ctx, r.db).
+		Joins("JOIN selection_components ON selection_components.id = selection_scores.component_id").
+		Where("selection_components.wave_id = ?", waveID).
+		Find(&scores).Error
+	return scores, utils.HandleDBError(err)
+}
+
+// UpsertScores stores scores, overwriting earlier scores for the same registrant and component
+func (r *selectionRepository) UpsertScores(ctx context.Context, scores []models.SelectionScore) error {
+	if len(scores) == 0 {
+		return nil
+	}
+	err := dbFrom(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "santri_id"}, {Name: "component_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"score", "scored_by", "updated_at"}),
+	}).Create(&scores).Error
+	return utils.HandleDBError(err)
+}
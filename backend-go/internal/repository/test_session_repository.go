@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/utils"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TestSessionRepository interface {
+	Create(ctx context.Context, session *models.TestSession) error
+	FindAll(ctx context.Context, waveID uint, kind string) ([]models.TestSession, error)
+	FindByID(ctx context.Context, id uint) (*models.TestSession, error)
+	Update(ctx context.Context, session *models.TestSession) error
+	Delete(ctx context.Context, id uint) error
+	AutoAssign(ctx context.Context, waveID uint, kind models.TestSessionKind, now time.Time) ([]models.TestSessionAssignment, []models.Santri, error)
+	Assign(ctx context.Context, sessionID, santriID uint) (*models.TestSessionAssignment, error)
+	Unassign(ctx context.Context, sessionID, santriID uint) error
+	FindBySantri(ctx context.Context, santriID uint) ([]models.TestSessionAssignment, error)
+	FindDueReminders(ctx context.Context, from, to time.Time) ([]models.TestSessionAssignment, error)
+	ClaimReminder(ctx context.Context, id uint, at time.Time) (bool, error)
+}
+
+type testSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewTestSessionRepository(db *gorm.DB) TestSessionRepository {
+	return &testSessionRepository{db}
+}
+
+func (r *testSessionRepository) Create(ctx context.Context, session *models.TestSession) error {
+	return utils.HandleDBError(dbFrom(ctx, r.db).Create(session).Error)
+}
+
+// FindAll returns the sessions of a wave, optionally of one kind, with their assigned count
+func (r *testSessionRepository) FindAll(ctx context.Context, waveID uint, kind string) ([]models.TestSession, error) {
+	var sessions []models.TestSession
+	query := dbFrom(ctx, r.db).Where("wave_id = ?", waveID)
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	if err := query.Order("starts_at, id").Find(&sessions).Error; err != nil {
+		return nil, utils.HandleDBError(err)
+	}
+	if err := r.fillAssignedCounts(dbFrom(ctx, r.db), sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// FindByID returns a session with its assigned registrants
+func (r *testSessionRepository) FindByID(ctx context.Context, id uint) (*models.TestSession, error) {
+	var session models.TestSession
+	err := dbFrom(ctx, r.db).
+		Preload("Assignments", func(db *gorm.DB) *gorm.DB { return db.Order("created_at, id") }).
+		Preload("Assignments.Santri").
+		First(&session, id).Error
+	if err != nil {
+		return nil, utils.HandleDBError(err)
+	}
+	session.AssignedCount = len(session.Assignments)
+	return &session, nil
+}
+
+// Update saves the session details. The capacity and gender may not leave already assigned registrants out.
+func (r *testSessionRepository) Update(ctx context.Context, session *models.TestSession) error {
+	return dbFrom(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var existing models.TestSession
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&existing, session.ID).Error; err != nil {
+			return utils.HandleDBError(err)
+		}
+
+		var assigned int64
+		if err := tx.Model(&models.TestSessionAssignment{}).Where("session_id = ?", session.ID).Count(&assigned).Error; err != nil {
+			return utils.HandleDBError(err)
+		}
+		if int64(session.Capacity) < assigned {
+			return utils.NewAppError(400, "Capacity cannot be lower than the number of assigned registrants")
+		}
+		if session.Kind != existing.Kind && assigned > 0 {
+			return utils.NewAppError(400, "Cannot change the kind of a session with assigned registrants")
+		}
+		if session.Gender != "" {
+			var mismatched int64
+			err := tx.Model(&models.TestSessionAssignment{}).
+				Joins("JOIN santris ON santris.id = test_session_assignments.santri_id").
+				Where("test_session_assignments.session_id = ? AND santris.gender <> ?", session.ID, session.Gender).
+				Count(&mismatched).Error
+			if err != nil {
+				return utils.HandleDBError(err)
+			}
+			if mismatched > 0 {
+				return utils.NewAppError(400, "Some assigned registrants do not match the session gender")
+			}
+		}
+
+		return utils.HandleDBError(tx.Omit(clause.Associations).Save(session).Error)
+	})
+}
+
+// Delete removes the session and frees its registrants to be assigned elsewhere
+func (r *testSessionRepository) Delete(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("session_id = ?", id).Delete(&models.TestSessionAssignment{}).Error; err != nil {
+			return utils.HandleDBError(err)
+		}
+		result := tx.Delete(&models.TestSession{}, id)
+		if result.Error != nil {
+			return utils.HandleDBError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return utils.ErrNotFound
+		}
+		return nil
+	})
+}
+
+// AutoAssign places the wave's verified registrants that have no session of this kind yet into the upcoming
+// sessions of that kind, in registration order. It returns the new assignments and the registrants that did not fit.
+func (r *testSessionRepository) AutoAssign(ctx context.Context, waveID uint, kind models.TestSessionKind, now time.Time) ([]models.TestSessionAssignment, []models.Santri, error) {
+	var assigned []models.TestSessionAssignment
+	var unplaced []models.Santri
+
+	err := dbFrom(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Lock the sessions so concurrent runs and manual assignments cannot overfill them
+		var sessions []models.TestSession
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("wave_id = ? AND kind = ? AND starts_at > ?", waveID, kind, now).
+			Order("starts_at, id").
+			Find(&sessions).Error
+		if err != nil {
+			return utils.HandleDBError(err)
+		}
+		if len(sessions) == 0 {
+			return utils.NewAppError(400, "There are no upcoming sessions of this kind in the wave")
+		}
+		if err := r.fillAssignedCounts(tx, sessions); err != nil {
+			return err
+		}
+
+		var registrants []models.Santri
+		err = tx.Where("wave_id = ? AND status = ?", waveID, models.StatusVerified).
+			Where("NOT EXISTS (SELECT 1 FROM test_session_assignments a WHERE a.santri_id = santris.id AND a.kind = ?)", kind).
+			Order("created_at, id").
+			Find(&registrants).Error
+		if err != nil {
+			return utils.HandleDBError(err)
+		}
+
+		assigned, unplaced = models.AssignToSessions(sessions, registrants)
+		if len(assigned) == 0 {
+			return nil
+		}
+		return utils.HandleDBError(tx.Create(&assigned).Error)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return assigned, unplaced, nil
+}
+
+// Assign places a registrant in the session, moving them out of any other session of the same kind
+func (r *testSessionRepository) Assign(ctx context.Context, sessionID, santriID uint) (*models.TestSessionAssignment, error) {
+	var assignment *models.TestSessionAssignment
+
+	err := dbFrom(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var session models.TestSession
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&session, sessionID).Error; err != nil {
+			return utils.HandleDBError(err)
+		}
+
+		var santri models.Santri
+		if err := tx.First(&santri, santriID).Error; err != nil {
+			return utils.HandleDBError(err)
+		}
+		if santri.WaveID == nil || *santri.WaveID != session.WaveID {
+			return utils.NewAppError(400, "Registrant did not apply in this session's wave")
+		}
+		if santri.Status != models.StatusVerified {
+			return utils.NewAppError(400, "Only verified registrants can be scheduled")
+		}
+		if !session.Accepts(santri.Gender) {
+			return utils.NewAppError(400, "Session is not open to the registrant's gender")
+		}
+
+		var current models.TestSessionAssignment
+		err := tx.Where("santri_id = ? AND kind = ?", santriID, session.Kind).First(&current).Error
+		switch {
+		case err == nil && current.SessionID == sessionID:
+			assignment = &current
+			return nil
+		case err == nil:
+			if err := tx.Delete(&current).Error; err != nil {
+				return utils.HandleDBError(err)
+			}
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return utils.HandleDBError(err)
+		}
+
+		var assigned int64
+		if err := tx.Model(&models.TestSessionAssignment{}).Where("session_id = ?", sessionID).Count(&assigned).Error; err != nil {
+			return utils.HandleDBError(err)
+		}
+		if assigned >= int64(session.Capacity) {
+			return utils.NewAppError(409, "Session is full")
+		}
+
+		assignment = &models.TestSessionAssignment{SessionID: sessionID, SantriID: santriID, Kind: session.Kind}
+		return utils.HandleDBError(tx.Create(assignment).Error)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+func (r *testSessionRepository) Unassign(ctx context.Context, sessionID, santriID uint) error {
+	result := dbFrom(ctx, r.db).
+		Where("session_id = ? AND santri_id = ?", sessionID, santriID).
+		Delete(&models.TestSessionAssignment{})
+	if result.Error != nil {
+		return utils.HandleDBError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+	return nil
+}
+
+// FindBySantri returns the registrant's assignments with their sessions, earliest first
+func (r *testSessionRepository) FindBySantri(ctx context.Context, santriID uint) ([]models.TestSessionAssignment, error) {
+	var assignments []models.TestSessionAssignment
+	err := dbFrom(ctx, r.db).
+		Joins("Session").
+		Where("test_session_assignments.santri_id = ?", santriID).
+		Order("\"Session\".starts_at").
+		Find(&assignments).Error
+	return assignments, utils.HandleDBError(err)
+}
+
+// FindDueReminders returns the assignments of sessions starting within (from, to] whose reminder has not been sent,
+// skipping registrants without parent contact details and those that have been rejected since
+func (r *testSessionRepository) FindDueReminders(ctx context.Context, from, to time.Time) ([]models.TestSessionAssignment, error) {
+	var assignments []models.TestSessionAssignment
+	err := dbFrom(ctx, r.db).
+		Joins("Session").
+		Joins("Santri").
+		Where("test_session_assignments.reminder_sent_at IS NULL").
+		Where("\"Session\".starts_at > ? AND \"Session\".starts_at <= ?", from, to).
+		Where("\"Santri\".status <> ? AND (\"Santri\".parent_email <> '' OR \"Santri\".parent_phone <> '')", models.StatusRejected).
+		Find(&assignments).Error
+	return assignments, utils.HandleDBError(err)
+}
+
+// ClaimReminder marks the assignment's reminder as sent unless it already is, and reports whether this call did.
+// The row stays locked until the transaction ends, so when several workers run at once only one of them claims it.
+func (r *testSessionRepository) ClaimReminder(ctx context.Context, id uint, at time.Time) (bool, error) {
+	result := dbFrom(ctx, r.db).Model(&models.TestSessionAssignment{}).
+		Where("id = ? AND reminder_sent_at IS NULL", id).
+		Update("reminder_sent_at", at)
+	if result.Error != nil {
+		return false, utils.HandleDBError(result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// fillAssignedCounts sets AssignedCount on each session
+func (r *testSessionRepository) fillAssignedCounts(db *gorm.DB, sessions []models.TestSession) error {
+	if len(sessions) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(sessions))
+	for i := range sessions {
+		ids[i] = sessions[i].ID
+	}
+
+	var counts []struct {
+		SessionID uint
+		Count     int
+	}
+	err := db.Model(&models.TestSessionAssignment{}).
+		Select("session_id, COUNT(*) AS count").
+		Where("session_id IN ?", ids).
+		Group("session_id").
+		Scan(&counts).Error
+	if err != nil {
+		return utils.HandleDBError(err)
+	}
+
+	bySession := make(map[uint]int, len(counts))
+	for _, c := range counts {
+		bySession[c.SessionID] = c.Count
+	}
+	for i := range sessions {
+		sessions[i].AssignedCount = bySession[sessions[i].ID]
+	}
+	return nil
+}
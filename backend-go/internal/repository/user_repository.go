@@ -11,6 +11,7 @@ import (
 type UserRepository interface {
 	CreateUser(ctx context.Context, user *models.User) error
 	FindByUsername(ctx context.Context, username string) (*models.User, error)
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
 	FindAll(ctx context.Context) ([]models.User, error)
 	FindByID(ctx context.Context, id uint) (*models.User, error)
 	UpdateUser(ctx context.Context, user *models.User) error
@@ -27,37 +28,43 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 }
 
 func (r *userRepository) CreateUser(ctx context.Context, user *models.User) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Create(user).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Create(user).Error)
 }
 
 func (r *userRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
 	var user models.User
-	err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
+	err := dbFrom(ctx, r.db).Where("username = ?", username).First(&user).Error
+	return &user, utils.HandleDBError(err)
+}
+
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	var user models.User
+	err := dbFrom(ctx, r.db).Where("LOWER(email) = LOWER(?)", email).First(&user).Error
 	return &user, utils.HandleDBError(err)
 }
 
 func (r *userRepository) FindAll(ctx context.Context) ([]models.User, error) {
 	var users []models.User
-	err := r.db.WithContext(ctx).Find(&users).Error
+	err := dbFrom(ctx, r.db).Find(&users).Error
 	return users, utils.HandleDBError(err)
 }
 
 func (r *userRepository) FindByID(ctx context.Context, id uint) (*models.User, error) {
 	var user models.User
-	err := r.db.WithContext(ctx).First(&user, id).Error
+	err := dbFrom(ctx, r.db).First(&user, id).Error
 	return &user, utils.HandleDBError(err)
 }
 
 func (r *userRepository) UpdateUser(ctx context.Context, user *models.User) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Save(user).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Save(user).Error)
 }
 
 func (r *userRepository) DeleteUser(ctx context.Context, id uint) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Delete(&models.User{}, id).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Delete(&models.User{}, id).Error)
 }
 
 func (r *userRepository) Count(ctx context.Context) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&models.User{}).Count(&count).Error
+	err := dbFrom(ctx, r.db).Model(&models.User{}).Count(&count).Error
 	return count, utils.HandleDBError(err)
 }
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/utils"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type UserSessionRepository interface {
+	Create(ctx context.Context, session *models.UserSession) error
+	FindByID(ctx context.Context, id string) (*models.UserSession, error)
+	FindActiveByUser(ctx context.Context, userID uint, now time.Time) ([]models.UserSession, error)
+	Touch(ctx context.Context, id string, lastUsedAt, expiresAt time.Time) error
+	Revoke(ctx context.Context, id string, now time.Time) error
+	RevokeByUser(ctx context.Context, userID uint, exceptID string, now time.Time) ([]string, error)
+	DeleteInactiveBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type userSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewUserSessionRepository(db *gorm.DB) UserSessionRepository {
+	return &userSessionRepository{db}
+}
+
+func (r *userSessionRepository) Create(ctx context.Context, session *models.UserSession) error {
+	return utils.HandleDBError(dbFrom(ctx, r.db).Create(session).Error)
+}
+
+func (r *userSessionRepository) FindByID(ctx context.Context, id string) (*models.UserSession, error) {
+	var session models.UserSession
+	if err := dbFrom(ctx, r.db).Where("id = ?", id).First(&session).Error; err != nil {
+		return nil, utils.HandleDBError(err)
+	}
+	return &session, nil
+}
+
+// FindActiveByUser returns the sessions that are neither revoked nor expired, most recently used first
+func (r *userSessionRepository) FindActiveByUser(ctx context.Context, userID uint, now time.Time) ([]models.UserSession, error) {
+	var sessions []models.UserSession
+	err := dbFrom(ctx, r.db).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_used_at desc").
+		Find(&sessions).Error
+	return sessions, utils.HandleDBError(err)
+}
+
+// Touch records a token refresh, which extends the session
+func (r *userSessionRepository) Touch(ctx context.Context, id string, lastUsedAt, expiresAt time.Time) error {
+	err := dbFrom(ctx, r.db).Model(&models.UserSession{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"last_used_at": lastUsedAt,
+		"expires_at":   expiresAt,
+	}).Error
+	return utils.HandleDBError(err)
+}
+
+func (r *userSessionRepository) Revoke(ctx context.Context, id string, now time.Time) error {
+	err := dbFrom(ctx, r.db).Model(&models.UserSession{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", now).Error
+	return utils.HandleDBError(err)
+}
+
+// RevokeByUser revokes every active session of the user except exceptID, which may be empty, and returns the
+// IDs it revoked
+func (r *userSessionRepository) RevokeByUser(ctx context.Context, userID uint, exceptID string, now time.Time) ([]string, error) {
+	var ids []string
+	err := dbFrom(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&models.UserSession{}).Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now)
+		if exceptID != "" {
+			query = query.Where("id <> ?", exceptID)
+		}
+		if err := query.Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return tx.Model(&models.UserSession{}).Where("id IN ?", ids).Update("revoked_at", now).Error
+	})
+	return ids, utils.HandleDBError(err)
+}
+
+// DeleteInactiveBefore removes sessions that expired or were revoked before the given time
+func (r *userSessionRepository) DeleteInactiveBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := dbFrom(ctx, r.db).
+		Where("expires_at < ? OR revoked_at < ?", before, before).
+		Delete(&models.UserSession{})
+	return result.RowsAffected, utils.HandleDBError(result.Error)
+}
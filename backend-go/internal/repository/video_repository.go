@@ -25,25 +25,25 @@ func NewVideoRepository(db *gorm.DB) VideoRepository {
 }
 
 func (r *videoRepository) Create(ctx context.Context, video *models.Video) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Create(video).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Create(video).Error)
 }
 
 func (r *videoRepository) FindAll(ctx context.Context) ([]models.Video, error) {
 	var videos []models.Video
-	err := r.db.WithContext(ctx).Order("created_at desc").Find(&videos).Error
+	err := dbFrom(ctx, r.db).Order("created_at desc").Find(&videos).Error
 	return videos, utils.HandleDBError(err)
 }
 
 func (r *videoRepository) FindByID(ctx context.Context, id uint) (*models.Video, error) {
 	var video models.Video
-	err := r.db.WithContext(ctx).First(&video, id).Error
+	err := dbFrom(ctx, r.db).First(&video, id).Error
 	return &video, utils.HandleDBError(err)
 }
 
 func (r *videoRepository) Update(ctx context.Context, video *models.Video) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Save(video).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Save(video).Error)
 }
 
 func (r *videoRepository) Delete(ctx context.Context, id uint) error {
-	return utils.HandleDBError(r.db.WithContext(ctx).Delete(&models.Video{}, id).Error)
+	return utils.HandleDBError(dbFrom(ctx, r.db).Delete(&models.Video{}, id).Error)
 }
@@ -15,15 +15,26 @@ type AchievementService interface {
 }
 
 type achievementService struct {
-	repo repository.AchievementRepository
+	repo  repository.AchievementRepository
+	cache CacheService
+	tx    repository.TxManager
 }
 
-func NewAchievementService(repo repository.AchievementRepository) AchievementService {
-	return &achievementService{repo}
+func NewAchievementService(repo repository.AchievementRepository, cache CacheService, tx repository.TxManager) AchievementService {
+	return &achievementService{repo, cache, tx}
 }
 
 func (s *achievementService) CreateAchievement(ctx context.Context, achievement *models.Achievement) error {
-	return s.repo.Create(ctx, achievement)
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, achievement); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionCreate, "achievement", &achievement.ID, nil, achievement)
+	})
+	if err == nil {
+		invalidateSearchCache(s.cache)
+	}
+	return err
 }
 
 func (s *achievementService) GetAllAchievements(ctx context.Context) ([]models.Achievement, error) {
@@ -47,9 +58,27 @@ func (s *achievementService) UpdateAchievement(ctx context.Context, id uint, dat
 	existing.Icon = data.Icon
 	existing.Color = data.Color
 
-	return s.repo.Update(ctx, existing)
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, existing); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionUpdate, "achievement", &id, nil, data)
+	})
+	if err == nil {
+		invalidateSearchCache(s.cache)
+	}
+	return err
 }
 
 func (s *achievementService) DeleteAchievement(ctx context.Context, id uint) error {
-	return s.repo.Delete(ctx, id)
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionDelete, "achievement", &id, nil, nil)
+	})
+	if err == nil {
+		invalidateSearchCache(s.cache)
+	}
+	return err
 }
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/repository"
+	"backend-go/internal/utils"
+	"context"
+	"time"
+)
+
+type AdmissionWaveService interface {
+	CreateWave(ctx context.Context, wave *models.AdmissionWave) error
+	GetAllWaves(ctx context.Context, academicYear string) ([]models.AdmissionWave, error)
+	GetWaveByID(ctx context.Context, id uint) (*models.AdmissionWave, error)
+	GetOpenWave(ctx context.Context) (*models.AdmissionWave, error)
+	UpdateWave(ctx context.Context, id uint, data *models.AdmissionWave) error
+	DeleteWave(ctx context.Context, id uint) error
+}
+
+type admissionWaveService struct {
+	repo       repository.AdmissionWaveRepository
+	santriRepo repository.SantriRepository
+	tx         repository.TxManager
+}
+
+func NewAdmissionWaveService(repo repository.AdmissionWaveRepository, santriRepo repository.SantriRepository, tx repository.TxManager) AdmissionWaveService {
+	return &admissionWaveService{repo, santriRepo, tx}
+}
+
+func (s *admissionWaveService) CreateWave(ctx context.Context, wave *models.AdmissionWave) error {
+	if wave.EndDate.Before(wave.StartDate) {
+		return utils.NewAppError(400, "End date must not be before start date")
+	}
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, wave); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionCreate, "admission_wave", &wave.ID, nil, wave)
+	})
+}
+
+func (s *admissionWaveService) GetAllWaves(ctx context.Context, academicYear string) ([]models.AdmissionWave, error) {
+	return s.repo.FindAll(ctx, academicYear)
+}
+
+func (s *admissionWaveService) GetWaveByID(ctx context.Context, id uint) (*models.AdmissionWave, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *admissionWaveService) GetOpenWave(ctx context.Context) (*models.AdmissionWave, error) {
+	return s.repo.FindOpen(ctx, time.Now())
+}
+
+func (s *admissionWaveService) UpdateWave(ctx context.Context, id uint, data *models.AdmissionWave) error {
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if data.EndDate.Before(data.StartDate) {
+		return utils.NewAppError(400, "End date must not be before start date")
+	}
+
+	// Update fields (quotas and fee of 0 are meaningful, so the whole wave is replaced)
+	existing.Name = data.Name
+	existing.AcademicYear = data.AcademicYear
+	existing.StartDate = data.StartDate
+	existing.EndDate = data.EndDate
+	existing.QuotaMale = data.QuotaMale
+	existing.QuotaFemale = data.QuotaFemale
+	existing.RegistrationFee = data.RegistrationFee
+	existing.IsActive = data.IsActive
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, existing); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionUpdate, "admission_wave", &id, nil, data)
+	})
+}
+
+func (s *admissionWaveService) DeleteWave(ctx context.Context, id uint) error {
+	// First verify it exists
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return err
+	}
+
+	// Registrants keep a reference to their wave, so only empty waves can be removed
+	count, err := s.santriRepo.CountByWave(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return utils.NewAppError(409, "Wave still has registrants, deactivate it instead")
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionDelete, "admission_wave", &id, nil, nil)
+	})
+}
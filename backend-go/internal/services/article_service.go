@@ -1,11 +1,14 @@
 package services
 
 import (
+	"backend-go/internal/dto"
 	"backend-go/internal/models"
 	"backend-go/internal/repository"
 	"backend-go/internal/utils"
 	"context"
 	"fmt"
+	"html"
+	"regexp"
 	"time"
 
 	"github.com/gosimple/slug"
@@ -17,21 +20,30 @@ type ArticleService interface {
 	GetAllArticles(ctx context.Context) ([]models.Article, error)
 	GetAllArticlesPaginated(ctx context.Context, page, limit int) ([]models.Article, int64, error)
 	GetArticleByID(ctx context.Context, id uint) (*models.Article, error)
+	GetManagedArticles(ctx context.Context, status models.ArticleStatus, page, limit int) ([]models.Article, int64, error)
+	GetManagedArticle(ctx context.Context, id uint) (*models.Article, error)
 	GetArticleBySlug(ctx context.Context, slug string) (*models.Article, error)
-	SearchArticles(ctx context.Context, query string, page, limit int) ([]models.Article, int64, error)
+	SearchArticles(ctx context.Context, query string, page, limit int) ([]models.ArticleSearchHit, int64, error)
 	GetArticlesByCategory(ctx context.Context, categoryID uint, page, limit int) ([]models.Article, int64, error)
 	GetArticlesByTag(ctx context.Context, tagID uint, page, limit int) ([]models.Article, int64, error)
-	UpdateArticle(ctx context.Context, id uint, articleData *models.Article) error
+	UpdateArticle(ctx context.Context, id, editorID uint, articleData *models.Article) error
 	DeleteArticle(ctx context.Context, id uint) error
+	GetRevisions(ctx context.Context, id uint) ([]models.ArticleRevision, error)
+	GetRevision(ctx context.Context, id uint, revision int) (*models.ArticleRevision, error)
+	DiffRevisions(ctx context.Context, id uint, from, to int) (*dto.ArticleRevisionDiff, error)
+	RestoreRevision(ctx context.Context, id uint, revision int, editorID uint) (*models.Article, error)
+	PublishScheduled(ctx context.Context, now time.Time) (int, error)
 }
 
 type articleService struct {
-	repo  repository.ArticleRepository
-	cache CacheService
+	repo      repository.ArticleRepository
+	revisions repository.ArticleRevisionRepository
+	cache     CacheService
+	tx        repository.TxManager
 }
 
-func NewArticleService(repo repository.ArticleRepository, cache CacheService) ArticleService {
-	return &articleService{repo, cache}
+func NewArticleService(repo repository.ArticleRepository, revisions repository.ArticleRevisionRepository, cache CacheService, tx repository.TxManager) ArticleService {
+	return &articleService{repo, revisions, cache, tx}
 }
 
 func (s *articleService) CreateArticle(ctx context.Context, article *models.Article) error {
@@ -41,10 +53,24 @@ func (s *articleService) CreateArticle(ctx context.Context, article *models.Arti
 	article.Slug = slug.Make(article.Title)
 	article.CreatedAt = time.Now()
 
-	err := s.repo.Create(ctx, article)
+	// A new article has no state yet, so the requested one is checked like any change
+	status, publishAt := article.Status, article.PublishAt
+	article.Status, article.PublishAt = "", nil
+	if err := applyArticleStatus(article, status, publishAt, article.CreatedAt); err != nil {
+		return err
+	}
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, article); err != nil {
+			return err
+		}
+		if err := s.revisions.Create(ctx, newArticleRevision(article, article.AuthorID, nil)); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionCreate, "article", &article.ID, nil, article)
+	})
 	if err == nil {
-		s.cache.Delete(utils.CacheKeyArticlesAll)
-        s.cache.DeleteByPattern("articles:page:*")
+		s.invalidateCache()
 	}
 	return err
 }
@@ -96,7 +122,7 @@ func (s *articleService) GetArticleByID(ctx context.Context, id uint) (*models.A
 		return &article, nil
 	}
 
-	result, err := s.repo.FindByID(ctx, id)
+	result, err := s.repo.FindPublishedByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -105,6 +131,19 @@ func (s *articleService) GetArticleByID(ctx context.Context, id uint) (*models.A
 	return result, nil
 }
 
+// GetManagedArticles lists articles in every state for the admin, optionally filtered by status
+func (s *articleService) GetManagedArticles(ctx context.Context, status models.ArticleStatus, page, limit int) ([]models.Article, int64, error) {
+	if status != "" && !status.IsValid() {
+		return nil, 0, utils.NewAppError(400, "Invalid article status: "+string(status))
+	}
+	return s.repo.FindAllByStatus(ctx, status, page, limit)
+}
+
+// GetManagedArticle returns an article in any state. It is not cached, since editors need to see their changes.
+func (s *articleService) GetManagedArticle(ctx context.Context, id uint) (*models.Article, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
 func (s *articleService) GetArticleBySlug(ctx context.Context, slug string) (*models.Article, error) {
 	var article models.Article
 	key := fmt.Sprintf(utils.CacheKeyArticlesSlugPattern, slug)
@@ -122,7 +161,7 @@ func (s *articleService) GetArticleBySlug(ctx context.Context, slug string) (*mo
 	return result, nil
 }
 
-func (s *articleService) SearchArticles(ctx context.Context, query string, page, limit int) ([]models.Article, int64, error) {
+func (s *articleService) SearchArticles(ctx context.Context, query string, page, limit int) ([]models.ArticleSearchHit, int64, error) {
 	// No caching for search as queries vary greatly
 	return s.repo.Search(ctx, query, page, limit)
 }
@@ -135,27 +174,26 @@ func (s *articleService) GetArticlesByTag(ctx context.Context, tagID uint, page,
 	return s.repo.FindByTag(ctx, tagID, page, limit)
 }
 
-func (s *articleService) UpdateArticle(ctx context.Context, id uint, articleData *models.Article) error {
+// UpdateArticle saves the new state of an article together with a revision of it, so earlier states can be restored
+func (s *articleService) UpdateArticle(ctx context.Context, id, editorID uint, articleData *models.Article) error {
 	existing, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
+	previous := *existing
 	p := bluemonday.UGCPolicy()
 
 	existing.Title = articleData.Title
 	existing.Content = p.Sanitize(articleData.Content)
 	existing.ThumbnailURL = articleData.ThumbnailURL
-	existing.IsPublished = articleData.IsPublished
-
-	err = s.repo.Update(ctx, existing)
-	if err == nil {
-		s.cache.Delete(utils.CacheKeyArticlesAll)
-		s.cache.Delete(fmt.Sprintf(utils.CacheKeyArticlesIDPattern, id))
-		s.cache.Delete(fmt.Sprintf(utils.CacheKeyArticlesSlugPattern, existing.Slug))
-		s.cache.DeleteByPattern("articles:page:*")
+	if err := applyArticleStatus(existing, articleData.Status, articleData.PublishAt, time.Now()); err != nil {
+		return err
 	}
-	return err
+
+	return s.saveWithRevision(ctx, existing, editorID, nil, func(ctx context.Context) error {
+		return recordActivity(ctx, models.ActionUpdate, "article", &id, previous, articleData)
+	})
 }
 
 func (s *articleService) DeleteArticle(ctx context.Context, id uint) error {
@@ -170,12 +208,185 @@ func (s *articleService) DeleteArticle(ctx context.Context, id uint) error {
 		CleanupImageAsync(article.ThumbnailURL)
 	}
 
-	err = s.repo.Delete(ctx, id)
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionDelete, "article", &id, nil, nil)
+	})
 	if err == nil {
-		s.cache.Delete(utils.CacheKeyArticlesAll)
-		s.cache.Delete(fmt.Sprintf(utils.CacheKeyArticlesIDPattern, id))
-		s.cache.DeleteByPattern("articles:slug:*")
-		s.cache.DeleteByPattern("articles:page:*")
+		s.invalidateCache()
 	}
 	return err
 }
+
+func (s *articleService) GetRevisions(ctx context.Context, id uint) ([]models.ArticleRevision, error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.revisions.FindByArticle(ctx, id)
+}
+
+func (s *articleService) GetRevision(ctx context.Context, id uint, revision int) (*models.ArticleRevision, error) {
+	return s.revisions.FindByRevision(ctx, id, revision)
+}
+
+// DiffRevisions compares two revisions word by word. Content is compared as text, without its markup.
+func (s *articleService) DiffRevisions(ctx context.Context, id uint, from, to int) (*dto.ArticleRevisionDiff, error) {
+	before, err := s.revisions.FindByRevision(ctx, id, from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.revisions.FindByRevision(ctx, id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ArticleRevisionDiff{
+		ArticleID:        id,
+		From:             from,
+		To:               to,
+		Title:            utils.WordDiff(before.Title, after.Title),
+		Content:          utils.WordDiff(articlePlainText(before.Content), articlePlainText(after.Content)),
+		ThumbnailChanged: before.ThumbnailURL != after.ThumbnailURL,
+		StatusChanged:    before.Status != after.Status,
+	}, nil
+}
+
+// RestoreRevision brings back the title, content and thumbnail of a revision as a new revision. The status of the
+// article is left as it is, so restoring never publishes or retracts an article by accident.
+func (s *articleService) RestoreRevision(ctx context.Context, id uint, revision int, editorID uint) (*models.Article, error) {
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	source, err := s.revisions.FindByRevision(ctx, id, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := *existing
+	existing.Title = source.Title
+	existing.Content = source.Content
+	existing.ThumbnailURL = source.ThumbnailURL
+
+	err = s.saveWithRevision(ctx, existing, editorID, &source.Revision, func(ctx context.Context) error {
+		return recordActivity(ctx, models.ActionRestore, "article", &id, previous, map[string]interface{}{
+			"restored_revision": revision,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// PublishScheduled publishes the scheduled articles whose PublishAt has passed and records a revision for each.
+// The public article caches are dropped when anything was published.
+func (s *articleService) PublishScheduled(ctx context.Context, now time.Time) (int, error) {
+	var published []models.Article
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		published, err = s.repo.PublishDue(ctx, now)
+		if err != nil {
+			return err
+		}
+		for i := range published {
+			if err := s.revisions.Create(ctx, newArticleRevision(&published[i], 0, nil)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(published) > 0 {
+		s.invalidateCache()
+	}
+	return len(published), nil
+}
+
+// applyArticleStatus moves an article to status, or keeps its status when status is empty, and sets PublishAt when
+// given. A scheduled article needs a PublishAt in the future. Publishing sets PublishAt to now, unless the article
+// already went live earlier.
+func applyArticleStatus(article *models.Article, status models.ArticleStatus, publishAt *time.Time, now time.Time) error {
+	changed := publishAt != nil || (status != "" && status != article.Status)
+	if status == "" {
+		status = article.Status
+	}
+	if status == "" {
+		status = models.ArticleDraft
+	}
+	if !status.IsValid() {
+		return utils.NewAppError(400, "Invalid article status: "+string(status))
+	}
+
+	if publishAt != nil {
+		article.PublishAt = publishAt
+	}
+	switch status {
+	case models.ArticleScheduled:
+		if changed && (article.PublishAt == nil || !article.PublishAt.After(now)) {
+			return utils.NewAppError(400, "A scheduled article needs a publish_at time in the future")
+		}
+	case models.ArticlePublished:
+		if article.PublishAt == nil || article.PublishAt.After(now) {
+			article.PublishAt = &now
+		}
+	}
+
+	article.Status = status
+	return nil
+}
+
+// saveWithRevision saves an existing article and records its new state as a revision in one transaction, together
+// with the activity log entry written by record
+func (s *articleService) saveWithRevision(ctx context.Context, article *models.Article, editorID uint, restoredFrom *int, record func(ctx context.Context) error) error {
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, article); err != nil {
+			return err
+		}
+		if err := s.revisions.Create(ctx, newArticleRevision(article, editorID, restoredFrom)); err != nil {
+			return err
+		}
+		return record(ctx)
+	})
+	if err == nil {
+		s.invalidateCache()
+	}
+	return err
+}
+
+// invalidateCache drops every cached article, list, feed and search result after a change. Edits can change the slug,
+// status and categories that the cached lists and feeds depend on, so dropping only the article's own keys is not enough.
+func (s *articleService) invalidateCache() {
+	s.cache.DeleteByPattern("articles:*")
+	invalidateSearchCache(s.cache)
+}
+
+func newArticleRevision(article *models.Article, editorID uint, restoredFrom *int) *models.ArticleRevision {
+	revision := &models.ArticleRevision{
+		ArticleID:    article.ID,
+		Title:        article.Title,
+		Content:      article.Content,
+		ThumbnailURL: article.ThumbnailURL,
+		IsPublished:  article.Status == models.ArticlePublished,
+		Status:       article.Status,
+		RestoredFrom: restoredFrom,
+	}
+	if editorID != 0 {
+		revision.EditorID = &editorID
+	}
+	return revision
+}
+
+// blockEnd matches the tags that end a line of text, so paragraphs stay apart once the markup is stripped
+var blockEnd = regexp.MustCompile(`(?i)</(p|div|h[1-6]|li|blockquote|pre|tr)>|<br\s*/?>`)
+
+// articlePlainText turns sanitized article HTML into the text a reader sees
+func articlePlainText(content string) string {
+	content = blockEnd.ReplaceAllString(content, "$0\n")
+	return html.UnescapeString(bluemonday.StrictPolicy().Sanitize(content))
+}
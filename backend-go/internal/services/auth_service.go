@@ -7,7 +7,9 @@ import (
 	"backend-go/internal/repository"
 	"backend-go/internal/utils"
 	"context"
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"time"
@@ -26,26 +28,46 @@ const (
 const TokenBlacklistPrefix = "token:blacklist:"
 
 type AuthService interface {
-	RegisterAdmin(ctx context.Context, username, password, role string) error
-	Login(ctx context.Context, username, password string) (*dto.TokenPair, error)
+	RegisterAdmin(ctx context.Context, username, password, email, role string) error
+	Login(ctx context.Context, username, password string, client ClientInfo) (*dto.LoginResponse, error)
+	VerifyTwoFactor(ctx context.Context, challengeToken, code string, client ClientInfo) (*dto.TokenPair, bool, error)
 	RefreshToken(ctx context.Context, refreshToken string) (*dto.TokenPair, error)
+	Logout(ctx context.Context, refreshToken string, client ClientInfo) error
 	BlacklistToken(ctx context.Context, token string) error
 	IsTokenBlacklisted(ctx context.Context, token string) bool
 	GetAllAdmins(ctx context.Context) ([]models.User, error)
 	DeleteAdmin(ctx context.Context, id uint) error
 	UpdateAdminPassword(ctx context.Context, id uint, password string) error
+	UpdateAdminEmail(ctx context.Context, id uint, email string) error
+	UpdateAdminRole(ctx context.Context, id uint, role string) error
+	ChangePassword(ctx context.Context, userID uint, sessionID, currentPassword, newPassword string) (*dto.TokenPair, error)
+	ForgotPassword(ctx context.Context, email, locale string) error
+	ResetPassword(ctx context.Context, token, password string) error
+	SetupTwoFactor(ctx context.Context, userID uint) (*dto.TwoFactorSetupResponse, error)
+	EnableTwoFactor(ctx context.Context, userID uint, sessionID, code string) (*dto.TwoFactorEnabledResponse, error)
+	DisableTwoFactor(ctx context.Context, userID uint, password, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uint, code string) ([]string, error)
+	ResetTwoFactor(ctx context.Context, id uint) error
+	UnlockAdmin(ctx context.Context, id uint) error
 }
 
 type authService struct {
-	repo  repository.UserRepository
-	cache CacheService
+	repo          repository.UserRepository
+	roles         repository.RoleRepository
+	recoveryCodes repository.RecoveryCodeRepository
+	sessions      SessionService
+	cache         CacheService
+	tx            repository.TxManager
+	notifier      NotificationService
 }
 
-func NewAuthService(repo repository.UserRepository, cache CacheService) AuthService {
-	return &authService{repo: repo, cache: cache}
+func NewAuthService(repo repository.UserRepository, roles repository.RoleRepository, recoveryCodes repository.RecoveryCodeRepository, sessions SessionService, cache CacheService, tx repository.TxManager, notifier NotificationService) AuthService {
+	return &authService{repo: repo, roles: roles, recoveryCodes: recoveryCodes, sessions: sessions, cache: cache, tx: tx, notifier: notifier}
 }
 
-func (s *authService) RegisterAdmin(ctx context.Context, username, password, role string) error {
+// RegisterAdmin creates an admin who has to choose their own password on first login. When an email is given
+// a welcome email with a link to choose the password is sent there; the password itself is never emailed.
+func (s *authService) RegisterAdmin(ctx context.Context, username, password, email, role string) error {
 	// Validate password policy
 	if err := utils.ValidatePassword(password); err != nil {
 		return utils.NewAppError(400, err.Error())
@@ -59,29 +81,71 @@ func (s *authService) RegisterAdmin(ctx context.Context, username, password, rol
 	if role == "" {
 		role = models.RoleAdmin
 	}
+	if err := s.checkRoleExists(ctx, role); err != nil {
+		return err
+	}
 
 	user := &models.User{
-		Username: username,
-		Password: string(hashedPassword),
-		Role:     role,
+		Username:           username,
+		Email:              email,
+		Password:           string(hashedPassword),
+		Role:               role,
+		MustChangePassword: true,
 	}
 
-	return s.repo.CreateUser(ctx, user)
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateUser(ctx, user); err != nil {
+			return err
+		}
+		if email == "" {
+			return nil
+		}
+		return s.notifier.NotifyPasswordLink(ctx, user, EventAdminWelcome, models.LocaleID)
+	})
 }
 
-func (s *authService) Login(ctx context.Context, username, password string) (*dto.TokenPair, error) {
+// Login checks the password. Accounts with two-factor authentication get a challenge to complete with
+// VerifyTwoFactor; the others get their tokens right away.
+func (s *authService) Login(ctx context.Context, username, password string, client ClientInfo) (*dto.LoginResponse, error) {
+	if err := s.checkLoginAllowed(username, client.IPAddress); err != nil {
+		return nil, err
+	}
+
 	user, err := s.repo.FindByUsername(ctx, username)
 	if err != nil {
 		// Return generic unauthorized to avoid leaking verification details
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+		s.recordLoginFailure(ctx, username, nil, client)
 		return nil, utils.ErrUnauthorized
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
+		s.recordLoginFailure(ctx, username, user, client)
 		return nil, utils.ErrUnauthorized
 	}
 
-	return s.generateTokenPair(user)
+	// With two-factor authentication the failures are only forgotten once the second factor is right too, so
+	// logging in again with the password does not buy more guesses at the code
+	if user.TOTPEnabled {
+		challengeToken, err := s.createLoginChallenge(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		return &dto.LoginResponse{
+			TwoFactorRequired:  true,
+			ChallengeToken:     challengeToken,
+			ChallengeExpiresIn: int64(LoginChallengeExpiry.Seconds()),
+		}, nil
+	}
+
+	s.clearLoginFailures(username)
+
+	tokenPair, err := s.startSession(ctx, user, client, false)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.LoginResponse{TokenPair: tokenPair}, nil
 }
 
 // hashToken creates a hash of the token for storage (more secure than storing raw tokens)
@@ -121,7 +185,56 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*d
 		return nil, utils.ErrUnauthorized
 	}
 
-	// Parse and validate refresh token
+	userID, sessionID, err := parseRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Revoked sessions cannot be refreshed
+	if !s.sessions.IsActive(ctx, sessionID) {
+		return nil, utils.ErrUnauthorized
+	}
+
+	// Find user to get latest info
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, utils.ErrUnauthorized
+	}
+
+	// Blacklist the old refresh token (token rotation)
+	_ = s.BlacklistToken(ctx, refreshToken)
+
+	if err := s.sessions.Extend(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	// Generate new token pair
+	return s.generateTokenPair(ctx, user, sessionID)
+}
+
+// Logout blacklists the refresh token, ends its session and records the logout for the token's user
+func (s *authService) Logout(ctx context.Context, refreshToken string, client ClientInfo) error {
+	if refreshToken == "" {
+		return nil
+	}
+	if err := s.BlacklistToken(ctx, refreshToken); err != nil {
+		return err
+	}
+
+	userID, sessionID, err := parseRefreshToken(refreshToken)
+	if err != nil {
+		return nil
+	}
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.sessions.Revoke(ctx, userID, sessionID); err != nil && !errors.Is(err, utils.ErrNotFound) {
+			return err
+		}
+		return recordActivity(WithActor(ctx, userID, client), models.ActionLogout, "auth", nil, nil, nil)
+	})
+}
+
+// parseRefreshToken validates a refresh token and returns its user and session
+func parseRefreshToken(refreshToken string) (uint, string, error) {
 	token, err := jwt.Parse(refreshToken, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("invalid signing method")
@@ -130,52 +243,74 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*d
 	})
 
 	if err != nil || !token.Valid {
-		return nil, utils.ErrUnauthorized
+		return 0, "", utils.ErrUnauthorized
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
-		return nil, utils.ErrUnauthorized
+		return 0, "", utils.ErrUnauthorized
 	}
 
 	// Check token type
 	tokenType, ok := claims["type"].(string)
 	if !ok || tokenType != "refresh" {
-		return nil, errors.New("invalid token type")
+		return 0, "", errors.New("invalid token type")
 	}
 
 	// Get user ID
 	userIDFloat, ok := claims["user_id"].(float64)
 	if !ok {
-		return nil, utils.ErrUnauthorized
+		return 0, "", utils.ErrUnauthorized
 	}
-	userID := uint(userIDFloat)
 
-	// Find user to get latest info
-	user, err := s.repo.FindByID(ctx, userID)
+	sessionID, _ := claims["sid"].(string)
+	return uint(userIDFloat), sessionID, nil
+}
+
+// startSession registers a new login, records it in the activity log and issues its first token pair
+func (s *authService) startSession(ctx context.Context, user *models.User, client ClientInfo, usedRecoveryCode bool) (*dto.TokenPair, error) {
+	var session *models.UserSession
+	err := s.tx.WithinTransaction(WithActor(ctx, user.ID, client), func(ctx context.Context) error {
+		var err error
+		if session, err = s.sessions.Start(ctx, user.ID, client); err != nil {
+			return err
+		}
+		if usedRecoveryCode {
+			if err := recordActivity(ctx, models.ActionRecoveryCodeUse, "auth", nil, nil, nil); err != nil {
+				return err
+			}
+		}
+		return recordActivity(ctx, models.ActionLogin, "auth", nil, nil, map[string]string{"username": user.Username})
+	})
 	if err != nil {
-		return nil, utils.ErrUnauthorized
+		return nil, err
 	}
-
-	// Blacklist the old refresh token (token rotation)
-	_ = s.BlacklistToken(ctx, refreshToken)
-
-	// Generate new token pair
-	return s.generateTokenPair(user)
+	return s.generateTokenPair(ctx, user, session.ID)
 }
 
-func (s *authService) generateTokenPair(user *models.User) (*dto.TokenPair, error) {
+// generateTokenPair issues tokens for a session. The access token carries the permissions of the user's role, so
+// routes can be authorized without a database lookup.
+func (s *authService) generateTokenPair(ctx context.Context, user *models.User, sessionID string) (*dto.TokenPair, error) {
+	permissions, err := s.roles.FindPermissionCodes(ctx, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	accessExpiry := now.Add(AccessTokenExpiry)
 	refreshExpiry := now.Add(RefreshTokenExpiry)
 
 	// Access Token
 	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": user.ID,
-		"role":    user.Role,
-		"type":    "access",
-		"exp":     accessExpiry.Unix(),
-		"iat":     now.Unix(),
+		"user_id":                   user.ID,
+		"role":                      user.Role,
+		"permissions":               permissions,
+		"must_change_password":      user.MustChangePassword,
+		"two_factor_setup_required": twoFactorSetupRequired(user),
+		"sid":                       sessionID,
+		"type":                      "access",
+		"exp":                       accessExpiry.Unix(),
+		"iat":                       now.Unix(),
 	})
 
 	accessTokenString, err := accessToken.SignedString([]byte(config.AppConfig.JWTSecret))
@@ -186,6 +321,7 @@ func (s *authService) generateTokenPair(user *models.User) (*dto.TokenPair, erro
 	// Refresh Token
 	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"user_id": user.ID,
+		"sid":     sessionID,
 		"type":    "refresh",
 		"exp":     refreshExpiry.Unix(),
 		"iat":     now.Unix(),
@@ -201,9 +337,13 @@ func (s *authService) generateTokenPair(user *models.User) (*dto.TokenPair, erro
 		RefreshToken: refreshTokenString,
 		ExpiresIn:    int64(AccessTokenExpiry.Seconds()),
 		User: dto.UserDTO{
-			ID:       user.ID,
-			Username: user.Username,
-			Role:     user.Role,
+			ID:                     user.ID,
+			Username:               user.Username,
+			Role:                   user.Role,
+			Permissions:            permissions,
+			MustChangePassword:     user.MustChangePassword,
+			TwoFactorEnabled:       user.TOTPEnabled,
+			TwoFactorSetupRequired: twoFactorSetupRequired(user),
 		},
 	}, nil
 }
@@ -213,7 +353,15 @@ func (s *authService) GetAllAdmins(ctx context.Context) ([]models.User, error) {
 }
 
 func (s *authService) DeleteAdmin(ctx context.Context, id uint) error {
-	return s.repo.DeleteUser(ctx, id)
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.DeleteUser(ctx, id); err != nil {
+			return err
+		}
+		if _, err := s.sessions.RevokeAll(ctx, id, ""); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionDelete, "user", &id, nil, nil)
+	})
 }
 
 func (s *authService) UpdateAdminPassword(ctx context.Context, id uint, password string) error {
@@ -232,5 +380,166 @@ func (s *authService) UpdateAdminPassword(ctx context.Context, id uint, password
 		return err
 	}
 	user.Password = string(hashedPassword)
+	// The admin did not choose this password, so they have to replace it on their next login
+	user.MustChangePassword = true
+	if err := s.repo.UpdateUser(ctx, user); err != nil {
+		return err
+	}
+	_, err = s.sessions.RevokeAll(ctx, user.ID, "")
+	return err
+}
+
+func (s *authService) UpdateAdminEmail(ctx context.Context, id uint, email string) error {
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	user.Email = email
+	return s.repo.UpdateUser(ctx, user)
+}
+
+// UpdateAdminRole assigns a role and ends the admin's sessions, so the new permissions apply at once. The last
+// super admin cannot be given another role, so that someone can still manage admins and roles.
+func (s *authService) UpdateAdminRole(ctx context.Context, id uint, role string) error {
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.checkRoleExists(ctx, role); err != nil {
+		return err
+	}
+	if user.Role == role {
+		return nil
+	}
+
+	if user.Role == models.RoleSuperAdmin {
+		count, err := s.roles.CountUsers(ctx, models.RoleSuperAdmin)
+		if err != nil {
+			return err
+		}
+		if count <= 1 {
+			return utils.NewAppError(400, "The last super admin cannot be given another role")
+		}
+	}
+
+	user.Role = role
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateUser(ctx, user); err != nil {
+			return err
+		}
+		if _, err := s.sessions.RevokeAll(ctx, user.ID, ""); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionUpdate, "user", &id, nil, map[string]string{"role": role})
+	})
+}
+
+func (s *authService) checkRoleExists(ctx context.Context, role string) error {
+	if _, err := s.roles.FindByName(ctx, role); err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return utils.NewAppError(400, "Unknown role: "+role)
+		}
+		return err
+	}
+	return nil
+}
+
+// ChangePassword replaces the user's own password, clears MustChangePassword and ends the user's other sessions.
+// It returns a new token pair for the current session because its access token still carries the flag.
+func (s *authService) ChangePassword(ctx context.Context, userID uint, sessionID, currentPassword, newPassword string) (*dto.TokenPair, error) {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, utils.ErrUnauthorized
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword)); err != nil {
+		return nil, utils.NewAppError(400, "Current password is incorrect")
+	}
+	if currentPassword == newPassword {
+		return nil, utils.NewAppError(400, "New password must be different from the current password")
+	}
+	if err := utils.ValidatePassword(newPassword); err != nil {
+		return nil, utils.NewAppError(400, err.Error())
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.setPassword(ctx, user, newPassword); err != nil {
+			return err
+		}
+		if _, err := s.sessions.RevokeAll(ctx, user.ID, sessionID); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionPasswordChange, "auth", nil, nil, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.generateTokenPair(ctx, user, sessionID)
+}
+
+// ForgotPassword emails a single-use reset link to the admin with this address. It returns nil for unknown
+// addresses as well, so the endpoint cannot be used to find out which addresses have an account.
+func (s *authService) ForgotPassword(ctx context.Context, email, locale string) error {
+	// Without Redis the token could not be stored and the link would never work
+	if !s.cache.IsAvailable() {
+		return utils.NewAppError(503, "Password reset is temporarily unavailable")
+	}
+
+	user, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	// The link is created when the email is sent; only the latest one works
+	return s.notifier.NotifyPasswordLink(ctx, user, EventPasswordReset, locale)
+}
+
+// ResetPassword sets a new password with a token from ForgotPassword. The password policy is checked first, so a
+// rejected password does not use up the link.
+func (s *authService) ResetPassword(ctx context.Context, token, password string) error {
+	if err := utils.ValidatePassword(password); err != nil {
+		return utils.NewAppError(400, err.Error())
+	}
+
+	userID := consumePasswordLink(s.cache, token)
+	if userID == 0 {
+		return utils.NewAppError(400, "Invalid or expired reset token")
+	}
+
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return utils.NewAppError(400, "Invalid or expired reset token")
+		}
+		return err
+	}
+	if err := s.setPassword(ctx, user, password); err != nil {
+		return err
+	}
+	// Whoever may have known the old password is logged out
+	_, err = s.sessions.RevokeAll(ctx, user.ID, "")
+	return err
+}
+
+// setPassword stores a password the user chose themselves
+func (s *authService) setPassword(ctx context.Context, user *models.User, password string) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user.Password = string(hashedPassword)
+	user.MustChangePassword = false
 	return s.repo.UpdateUser(ctx, user)
 }
+
+// generateRandomToken returns 32 random bytes, URL-safe encoded
+func generateRandomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -2,13 +2,19 @@ package services_test
 
 import (
 	"backend-go/config"
+	"backend-go/internal/logger"
 	"backend-go/internal/models"
 	"backend-go/internal/services"
+	"backend-go/internal/utils"
 	"context"
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/pquerna/otp/totp"
+	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -40,6 +46,15 @@ func (m *mockUserRepository) FindByUsername(ctx context.Context, username string
 	return nil, errors.New("record not found")
 }
 
+func (m *mockUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	for _, u := range m.users {
+		if u.Email != "" && strings.EqualFold(u.Email, email) {
+			return u, nil
+		}
+	}
+	return nil, utils.ErrNotFound
+}
+
 func (m *mockUserRepository) FindAll(ctx context.Context) ([]models.User, error) {
 	var users []models.User
 	for _, u := range m.users {
@@ -93,13 +108,19 @@ func (m *mockCacheService) Get(key string, dest interface{}) error {
 	if !ok {
 		return errors.New("key not found")
 	}
-	// Simplified reflection for test (assumes bool for blacklist)
-	if v, ok := dest.(*bool); ok {
-		if valBool, ok := val.(bool); ok {
-			*v = valBool
-			return nil
-		}
+	// Round-trip through JSON like the Redis implementation
+	raw, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, dest)
+}
+
+func (m *mockCacheService) GetDel(key string, dest interface{}) error {
+	if err := m.Get(key, dest); err != nil {
+		return err
 	}
+	delete(m.cache, key)
 	return nil
 }
 
@@ -108,6 +129,21 @@ func (m *mockCacheService) Set(key string, value interface{}, expiration time.Du
 	return nil
 }
 
+func (m *mockCacheService) SetNX(key string, value interface{}, expiration time.Duration) (bool, error) {
+	if _, ok := m.cache[key]; ok {
+		return false, nil
+	}
+	m.cache[key] = value
+	return true, nil
+}
+
+func (m *mockCacheService) Increment(key string, expiration time.Duration) (int64, error) {
+	count, _ := m.cache[key].(int64)
+	count++
+	m.cache[key] = count
+	return count, nil
+}
+
 func (m *mockCacheService) Delete(key string) error {
 	delete(m.cache, key)
 	return nil
@@ -122,15 +158,79 @@ func (m *mockCacheService) IsAvailable() bool {
 	return true
 }
 
+// Manual Mock for RecoveryCodeRepository, keyed by code hash
+type mockRecoveryCodes struct {
+	codes map[string]*models.RecoveryCode
+}
+
+func newMockRecoveryCodes() *mockRecoveryCodes {
+	return &mockRecoveryCodes{codes: make(map[string]*models.RecoveryCode)}
+}
+
+func (m *mockRecoveryCodes) Replace(ctx context.Context, userID uint, codeHashes []string) error {
+	_ = m.DeleteByUser(ctx, userID)
+	for _, hash := range codeHashes {
+		m.codes[hash] = &models.RecoveryCode{UserID: userID, CodeHash: hash}
+	}
+	return nil
+}
+
+func (m *mockRecoveryCodes) Use(ctx context.Context, userID uint, codeHash string, at time.Time) (bool, error) {
+	code, ok := m.codes[codeHash]
+	if !ok || code.UserID != userID || code.UsedAt != nil {
+		return false, nil
+	}
+	code.UsedAt = &at
+	return true, nil
+}
+
+func (m *mockRecoveryCodes) CountUnused(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	for _, code := range m.codes {
+		if code.UserID == userID && code.UsedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockRecoveryCodes) DeleteByUser(ctx context.Context, userID uint) error {
+	for hash, code := range m.codes {
+		if code.UserID == userID {
+			delete(m.codes, hash)
+		}
+	}
+	return nil
+}
+
+// Manual Mock for NotificationService, recording emails
+type mockEmailNotifier struct {
+	services.NotificationService
+	events []string
+	data   []interface{}
+}
+
+func (m *mockEmailNotifier) NotifyEmail(ctx context.Context, to, event, locale string, data interface{}) error {
+	m.events = append(m.events, event)
+	m.data = append(m.data, data)
+	return nil
+}
+
+func (m *mockEmailNotifier) NotifyPasswordLink(ctx context.Context, user *models.User, event, locale string) error {
+	m.events = append(m.events, event)
+	m.data = append(m.data, user.ID)
+	return nil
+}
+
 // Test Suite
 func TestAuthService_RegisterAdmin(t *testing.T) {
 	repo := newMockRepo()
 	cache := newMockCache()
-	service := services.NewAuthService(repo, cache)
+	service := services.NewAuthService(repo, newMockRoles(repo), newMockRecoveryCodes(), newMockSessions(), cache, mockTxManager{}, &mockEmailNotifier{})
 	ctx := context.Background()
 
 	// Test Success
-	err := service.RegisterAdmin(ctx, "admin", "Password123", "")
+	err := service.RegisterAdmin(ctx, "admin", "Password123", "", "")
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -143,7 +243,7 @@ func TestAuthService_RegisterAdmin(t *testing.T) {
 	}
 
 	// Test Duplicate
-	err = service.RegisterAdmin(ctx, "admin", "newpass", "")
+	err = service.RegisterAdmin(ctx, "admin", "newpass", "", "")
 	if err == nil {
 		t.Error("expected error for duplicate user, got nil")
 	}
@@ -155,14 +255,14 @@ func TestAuthService_Login(t *testing.T) {
 
 	repo := newMockRepo()
 	cache := newMockCache()
-	service := services.NewAuthService(repo, cache)
+	service := services.NewAuthService(repo, newMockRoles(repo), newMockRecoveryCodes(), newMockSessions(), cache, mockTxManager{}, &mockEmailNotifier{})
 	ctx := context.Background()
 
 	// Seed User
-	service.RegisterAdmin(ctx, "user1", "CorrectPass1", "")
+	service.RegisterAdmin(ctx, "user1", "CorrectPass1", "", "")
 
 	// Test Success
-	tokenPair, err := service.Login(ctx, "user1", "CorrectPass1")
+	tokenPair, err := service.Login(ctx, "user1", "CorrectPass1", services.ClientInfo{})
 	if err != nil {
 		t.Errorf("login failed: %v", err)
 	}
@@ -171,14 +271,353 @@ func TestAuthService_Login(t *testing.T) {
 	}
 
 	// Test Wrong Password
-	_, err = service.Login(ctx, "user1", "wrongpass")
+	_, err = service.Login(ctx, "user1", "wrongpass", services.ClientInfo{})
 	if err == nil {
 		t.Error("expected error for wrong password")
 	}
 
 	// Test Non-existent User
-	_, err = service.Login(ctx, "ghost", "pass")
+	_, err = service.Login(ctx, "ghost", "pass", services.ClientInfo{})
 	if err == nil {
 		t.Error("expected error for non-existent user")
 	}
 }
+
+func TestAuthService_LoginLockout(t *testing.T) {
+	config.AppConfig.JWTSecret = "supersecret"
+	logger.Log = zap.NewNop()
+
+	repo := newMockRepo()
+	cache := newMockCache()
+	service := services.NewAuthService(repo, newMockRoles(repo), newMockRecoveryCodes(), newMockSessions(), cache, mockTxManager{}, &mockEmailNotifier{})
+	ctx := context.Background()
+	service.RegisterAdmin(ctx, "user1", "CorrectPass1", "", "")
+
+	// failLogins makes wrong attempts, skipping the waits between them, and returns the error of the next attempt
+	failLogins := func(username string, attempts int, client services.ClientInfo) error {
+		for i := 0; i < attempts; i++ {
+			if _, err := service.Login(ctx, username, "wrongpass", client); err != utils.ErrUnauthorized {
+				t.Fatalf("attempt %d for %s: expected unauthorized, got %v", i+1, username, err)
+			}
+			cache.Delete(services.LoginDelayPrefix + username)
+		}
+		_, err := service.Login(ctx, username, "CorrectPass1", client)
+		return err
+	}
+
+	// Wrong attempts past the free ones make the next attempt wait
+	for i := 0; i < 3; i++ {
+		service.Login(ctx, "user1", "wrongpass", services.ClientInfo{})
+	}
+	if _, err := service.Login(ctx, "user1", "CorrectPass1", services.ClientInfo{}); !isStatus(err, 429) {
+		t.Errorf("expected to have to wait after 3 failures, got %v", err)
+	}
+	cache.Delete(services.LoginDelayPrefix + "user1")
+
+	// Five failures lock the account, even for the right password
+	lockErr := failLogins("user1", 2, services.ClientInfo{})
+	if !isStatus(lockErr, 429) {
+		t.Fatalf("expected the account to be locked, got %v", lockErr)
+	}
+
+	// An unknown username gets exactly the same responses
+	if err := failLogins("ghost", 5, services.ClientInfo{}); err == nil || err.Error() != lockErr.Error() {
+		t.Errorf("expected an unknown username to be locked like a real one, got %v", err)
+	}
+
+	user, _ := repo.FindByUsername(ctx, "user1")
+	if err := service.UnlockAdmin(ctx, user.ID); err != nil {
+		t.Fatalf("unlock failed: %v", err)
+	}
+	if _, err := service.Login(ctx, "user1", "CorrectPass1", services.ClientInfo{}); err != nil {
+		t.Errorf("expected login to work after unlock, got %v", err)
+	}
+
+	// Failures from one IP address add up across usernames
+	config.AppConfig.LoginMaxAttemptsPerIP = 3
+	defer func() { config.AppConfig.LoginMaxAttemptsPerIP = 0 }()
+	client := services.ClientInfo{IPAddress: "10.0.0.1"}
+	failLogins("alice", 1, client)
+	failLogins("bob", 0, client)
+	if _, err := service.Login(ctx, "user1", "CorrectPass1", client); !isStatus(err, 429) {
+		t.Errorf("expected the IP address to be blocked, got %v", err)
+	}
+	if _, err := service.Login(ctx, "user1", "CorrectPass1", services.ClientInfo{IPAddress: "10.0.0.2"}); err != nil {
+		t.Errorf("expected other IP addresses to be unaffected, got %v", err)
+	}
+}
+
+func isStatus(err error, code int) bool {
+	var appErr *utils.AppError
+	return errors.As(err, &appErr) && appErr.Code == code
+}
+
+func TestAuthService_ForcedPasswordChange(t *testing.T) {
+	config.AppConfig.JWTSecret = "supersecret"
+
+	repo := newMockRepo()
+	notifier := &mockEmailNotifier{}
+	service := services.NewAuthService(repo, newMockRoles(repo), newMockRecoveryCodes(), newMockSessions(), newMockCache(), mockTxManager{}, notifier)
+	ctx := context.Background()
+
+	if err := service.RegisterAdmin(ctx, "user1", "TempPass123", "user1@example.com", ""); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	if len(notifier.events) != 1 || notifier.events[0] != services.EventAdminWelcome {
+		t.Errorf("expected a welcome email, got %v", notifier.events)
+	}
+
+	result, err := service.Login(ctx, "user1", "TempPass123", services.ClientInfo{})
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	if !result.User.MustChangePassword {
+		t.Error("expected a new admin to have to change the password")
+	}
+
+	if _, err := service.ChangePassword(ctx, result.User.ID, "", "WrongPass123", "NewPass123"); err == nil {
+		t.Error("expected error for wrong current password")
+	}
+	if _, err := service.ChangePassword(ctx, result.User.ID, "", "TempPass123", "TempPass123"); err == nil {
+		t.Error("expected error for reusing the current password")
+	}
+
+	tokenPair, err := service.ChangePassword(ctx, result.User.ID, "", "TempPass123", "NewPass123")
+	if err != nil {
+		t.Fatalf("change password failed: %v", err)
+	}
+	if tokenPair.User.MustChangePassword {
+		t.Error("expected the new tokens to no longer require a password change")
+	}
+
+	if err := service.UpdateAdminPassword(ctx, tokenPair.User.ID, "ByAdmin123"); err != nil {
+		t.Fatalf("update password failed: %v", err)
+	}
+	user, _ := repo.FindByID(ctx, tokenPair.User.ID)
+	if !user.MustChangePassword {
+		t.Error("expected a password set by a super admin to require a change")
+	}
+}
+
+func TestAuthService_PasswordReset(t *testing.T) {
+	config.AppConfig.FrontendURL = "https://admin.example.com/"
+
+	repo := newMockRepo()
+	cache := newMockCache()
+	outbox := &mockOutbox{}
+	email := &mockNotifier{channel: models.ChannelEmail}
+	notifications := services.NewNotificationService(&mockDeliveryRepository{}, outbox, newTemplateService(t, &mockTemplateRepository{}), cache, []services.Notifier{email})
+	service := services.NewAuthService(repo, newMockRoles(repo), newMockRecoveryCodes(), newMockSessions(), cache, mockTxManager{}, notifications)
+	ctx := context.Background()
+
+	// The welcome email links to the same page, but never contains the password
+	service.RegisterAdmin(ctx, "user1", "TempPass123", "User1@Example.com", "")
+	deliverQueued(t, notifications, outbox)
+	if len(email.messages) != 1 || strings.Contains(email.messages[0].Text, "TempPass123") {
+		t.Fatalf("expected a welcome email without the password, got %+v", email.messages)
+	}
+	welcome := resetToken(t, email.messages[0])
+
+	// Unknown addresses get the same answer and no email
+	if err := service.ForgotPassword(ctx, "ghost@example.com", "id"); err != nil {
+		t.Errorf("expected no error for unknown email, got %v", err)
+	}
+	if len(outbox.jobs) != 0 {
+		t.Errorf("expected no email for unknown address, got %v", outbox.payloads)
+	}
+
+	// The first link stops working once a second one is requested
+	if err := service.ForgotPassword(ctx, "user1@example.com", "en"); err != nil {
+		t.Fatalf("forgot password failed: %v", err)
+	}
+	if err := service.ForgotPassword(ctx, "user1@example.com", "en"); err != nil {
+		t.Fatalf("forgot password failed: %v", err)
+	}
+	for _, payload := range outbox.payloads {
+		if strings.Contains(payload, "token") {
+			t.Errorf("expected the outbox not to hold the link, got %s", payload)
+		}
+	}
+	deliverQueued(t, notifications, outbox)
+	if len(email.messages) != 3 {
+		t.Fatalf("expected two reset emails, got %d emails", len(email.messages)-1)
+	}
+	first := resetToken(t, email.messages[1])
+	second := resetToken(t, email.messages[2])
+
+	for key := range cache.cache {
+		if strings.Contains(key, second) {
+			t.Errorf("expected only the token hash to be stored, found %s", key)
+		}
+	}
+
+	for _, replaced := range []string{welcome, first} {
+		if err := service.ResetPassword(ctx, replaced, "NewPass123"); err == nil {
+			t.Error("expected a replaced link to be rejected")
+		}
+	}
+	if err := service.ResetPassword(ctx, second, "short"); err == nil {
+		t.Error("expected a password that fails the policy to be rejected")
+	}
+	if err := service.ResetPassword(ctx, second, "NewPass123"); err != nil {
+		t.Fatalf("reset failed: %v", err)
+	}
+	if err := service.ResetPassword(ctx, second, "OtherPass123"); err == nil {
+		t.Error("expected a used link to be rejected")
+	}
+
+	user, _ := repo.FindByUsername(ctx, "user1")
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("NewPass123")) != nil {
+		t.Error("expected the password to be reset")
+	}
+	if user.MustChangePassword {
+		t.Error("expected a password chosen through a reset link not to require a change")
+	}
+}
+
+// resetToken takes the token from the password link in an email
+func resetToken(t *testing.T, msg services.Message) string {
+	t.Helper()
+	prefix := "https://admin.example.com/reset-password?token="
+	i := strings.Index(msg.Text, prefix)
+	if i < 0 {
+		t.Fatalf("expected a password link in %q", msg.Text)
+	}
+	return strings.Fields(msg.Text[i+len(prefix):])[0]
+}
+
+func TestAuthService_TwoFactor(t *testing.T) {
+	config.AppConfig.JWTSecret = "supersecret"
+	config.AppConfig.RequireSuperAdmin2FA = true
+	defer func() { config.AppConfig.RequireSuperAdmin2FA = false }()
+
+	repo := newMockRepo()
+	recoveryCodes := newMockRecoveryCodes()
+	service := services.NewAuthService(repo, newMockRoles(repo), recoveryCodes, newMockSessions(), newMockCache(), mockTxManager{}, &mockEmailNotifier{})
+	ctx := context.Background()
+
+	service.RegisterAdmin(ctx, "root", "RootPass123", "", models.RoleSuperAdmin)
+	user, _ := repo.FindByUsername(ctx, "root")
+
+	result, err := service.Login(ctx, "root", "RootPass123", services.ClientInfo{})
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	if result.TwoFactorRequired || !result.User.TwoFactorSetupRequired {
+		t.Fatal("expected a super admin without 2FA to get tokens that require enrollment")
+	}
+
+	setup, err := service.SetupTwoFactor(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	if !strings.HasPrefix(setup.OTPAuthURL, "otpauth://totp/") || !strings.HasPrefix(setup.QRCode, "data:image/png;base64,") {
+		t.Errorf("unexpected provisioning data %s", setup.OTPAuthURL)
+	}
+	if _, err := service.EnableTwoFactor(ctx, user.ID, "", "000000"); err == nil {
+		t.Error("expected a wrong code to be rejected")
+	}
+
+	code, _ := totp.GenerateCode(setup.Secret, time.Now())
+	enabled, err := service.EnableTwoFactor(ctx, user.ID, "", code)
+	if err != nil {
+		t.Fatalf("enable failed: %v", err)
+	}
+	if len(enabled.RecoveryCodes) != 10 || enabled.User.TwoFactorSetupRequired {
+		t.Errorf("expected 10 recovery codes and tokens without the enrollment requirement, got %d codes", len(enabled.RecoveryCodes))
+	}
+	for hash := range recoveryCodes.codes {
+		if strings.Contains(hash, "-") {
+			t.Errorf("expected recovery codes to be stored hashed, found %s", hash)
+		}
+	}
+	if err := service.DisableTwoFactor(ctx, user.ID, "RootPass123", code); err == nil {
+		t.Error("expected super admins not to be able to disable enforced 2FA")
+	}
+
+	// Login now stops at a challenge
+	result, err = service.Login(ctx, "root", "RootPass123", services.ClientInfo{})
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	if !result.TwoFactorRequired || result.ChallengeToken == "" || result.TokenPair != nil {
+		t.Fatal("expected a challenge instead of tokens")
+	}
+
+	if _, _, err := service.VerifyTwoFactor(ctx, result.ChallengeToken, "000000", services.ClientInfo{}); err == nil {
+		t.Error("expected a wrong code to be rejected")
+	}
+	tokenPair, usedRecovery, err := service.VerifyTwoFactor(ctx, result.ChallengeToken, code, services.ClientInfo{})
+	if err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	if tokenPair.AccessToken == "" || usedRecovery {
+		t.Error("expected tokens from a TOTP code")
+	}
+	if _, _, err := service.VerifyTwoFactor(ctx, result.ChallengeToken, code, services.ClientInfo{}); err == nil {
+		t.Error("expected a completed challenge to be rejected")
+	}
+
+	// A TOTP code cannot be replayed, and a recovery code works once, typed in any case
+	result, _ = service.Login(ctx, "root", "RootPass123", services.ClientInfo{})
+	if _, _, err := service.VerifyTwoFactor(ctx, result.ChallengeToken, code, services.ClientInfo{}); err == nil {
+		t.Error("expected a used TOTP code to be rejected")
+	}
+	recoveryCode := strings.ToUpper(enabled.RecoveryCodes[0])
+	if _, usedRecovery, err := service.VerifyTwoFactor(ctx, result.ChallengeToken, recoveryCode, services.ClientInfo{}); err != nil || !usedRecovery {
+		t.Fatalf("expected the recovery code to be accepted, got %v", err)
+	}
+	result, _ = service.Login(ctx, "root", "RootPass123", services.ClientInfo{})
+	if _, _, err := service.VerifyTwoFactor(ctx, result.ChallengeToken, recoveryCode, services.ClientInfo{}); err == nil {
+		t.Error("expected a used recovery code to be rejected")
+	}
+	if left, _ := recoveryCodes.CountUnused(ctx, user.ID); left != 9 {
+		t.Errorf("expected 9 unused recovery codes, got %d", left)
+	}
+}
+
+func TestAuthService_TwoFactorLockout(t *testing.T) {
+	config.AppConfig.JWTSecret = "supersecret"
+
+	repo := newMockRepo()
+	cache := newMockCache()
+	service := services.NewAuthService(repo, newMockRoles(repo), newMockRecoveryCodes(), newMockSessions(), cache, mockTxManager{}, &mockEmailNotifier{})
+	ctx := context.Background()
+
+	service.RegisterAdmin(ctx, "user1", "CorrectPass1", "", "")
+	user, _ := repo.FindByUsername(ctx, "user1")
+	setup, _ := service.SetupTwoFactor(ctx, user.ID)
+	code, _ := totp.GenerateCode(setup.Secret, time.Now())
+	if _, err := service.EnableTwoFactor(ctx, user.ID, "", code); err != nil {
+		t.Fatalf("enable failed: %v", err)
+	}
+
+	// Each login with the right password starts a new challenge, but the wrong codes keep adding up
+	for i := 0; i < 3; i++ {
+		result, err := service.Login(ctx, "user1", "CorrectPass1", services.ClientInfo{})
+		if err != nil {
+			t.Fatalf("login %d failed: %v", i+1, err)
+		}
+		if _, _, err := service.VerifyTwoFactor(ctx, result.ChallengeToken, "000000", services.ClientInfo{}); !isStatus(err, 401) {
+			t.Fatalf("expected a wrong code to be rejected, got %v", err)
+		}
+	}
+	if _, err := service.Login(ctx, "user1", "CorrectPass1", services.ClientInfo{}); !isStatus(err, 429) {
+		t.Fatalf("expected wrong codes to slow down further logins, got %v", err)
+	}
+
+	// Only a completed second factor forgets the failures
+	service.UnlockAdmin(ctx, user.ID)
+	result, _ := service.Login(ctx, "user1", "CorrectPass1", services.ClientInfo{})
+	service.VerifyTwoFactor(ctx, result.ChallengeToken, "000000", services.ClientInfo{})
+	if _, ok := cache.cache[services.LoginFailuresPrefix+"user:user1"]; !ok {
+		t.Fatal("expected the wrong code to be counted")
+	}
+	next, _ := totp.GenerateCode(setup.Secret, time.Now().Add(30*time.Second))
+	if _, _, err := service.VerifyTwoFactor(ctx, result.ChallengeToken, next, services.ClientInfo{}); err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	if _, ok := cache.cache[services.LoginFailuresPrefix+"user:user1"]; ok {
+		t.Error("expected the failures to be cleared after the second factor")
+	}
+}
@@ -13,7 +13,10 @@ import (
 
 type CacheService interface {
 	Get(key string, dest interface{}) error
+	GetDel(key string, dest interface{}) error
 	Set(key string, value interface{}, ttl time.Duration) error
+	SetNX(key string, value interface{}, ttl time.Duration) (bool, error)
+	Increment(key string, ttl time.Duration) (int64, error)
 	Delete(key string) error
 	DeleteByPattern(pattern string) error
 	IsAvailable() bool
@@ -61,6 +64,30 @@ func (s *cacheService) Get(key string, dest interface{}) error {
 	return json.Unmarshal([]byte(val), dest)
 }
 
+// GetDel retrieves a value and removes it in one step, so only one caller can ever read it.
+// Returns redis.Nil if key not found or Redis is unavailable.
+func (s *cacheService) GetDel(key string, dest interface{}) error {
+	if config.RedisClient == nil {
+		logger.Warn("Redis client not configured, skipping cache getdel", zap.String("key", key))
+		return redis.Nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	val, err := config.RedisClient.GetDel(ctx, key).Result()
+	if err != nil {
+		if err != redis.Nil {
+			logger.Warn("Redis GETDEL failed",
+				zap.String("key", key),
+				zap.Error(err),
+			)
+		}
+		return err
+	}
+	return json.Unmarshal([]byte(val), dest)
+}
+
 // Set stores a value in cache.
 // Logs warning and continues if Redis is unavailable.
 func (s *cacheService) Set(key string, value interface{}, ttl time.Duration) error {
@@ -89,6 +116,62 @@ func (s *cacheService) Set(key string, value interface{}, ttl time.Duration) err
 	return nil
 }
 
+// SetNX stores a value only if the key does not exist yet and reports whether it did, so exactly one caller can
+// claim a key. Returns an error if Redis is unavailable, since a claim cannot be skipped silently.
+func (s *cacheService) SetNX(key string, value interface{}, ttl time.Duration) (bool, error) {
+	if config.RedisClient == nil {
+		logger.Warn("Redis client not configured, skipping cache setnx", zap.String("key", key))
+		return false, redis.Nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+
+	claimed, err := config.RedisClient.SetNX(ctx, key, bytes, ttl).Result()
+	if err != nil {
+		logger.Warn("Redis SETNX failed",
+			zap.String("key", key),
+			zap.Error(err),
+		)
+		return false, err
+	}
+	return claimed, nil
+}
+
+// Increment adds one to a counter and returns the new value. The TTL is set when the counter is created, so it
+// counts within a fixed window. Returns an error if Redis is unavailable, since a counter cannot be skipped silently.
+func (s *cacheService) Increment(key string, ttl time.Duration) (int64, error) {
+	if config.RedisClient == nil {
+		logger.Warn("Redis client not configured, skipping cache increment", zap.String("key", key))
+		return 0, redis.Nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	count, err := config.RedisClient.Incr(ctx, key).Result()
+	if err != nil {
+		logger.Warn("Redis INCR failed",
+			zap.String("key", key),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	if count == 1 {
+		if err := config.RedisClient.Expire(ctx, key, ttl).Err(); err != nil {
+			// Without a TTL the counter would never reset, so drop it and let the next failure start over
+			config.RedisClient.Del(ctx, key)
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
 // Delete removes a key from cache.
 // Logs warning and continues if Redis is unavailable.
 func (s *cacheService) Delete(key string) error {
@@ -19,16 +19,22 @@ type CategoryService interface {
 
 type categoryService struct {
 	repo repository.CategoryRepository
+	tx   repository.TxManager
 }
 
-func NewCategoryService(repo repository.CategoryRepository) CategoryService {
-	return &categoryService{repo}
+func NewCategoryService(repo repository.CategoryRepository, tx repository.TxManager) CategoryService {
+	return &categoryService{repo, tx}
 }
 
 func (s *categoryService) CreateCategory(ctx context.Context, category *models.Category) error {
 	// Generate slug from name
 	category.Slug = generateSlug(category.Name)
-	return s.repo.Create(ctx, category)
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, category); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionCreate, "category", &category.ID, nil, category)
+	})
 }
 
 func (s *categoryService) GetAllCategories(ctx context.Context) ([]models.Category, error) {
@@ -57,7 +63,12 @@ func (s *categoryService) UpdateCategory(ctx context.Context, id uint, data *mod
 		existing.Description = data.Description
 	}
 
-	return s.repo.Update(ctx, existing)
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, existing); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionUpdate, "category", &id, nil, data)
+	})
 }
 
 func (s *categoryService) DeleteCategory(ctx context.Context, id uint) error {
@@ -65,7 +76,12 @@ func (s *categoryService) DeleteCategory(ctx context.Context, id uint) error {
 	if err != nil {
 		return err
 	}
-	return s.repo.Delete(ctx, id)
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionDelete, "category", &id, nil, nil)
+	})
 }
 
 // generateSlug creates a URL-friendly slug from a string
@@ -11,7 +11,7 @@ import (
 )
 
 type ExportService interface {
-	ExportSantriToExcel(ctx context.Context, status string) (*bytes.Buffer, error)
+	ExportSantriToExcel(ctx context.Context, status string, waveID uint) (*bytes.Buffer, error)
 }
 
 type exportService struct {
@@ -22,16 +22,8 @@ func NewExportService(santriRepo repository.SantriRepository) ExportService {
 	return &exportService{santriRepo}
 }
 
-func (s *exportService) ExportSantriToExcel(ctx context.Context, status string) (*bytes.Buffer, error) {
-	var santris []models.Santri
-	var err error
-
-	if status != "" {
-		santris, err = s.santriRepo.FindByStatus(ctx, models.SantriStatus(status))
-	} else {
-		santris, err = s.santriRepo.FindAll(ctx)
-	}
-
+func (s *exportService) ExportSantriToExcel(ctx context.Context, status string, waveID uint) (*bytes.Buffer, error) {
+	santris, err := s.santriRepo.FindFiltered(ctx, status, waveID)
 	if err != nil {
 		return nil, err
 	}
@@ -44,7 +36,9 @@ func (s *exportService) ExportSantriToExcel(ctx context.Context, status string)
 	headers := []string{
 		"No", "Nama Lengkap", "NIK", "Tempat Lahir", "Tanggal Lahir",
 		"Jenis Kelamin", "Alamat", "Nama Orang Tua", "No. HP Orang Tua",
-		"NIS", "Kelas", "Tahun Masuk", "Status", "Tanggal Daftar",
+		"NIS", "Kelas", "Tahun Masuk", "Status", "Tanggal Daftar", "Gelombang",
+		"Nama Ayah", "Pekerjaan Ayah", "Nama Ibu", "Pekerjaan Ibu",
+		"Asal Sekolah", "Alamat Sekolah", "Tahun Lulus",
 	}
 
 	headerStyle, _ := f.NewStyle(&excelize.Style{
@@ -80,6 +74,14 @@ func (s *exportService) ExportSantriToExcel(ctx context.Context, status string)
 	f.SetColWidth(sheetName, "L", "L", 12)
 	f.SetColWidth(sheetName, "M", "M", 12)
 	f.SetColWidth(sheetName, "N", "N", 18)
+	f.SetColWidth(sheetName, "O", "O", 25)
+	f.SetColWidth(sheetName, "P", "P", 20)
+	f.SetColWidth(sheetName, "Q", "Q", 18)
+	f.SetColWidth(sheetName, "R", "R", 20)
+	f.SetColWidth(sheetName, "S", "S", 18)
+	f.SetColWidth(sheetName, "T", "T", 25)
+	f.SetColWidth(sheetName, "U", "U", 30)
+	f.SetColWidth(sheetName, "V", "V", 12)
 
 	// Data style
 	dataStyle, _ := f.NewStyle(&excelize.Style{
@@ -98,6 +100,26 @@ func (s *exportService) ExportSantriToExcel(ctx context.Context, status string)
 		if santri.Gender == "P" {
 			genderLabel = "Perempuan"
 		}
+		waveLabel := "-"
+		if santri.Wave != nil {
+			waveLabel = fmt.Sprintf("%s (%s)", santri.Wave.Name, santri.Wave.AcademicYear)
+		}
+
+		var fatherName, fatherJob, motherName, motherJob string
+		if father := santri.GuardianByRelation(models.GuardianFather); father != nil {
+			fatherName, fatherJob = father.Name, father.Job
+		}
+		if mother := santri.GuardianByRelation(models.GuardianMother); mother != nil {
+			motherName, motherJob = mother.Name, mother.Job
+		}
+
+		var schoolName, schoolAddress, graduationYear string
+		if education := santri.PriorEducation; education != nil {
+			schoolName, schoolAddress = education.SchoolName, education.SchoolAddress
+			if education.GraduationYear != 0 {
+				graduationYear = fmt.Sprintf("%d", education.GraduationYear)
+			}
+		}
 
 		data := []interface{}{
 			i + 1,
@@ -114,6 +136,14 @@ func (s *exportService) ExportSantriToExcel(ctx context.Context, status string)
 			santri.EntryYear,
 			string(santri.Status),
 			santri.CreatedAt.Format("02-01-2006 15:04"),
+			waveLabel,
+			fatherName,
+			fatherJob,
+			motherName,
+			motherJob,
+			schoolName,
+			schoolAddress,
+			graduationYear,
 		}
 
 		for j, value := range data {
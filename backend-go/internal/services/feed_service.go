@@ -0,0 +1,222 @@
+package services
+
+import (
+	"backend-go/config"
+	"backend-go/internal/models"
+	"backend-go/internal/repository"
+	"backend-go/internal/utils"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/feeds"
+)
+
+// Feed formats
+const (
+	FeedRSS  = "rss"
+	FeedAtom = "atom"
+	FeedJSON = "json"
+)
+
+const (
+	feedSite         = "Pondok Pesantren K3 Arafah"
+	feedSize         = 20
+	feedSummaryRunes = 280
+	feedCacheTTL     = 30 * time.Minute
+)
+
+var feedContentTypes = map[string]string{
+	FeedRSS:  "application/rss+xml; charset=utf-8",
+	FeedAtom: "application/atom+xml; charset=utf-8",
+	FeedJSON: "application/feed+json; charset=utf-8",
+}
+
+// Feed is a rendered article feed with the validators for conditional requests
+type Feed struct {
+	Body         string    `json:"body"`
+	ContentType  string    `json:"content_type"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"last_modified"` // Zero when the feed has no articles
+}
+
+// FeedService renders the latest published articles as RSS, Atom or JSON Feed. Rendered feeds are cached until an
+// article changes.
+type FeedService interface {
+	ArticleFeed(ctx context.Context, format string) (*Feed, error)
+	CategoryFeed(ctx context.Context, slug, format string) (*Feed, error)
+	TagFeed(ctx context.Context, slug, format string) (*Feed, error)
+}
+
+type feedService struct {
+	articles   repository.ArticleRepository
+	categories repository.CategoryRepository
+	tags       repository.TagRepository
+	cache      CacheService
+}
+
+func NewFeedService(articles repository.ArticleRepository, categories repository.CategoryRepository, tags repository.TagRepository, cache CacheService) FeedService {
+	return &feedService{articles, categories, tags, cache}
+}
+
+func (s *feedService) ArticleFeed(ctx context.Context, format string) (*Feed, error) {
+	return s.cached("all", format, func() (*Feed, error) {
+		articles, _, err := s.articles.FindAllPaginated(ctx, 1, feedSize)
+		if err != nil {
+			return nil, err
+		}
+		return renderFeed(feedSite, "Berita dan artikel terbaru dari "+feedSite, "/feeds/articles", format, articles)
+	})
+}
+
+func (s *feedService) CategoryFeed(ctx context.Context, slug, format string) (*Feed, error) {
+	return s.cached("category:"+slug, format, func() (*Feed, error) {
+		category, err := s.categories.FindBySlug(ctx, slug)
+		if err != nil {
+			return nil, err
+		}
+		articles, _, err := s.articles.FindByCategory(ctx, category.ID, 1, feedSize)
+		if err != nil {
+			return nil, err
+		}
+		return renderFeed(feedSite+" - "+category.Name, "Artikel kategori "+category.Name, "/feeds/category/"+slug, format, articles)
+	})
+}
+
+func (s *feedService) TagFeed(ctx context.Context, slug, format string) (*Feed, error) {
+	return s.cached("tag:"+slug, format, func() (*Feed, error) {
+		tag, err := s.tags.FindBySlug(ctx, slug)
+		if err != nil {
+			return nil, err
+		}
+		articles, _, err := s.articles.FindByTag(ctx, tag.ID, 1, feedSize)
+		if err != nil {
+			return nil, err
+		}
+		return renderFeed(feedSite+" - #"+tag.Name, "Artikel dengan tag "+tag.Name, "/feeds/tag/"+slug, format, articles)
+	})
+}
+
+func (s *feedService) cached(scope, format string, build func() (*Feed, error)) (*Feed, error) {
+	if _, ok := feedContentTypes[format]; !ok {
+		return nil, utils.NewAppError(400, "Unknown feed format: "+format)
+	}
+
+	key := fmt.Sprintf(utils.CacheKeyArticlesFeedPattern, scope, format)
+	var feed Feed
+	if err := s.cache.Get(key, &feed); err == nil {
+		return &feed, nil
+	}
+
+	result, err := build()
+	if err != nil {
+		return nil, err
+	}
+	_ = s.cache.Set(key, result, feedCacheTTL)
+	return result, nil
+}
+
+// renderFeed builds a feed of articles, newest first. path is where the feed is served, below the public API URL.
+func renderFeed(title, description, path, format string, articles []models.Article) (*Feed, error) {
+	site := strings.TrimRight(config.AppConfig.FrontendURL, "/")
+	feed := &feeds.Feed{
+		Title:       title,
+		Link:        &feeds.Link{Href: site},
+		Description: description,
+		Id:          strings.TrimRight(config.AppConfig.PublicAPIURL, "/") + path,
+		Copyright:   feedSite,
+	}
+
+	var lastModified time.Time
+	for _, article := range articles {
+		published := article.CreatedAt
+		if article.PublishAt != nil {
+			published = *article.PublishAt
+		}
+		if article.UpdatedAt.After(lastModified) {
+			lastModified = article.UpdatedAt
+		}
+
+		link := site + "/articles/" + article.Slug
+		item := &feeds.Item{
+			Title:       article.Title,
+			Link:        &feeds.Link{Href: link},
+			Id:          link,
+			Description: feedSummary(article.Content),
+			Content:     article.Content,
+			Created:     published,
+			Updated:     article.UpdatedAt,
+		}
+		if article.Author.Username != "" {
+			item.Author = &feeds.Author{Name: article.Author.Username}
+		}
+		feed.Items = append(feed.Items, item)
+	}
+	feed.Updated = lastModified
+
+	var body string
+	var err error
+	switch format {
+	case FeedRSS:
+		rss := (&feeds.Rss{Feed: feed}).RssFeed()
+		for i, item := range rss.Items {
+			if category := articles[i].Category; category != nil {
+				item.Category = category.Name
+			}
+		}
+		body, err = feeds.ToXML(rss)
+	case FeedAtom:
+		body, err = feeds.ToXML(&feeds.Atom{Feed: feed})
+	case FeedJSON:
+		jsonFeed := (&feeds.JSON{Feed: feed}).JSONFeed()
+		jsonFeed.FeedUrl = feed.Id
+		for i, item := range jsonFeed.Items {
+			item.Tags = articleFeedTags(articles[i])
+			item.Image = articles[i].ThumbnailURL
+		}
+		var data []byte
+		data, err = json.MarshalIndent(jsonFeed, "", "  ")
+		body = string(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256([]byte(body))
+	return &Feed{
+		Body:         body,
+		ContentType:  feedContentTypes[format],
+		ETag:         `"` + hex.EncodeToString(sum[:16]) + `"`,
+		LastModified: lastModified.UTC().Truncate(time.Second),
+	}, nil
+}
+
+// articleFeedTags is the category of an article followed by its tags
+func articleFeedTags(article models.Article) []string {
+	var tags []string
+	if article.Category != nil {
+		tags = append(tags, article.Category.Name)
+	}
+	for _, tag := range article.Tags {
+		tags = append(tags, tag.Name)
+	}
+	return tags
+}
+
+// feedSummary is the start of the article text, cut at a word
+func feedSummary(content string) string {
+	text := strings.Join(strings.Fields(articlePlainText(content)), " ")
+	if utf8.RuneCountInString(text) <= feedSummaryRunes {
+		return text
+	}
+	cut := string([]rune(text)[:feedSummaryRunes])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return cut + "…"
+}
@@ -22,10 +22,12 @@ type GalleryService interface {
 type galleryService struct {
 	repo         repository.GalleryRepository
 	mediaService MediaService
+	cache        CacheService
+	tx           repository.TxManager
 }
 
-func NewGalleryService(repo repository.GalleryRepository, mediaService MediaService) GalleryService {
-	return &galleryService{repo, mediaService}
+func NewGalleryService(repo repository.GalleryRepository, mediaService MediaService, cache CacheService, tx repository.TxManager) GalleryService {
+	return &galleryService{repo, mediaService, cache, tx}
 }
 
 func (s *galleryService) CreateGallery(ctx context.Context, gallery *models.Gallery, coverFile *multipart.FileHeader) error {
@@ -44,7 +46,16 @@ func (s *galleryService) CreateGallery(ctx context.Context, gallery *models.Gall
 		gallery.CoverURL = url
 	}
 
-	return s.repo.Create(ctx, gallery)
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, gallery); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionCreate, "gallery", &gallery.ID, nil, gallery)
+	})
+	if err == nil {
+		invalidateSearchCache(s.cache)
+	}
+	return err
 }
 
 func (s *galleryService) GetAllGalleries(ctx context.Context) ([]models.Gallery, error) {
@@ -69,7 +80,16 @@ func (s *galleryService) UpdateGallery(ctx context.Context, id uint, galleryData
 		existing.CoverURL = galleryData.CoverURL
 	}
 
-	return s.repo.Update(ctx, existing)
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, existing); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionUpdate, "gallery", &id, nil, galleryData)
+	})
+	if err == nil {
+		invalidateSearchCache(s.cache)
+	}
+	return err
 }
 
 func (s *galleryService) DeleteGallery(ctx context.Context, id uint) error {
@@ -89,7 +109,16 @@ func (s *galleryService) DeleteGallery(ctx context.Context, id uint) error {
 		_ = s.mediaService.DeleteImageByURL(ctx, photo.PhotoURL)
 	}
 
-	return s.repo.Delete(ctx, id)
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionDelete, "gallery", &id, nil, nil)
+	})
+	if err == nil {
+		invalidateSearchCache(s.cache)
+	}
+	return err
 }
 
 func (s *galleryService) AddPhotos(ctx context.Context, galleryID uint, files []*multipart.FileHeader) error {
@@ -147,5 +176,9 @@ func (s *galleryService) DeletePhoto(ctx context.Context, photoID uint) error {
 		_ = s.mediaService.DeleteImageByURL(ctx, photo.PhotoURL)
 	}
 
-	return s.repo.DeletePhoto(ctx, photoID)
+	if err := s.repo.DeletePhoto(ctx, photoID); err != nil {
+		return err
+	}
+	invalidateSearchCache(s.cache)
+	return nil
 }
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"backend-go/config"
+	"backend-go/internal/dto"
+	"backend-go/internal/models"
+	"backend-go/internal/repository"
+	"backend-go/internal/utils"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// documentTokenNonceLength is the length of the random part of a document verification token
+const documentTokenNonceLength = 16
+
+// ErrDocumentNotFound is returned for unknown and forged verification tokens alike
+var ErrDocumentNotFound = utils.NewAppError(404, "Document not found, it may not have been issued by us")
+
+type IssuedDocumentService interface {
+	Issue(ctx context.Context, santri *models.Santri, docType models.IssuedDocumentType) (*models.IssuedDocument, error)
+	VerificationURL(document *models.IssuedDocument) string
+	Verify(ctx context.Context, token string) (*dto.DocumentVerificationResponse, error)
+	GetBySantri(ctx context.Context, santriID uint) ([]models.IssuedDocument, error)
+	Revoke(ctx context.Context, id uint, reason string, actorID uint) (*models.IssuedDocument, error)
+}
+
+type issuedDocumentService struct {
+	repo repository.IssuedDocumentRepository
+	tx   repository.TxManager
+}
+
+func NewIssuedDocumentService(repo repository.IssuedDocumentRepository, tx repository.TxManager) IssuedDocumentService {
+	return &issuedDocumentService{repo, tx}
+}
+
+// Issue returns the active document of this type for the santri, creating one with a fresh token if there is none.
+// Downloading the same document again therefore keeps printing the same QR code until it is revoked.
+func (s *issuedDocumentService) Issue(ctx context.Context, santri *models.Santri, docType models.IssuedDocumentType) (*models.IssuedDocument, error) {
+	existing, err := s.repo.FindActive(ctx, santri.ID, docType)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, utils.ErrNotFound) {
+		return nil, err
+	}
+
+	token, err := newDocumentToken([]byte(config.AppConfig.DocumentSigningSecret))
+	if err != nil {
+		return nil, err
+	}
+
+	document := &models.IssuedDocument{
+		SantriID:     santri.ID,
+		DocumentType: docType,
+		Token:        token,
+	}
+	if err := s.repo.Create(ctx, document); err != nil {
+		// A concurrent request issued the document first
+		if errors.Is(err, utils.ErrConflict) {
+			return s.repo.FindActive(ctx, santri.ID, docType)
+		}
+		return nil, err
+	}
+
+	return document, nil
+}
+
+// VerificationURL is the public URL encoded in the document's QR code
+func (s *issuedDocumentService) VerificationURL(document *models.IssuedDocument) string {
+	return strings.TrimRight(config.AppConfig.PublicAPIURL, "/") + "/verify/" + document.Token
+}
+
+func (s *issuedDocumentService) Verify(ctx context.Context, token string) (*dto.DocumentVerificationResponse, error) {
+	// Reject forged tokens before touching the database
+	if !verifyDocumentToken([]byte(config.AppConfig.DocumentSigningSecret), token) {
+		return nil, ErrDocumentNotFound
+	}
+
+	document, err := s.repo.FindByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return nil, ErrDocumentNotFound
+		}
+		return nil, err
+	}
+
+	result := &dto.DocumentVerificationResponse{
+		Valid:        !document.IsRevoked(),
+		Status:       "VALID",
+		DocumentType: string(document.DocumentType),
+		IssuedAt:     document.CreatedAt,
+		RevokedAt:    document.RevokedAt,
+	}
+	if document.IsRevoked() {
+		result.Status = "REVOKED"
+	}
+
+	// The santri record was deleted after the document was issued
+	if document.Santri == nil {
+		result.Valid = false
+		result.Status = "REVOKED"
+		return result, nil
+	}
+
+	result.FullName = document.Santri.FullName
+	if document.Santri.NIS != nil {
+		result.NIS = *document.Santri.NIS
+	}
+
+	return result, nil
+}
+
+func (s *issuedDocumentService) GetBySantri(ctx context.Context, santriID uint) ([]models.IssuedDocument, error) {
+	return s.repo.FindBySantriID(ctx, santriID)
+}
+
+func (s *issuedDocumentService) Revoke(ctx context.Context, id uint, reason string, actorID uint) (*models.IssuedDocument, error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Revoke(ctx, id, actorID, reason); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionRevoke, "issued_document", &id, nil, map[string]string{"reason": reason})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByID(ctx, id)
+}
+
+// newDocumentToken returns "<nonce>.<signature>", where the signature is an HMAC-SHA256 of the random nonce
+func newDocumentToken(secret []byte) (string, error) {
+	nonce, err := utils.GenerateRandomCode(documentTokenNonceLength)
+	if err != nil {
+		return "", err
+	}
+	return nonce + "." + signDocumentNonce(secret, nonce), nil
+}
+
+// verifyDocumentToken checks the token signature in constant time
+func verifyDocumentToken(secret []byte, token string) bool {
+	nonce, signature, ok := strings.Cut(token, ".")
+	if !ok || len(nonce) != documentTokenNonceLength {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(signDocumentNonce(secret, nonce)))
+}
+
+func signDocumentNonce(secret []byte, nonce string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(nonce))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
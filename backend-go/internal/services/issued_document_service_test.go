@@ -0,0 +1,141 @@
+package services_test
+
+import (
+	"backend-go/config"
+	"backend-go/internal/models"
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Manual Mock for IssuedDocumentRepository
+type mockIssuedDocumentRepository struct {
+	documents []*models.IssuedDocument
+	lookups   int
+}
+
+func (m *mockIssuedDocumentRepository) Create(ctx context.Context, document *models.IssuedDocument) error {
+	document.ID = uint(len(m.documents) + 1)
+	document.CreatedAt = time.Now()
+	m.documents = append(m.documents, document)
+	return nil
+}
+
+func (m *mockIssuedDocumentRepository) FindActive(ctx context.Context, santriID uint, docType models.IssuedDocumentType) (*models.IssuedDocument, error) {
+	for _, d := range m.documents {
+		if d.SantriID == santriID && d.DocumentType == docType && !d.IsRevoked() {
+			return d, nil
+		}
+	}
+	return nil, utils.ErrNotFound
+}
+
+func (m *mockIssuedDocumentRepository) FindByToken(ctx context.Context, token string) (*models.IssuedDocument, error) {
+	m.lookups++
+	for _, d := range m.documents {
+		if d.Token == token {
+			return d, nil
+		}
+	}
+	return nil, utils.ErrNotFound
+}
+
+func (m *mockIssuedDocumentRepository) FindByID(ctx context.Context, id uint) (*models.IssuedDocument, error) {
+	for _, d := range m.documents {
+		if d.ID == id {
+			return d, nil
+		}
+	}
+	return nil, utils.ErrNotFound
+}
+
+func (m *mockIssuedDocumentRepository) FindBySantriID(ctx context.Context, santriID uint) ([]models.IssuedDocument, error) {
+	return nil, nil
+}
+
+func (m *mockIssuedDocumentRepository) Revoke(ctx context.Context, id uint, revokedBy uint, reason string) error {
+	d, err := m.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	d.RevokedAt = &now
+	d.RevokedBy = &revokedBy
+	d.RevokeReason = reason
+	return nil
+}
+
+func TestIssuedDocumentService_IssueAndVerify(t *testing.T) {
+	config.AppConfig.DocumentSigningSecret = "test_document_signing_secret_32_chars"
+	repo := &mockIssuedDocumentRepository{}
+	svc := services.NewIssuedDocumentService(repo, mockTxManager{})
+	ctx := context.Background()
+
+	nis := "2026L0001"
+	santri := &models.Santri{ID: 7, FullName: "Ahmad Fauzi", NIS: &nis}
+
+	document, err := svc.Issue(ctx, santri, models.IssuedAcceptanceLetter)
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	document.Santri = santri
+
+	again, err := svc.Issue(ctx, santri, models.IssuedAcceptanceLetter)
+	if err != nil {
+		t.Fatalf("second Issue failed: %v", err)
+	}
+	if again.Token != document.Token {
+		t.Error("expected the active document to be reused")
+	}
+
+	result, err := svc.Verify(ctx, document.Token)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if !result.Valid || result.FullName != "Ahmad Fauzi" || result.NIS != nis {
+		t.Errorf("unexpected verification result: %+v", result)
+	}
+
+	if _, err := svc.Revoke(ctx, document.ID, "Santri withdrew", 1); err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	result, err = svc.Verify(ctx, document.Token)
+	if err != nil {
+		t.Fatalf("Verify after revoke failed: %v", err)
+	}
+	if result.Valid || result.Status != "REVOKED" {
+		t.Errorf("expected revoked document, got %+v", result)
+	}
+}
+
+func TestIssuedDocumentService_VerifyRejectsForgedToken(t *testing.T) {
+	config.AppConfig.DocumentSigningSecret = "test_document_signing_secret_32_chars"
+	repo := &mockIssuedDocumentRepository{}
+	svc := services.NewIssuedDocumentService(repo, mockTxManager{})
+	ctx := context.Background()
+
+	document, err := svc.Issue(ctx, &models.Santri{ID: 1}, models.IssuedRegistrationCard)
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	nonce, _, _ := strings.Cut(document.Token, ".")
+
+	forged := []string{
+		"",
+		nonce,
+		nonce + ".not-a-signature",
+		"AAAAAAAAAAAAAAAA" + strings.TrimPrefix(document.Token, nonce),
+	}
+	for _, token := range forged {
+		if _, err := svc.Verify(ctx, token); !errors.Is(err, services.ErrDocumentNotFound) {
+			t.Errorf("token %q: expected ErrDocumentNotFound, got %v", token, err)
+		}
+	}
+	if repo.lookups != 0 {
+		t.Errorf("forged tokens should not reach the repository, got %d lookups", repo.lookups)
+	}
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"backend-go/config"
+	"backend-go/internal/logger"
+	"backend-go/internal/models"
+	"backend-go/internal/utils"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Failed logins are counted in Redis per username and per IP address, so the limits hold across restarts and
+// replicas. After a few failures each attempt has to wait longer than the last, and too many lock the account.
+// Unknown usernames are counted and locked like real ones, so the responses do not reveal which accounts exist.
+const (
+	LoginFailuresPrefix = "auth:login:failures:" // failed attempts within the lockout window, by user: or ip:
+	LoginDelayPrefix    = "auth:login:delay:"    // present while the next attempt for a username has to wait
+	LoginLockPrefix     = "auth:login:lock:"     // present while a username is locked
+
+	loginFreeAttempts = 2
+	loginMaxDelay     = 30 * time.Second
+)
+
+// Defaults for when the limits are not configured
+const (
+	defaultLoginMaxAttempts      = 5
+	defaultLoginMaxAttemptsPerIP = 50
+	defaultLoginLockout          = 15 * time.Minute
+)
+
+// dummyPasswordHash is compared against for unknown usernames, so they take as long to reject as wrong passwords
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("not-a-real-password"), bcrypt.DefaultCost)
+
+func loginLimits() (maxAttempts, maxAttemptsPerIP int, lockout time.Duration) {
+	maxAttempts, maxAttemptsPerIP, lockout = defaultLoginMaxAttempts, defaultLoginMaxAttemptsPerIP, defaultLoginLockout
+	if n := config.AppConfig.LoginMaxAttempts; n > 0 {
+		maxAttempts = n
+	}
+	if n := config.AppConfig.LoginMaxAttemptsPerIP; n > 0 {
+		maxAttemptsPerIP = n
+	}
+	if n := config.AppConfig.LoginLockoutMinutes; n > 0 {
+		lockout = time.Duration(n) * time.Minute
+	}
+	return
+}
+
+// loginUsernameKey ignores case and surrounding spaces so variants of a username share one counter
+func loginUsernameKey(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// checkLoginAllowed refuses an attempt while the username is locked or waiting, or the IP address is blocked
+func (s *authService) checkLoginAllowed(username, ipAddress string) error {
+	_, maxAttemptsPerIP, lockout := loginLimits()
+	key := loginUsernameKey(username)
+
+	var locked bool
+	if err := s.cache.Get(LoginLockPrefix+key, &locked); err == nil && locked {
+		return errLoginLocked(lockout)
+	}
+
+	if ipAddress != "" {
+		var ipFailures int64
+		if err := s.cache.Get(LoginFailuresPrefix+"ip:"+ipAddress, &ipFailures); err == nil && ipFailures >= int64(maxAttemptsPerIP) {
+			return errLoginLocked(lockout)
+		}
+	}
+
+	var waiting bool
+	if err := s.cache.Get(LoginDelayPrefix+key, &waiting); err == nil && waiting {
+		return errLoginDelayed
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed attempt. The user is nil for unknown usernames.
+func (s *authService) recordLoginFailure(ctx context.Context, username string, user *models.User, client ClientInfo) {
+	maxAttempts, _, lockout := loginLimits()
+	key := loginUsernameKey(username)
+
+	if client.IPAddress != "" {
+		if _, err := s.cache.Increment(LoginFailuresPrefix+"ip:"+client.IPAddress, lockout); err != nil {
+			logger.Warn("Failed to count failed login by IP", zap.String("ip", client.IPAddress), zap.Error(err))
+		}
+	}
+
+	failures, err := s.cache.Increment(LoginFailuresPrefix+"user:"+key, lockout)
+	if err != nil {
+		logger.Warn("Failed to count failed login", zap.String("username", key), zap.Error(err))
+		return
+	}
+
+	if failures < int64(maxAttempts) {
+		if failures > loginFreeAttempts {
+			// 1s, 2s, 4s, ... up to loginMaxDelay
+			delay := time.Second << (failures - loginFreeAttempts - 1)
+			if delay > loginMaxDelay {
+				delay = loginMaxDelay
+			}
+			_ = s.cache.Set(LoginDelayPrefix+key, true, delay)
+		}
+		return
+	}
+
+	_ = s.cache.Set(LoginLockPrefix+key, true, lockout)
+	_ = s.cache.Delete(LoginFailuresPrefix + "user:" + key)
+	_ = s.cache.Delete(LoginDelayPrefix + key)
+
+	logger.Warn("Login locked after repeated failures",
+		zap.String("username", key),
+		zap.String("ip", client.IPAddress),
+		zap.Int64("failures", failures),
+	)
+	if user != nil {
+		LogActivityAsync(ctx, user.ID, models.ActionAccountLock, "user", &user.ID, nil, map[string]interface{}{
+			"failed_attempts": failures,
+			"locked_minutes":  int(lockout.Minutes()),
+		}, client.IPAddress, client.UserAgent)
+	}
+}
+
+// clearLoginFailures forgets the failed attempts of a username. The IP address keeps its count, so logging in to
+// one account cannot be used to keep guessing others.
+func (s *authService) clearLoginFailures(username string) {
+	key := loginUsernameKey(username)
+	_ = s.cache.Delete(LoginFailuresPrefix + "user:" + key)
+	_ = s.cache.Delete(LoginDelayPrefix + key)
+	_ = s.cache.Delete(LoginLockPrefix + key)
+}
+
+// UnlockAdmin lifts a lockout before it expires
+func (s *authService) UnlockAdmin(ctx context.Context, id uint) error {
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	s.clearLoginFailures(user.Username)
+	// The lockout only lives in Redis, so there is no transaction for the log entry to join
+	return recordActivity(ctx, models.ActionAccountUnlock, "user", &id, nil, nil)
+}
+
+var errLoginDelayed = utils.NewAppError(429, "Too many failed login attempts, please wait a moment and try again")
+
+func errLoginLocked(lockout time.Duration) error {
+	return utils.NewAppError(429, fmt.Sprintf("Too many failed login attempts, please try again in %d minutes", int(lockout.Minutes())))
+}
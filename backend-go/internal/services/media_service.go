@@ -19,8 +19,9 @@ import (
 
 // File upload constraints
 const (
-	MaxFileSize      = 5 * 1024 * 1024 // 5MB
-	AllowedMimeTypes = "image/jpeg,image/png,image/webp,image/gif"
+	MaxFileSize              = 5 * 1024 * 1024 // 5MB
+	AllowedMimeTypes         = "image/jpeg,image/png,image/webp,image/gif"
+	AllowedDocumentMimeTypes = AllowedMimeTypes + ",application/pdf"
 )
 
 // ErrFileTooLarge is returned when file exceeds size limit
@@ -29,9 +30,14 @@ var ErrFileTooLarge = errors.New("file size exceeds 5MB limit")
 // ErrInvalidFileType is returned when file type is not allowed
 var ErrInvalidFileType = errors.New("file type not allowed. Allowed types: JPEG, PNG, WebP, GIF")
 
+// ErrInvalidDocumentType is returned when a document is neither an allowed image nor a PDF
+var ErrInvalidDocumentType = errors.New("file type not allowed. Allowed types: JPEG, PNG, WebP, GIF, PDF")
+
 type MediaService interface {
 	UploadImage(ctx context.Context, file multipart.File, header *multipart.FileHeader, folder string) (string, error)
 	ValidateFile(file multipart.File, header *multipart.FileHeader) error
+	UploadDocument(ctx context.Context, file multipart.File, header *multipart.FileHeader, folder string) (string, error)
+	ValidateDocument(file multipart.File, header *multipart.FileHeader) (string, error)
 	DeleteImage(ctx context.Context, publicID string) error
 	DeleteImageByURL(ctx context.Context, imageURL string) error
 	GetUsageStats(ctx context.Context) (*CloudinaryUsage, error)
@@ -67,34 +73,56 @@ func NewMediaService() (MediaService, error) {
 
 // ValidateFile checks file size and MIME type before upload
 func (s *mediaService) ValidateFile(file multipart.File, header *multipart.FileHeader) error {
+	mimeType, err := detectUploadType(file, header)
+	if err != nil {
+		return err
+	}
+
+	// Check if MIME type is allowed
+	if !strings.Contains(AllowedMimeTypes, mimeType) {
+		return fmt.Errorf("%w: got %s", ErrInvalidFileType, mimeType)
+	}
+
+	return nil
+}
+
+// ValidateDocument checks file size and MIME type of a document (image or PDF) and returns the detected type
+func (s *mediaService) ValidateDocument(file multipart.File, header *multipart.FileHeader) (string, error) {
+	mimeType, err := detectUploadType(file, header)
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.Contains(AllowedDocumentMimeTypes, mimeType) {
+		return "", fmt.Errorf("%w: got %s", ErrInvalidDocumentType, mimeType)
+	}
+
+	return mimeType, nil
+}
+
+// detectUploadType checks the file size and detects the MIME type from the file content
+func detectUploadType(file multipart.File, header *multipart.FileHeader) (string, error) {
 	// Check file size
 	if header.Size > MaxFileSize {
-		return fmt.Errorf("%w: got %d bytes, max %d bytes", ErrFileTooLarge, header.Size, MaxFileSize)
+		return "", fmt.Errorf("%w: got %d bytes, max %d bytes", ErrFileTooLarge, header.Size, MaxFileSize)
 	}
 
 	// Read first 512 bytes to detect MIME type
 	buff := make([]byte, 512)
 	_, err := file.Read(buff)
 	if err != nil && err != io.EOF {
-		return fmt.Errorf("failed to read file: %w", err)
+		return "", fmt.Errorf("failed to read file: %w", err)
 	}
 
 	// Reset file reader position
 	if seeker, ok := file.(io.Seeker); ok {
 		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
-			return fmt.Errorf("failed to reset file reader: %w", err)
+			return "", fmt.Errorf("failed to reset file reader: %w", err)
 		}
 	}
 
 	// Detect actual MIME type from file content
-	mimeType := http.DetectContentType(buff)
-
-	// Check if MIME type is allowed
-	if !strings.Contains(AllowedMimeTypes, mimeType) {
-		return fmt.Errorf("%w: got %s", ErrInvalidFileType, mimeType)
-	}
-
-	return nil
+	return http.DetectContentType(buff), nil
 }
 
 // UploadImage validates and uploads an image to Cloudinary
@@ -116,6 +144,27 @@ func (s *mediaService) UploadImage(ctx context.Context, file multipart.File, hea
 	return uploadResult.SecureURL, nil
 }
 
+// UploadDocument validates and uploads a document (image or PDF) to Cloudinary.
+// Images are size-limited like UploadImage, PDFs are stored as-is.
+func (s *mediaService) UploadDocument(ctx context.Context, file multipart.File, header *multipart.FileHeader, folder string) (string, error) {
+	mimeType, err := s.ValidateDocument(file, header)
+	if err != nil {
+		return "", err
+	}
+
+	params := uploader.UploadParams{Folder: folder}
+	if mimeType != "application/pdf" {
+		params.Transformation = "c_limit,w_1920,h_1080,q_auto"
+	}
+
+	uploadResult, err := s.cld.Upload.Upload(ctx, file, params)
+	if err != nil {
+		return "", fmt.Errorf("cloudinary upload failed: %w", err)
+	}
+
+	return uploadResult.SecureURL, nil
+}
+
 // DeleteImage deletes an image from Cloudinary by its public ID
 func (s *mediaService) DeleteImage(ctx context.Context, publicID string) error {
 	if publicID == "" {
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Notification events
+const (
+	EventPSBRegistered       = "psb.registered"
+	EventPSBStatusUpdated    = "psb.status_updated"
+	EventTestSessionReminder = "psb.test_session_reminder"
+	EventAdminWelcome        = "admin.welcome"
+	EventPasswordReset       = "auth.password_reset"
+)
+
+type NotificationService interface {
+	Notify(ctx context.Context, santri *models.Santri, event string, data interface{}) error
+	NotifyEmail(ctx context.Context, to, event, locale string, data interface{}) error
+	NotifyPasswordLink(ctx context.Context, user *models.User, event, locale string) error
+	Deliver(ctx context.Context, job NotificationJob) error
+	GetDeliveries(ctx context.Context, santriID uint) ([]models.NotificationDelivery, error)
+}
+
+// NotificationJob is the outbox payload of a delivery. Messages with a password link carry PasswordLink instead of
+// a rendered Message, so the link only exists once the message is sent.
+type NotificationJob struct {
+	DeliveryID   uint          `json:"delivery_id"`
+	Message      Message       `json:"message"`
+	PasswordLink *PasswordLink `json:"password_link,omitempty"`
+}
+
+type notificationService struct {
+	repo      repository.NotificationDeliveryRepository
+	outbox    OutboxService
+	templates NotificationTemplateService
+	cache     CacheService
+	notifiers map[models.NotificationChannel]Notifier
+}
+
+func NewNotificationService(repo repository.NotificationDeliveryRepository, outbox OutboxService, templates NotificationTemplateService, cache CacheService, notifiers []Notifier) NotificationService {
+	byChannel := make(map[models.NotificationChannel]Notifier, len(notifiers))
+	for _, n := range notifiers {
+		byChannel[n.Channel()] = n
+	}
+	return &notificationService{repo, outbox, templates, cache, byChannel}
+}
+
+// Notify renders the event's template in the registrant's locale, records a delivery for every channel the parent
+// can be reached on and queues it in the outbox. Call it with a TxManager context so nothing is sent unless the
+// change that caused it commits.
+func (s *notificationService) Notify(ctx context.Context, santri *models.Santri, event string, data interface{}) error {
+	deliveries := s.recipients(santri)
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	msg, err := s.templates.Render(ctx, event, santri.Locale, data)
+	if err != nil {
+		return err
+	}
+	for _, delivery := range deliveries {
+		if err := s.queue(ctx, delivery, event, NotificationJob{Message: msg}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NotifyEmail sends the event's template to an email address that does not belong to a registrant, such as an admin
+func (s *notificationService) NotifyEmail(ctx context.Context, to, event, locale string, data interface{}) error {
+	msg, err := s.templates.Render(ctx, event, locale, data)
+	if err != nil {
+		return err
+	}
+	return s.queue(ctx, &models.NotificationDelivery{Channel: models.ChannelEmail, Recipient: to}, event, NotificationJob{Message: msg})
+}
+
+// NotifyPasswordLink emails an admin the event's template with a link to choose a password. Only the user is queued;
+// the link is created and the template rendered when the email is sent, so the outbox never holds the link.
+func (s *notificationService) NotifyPasswordLink(ctx context.Context, user *models.User, event, locale string) error {
+	if _, ok := passwordLinkExpiry[event]; !ok {
+		return fmt.Errorf("event %s has no password link", event)
+	}
+	link := &PasswordLink{UserID: user.ID, Username: user.Username, Event: event, Locale: locale}
+	return s.queue(ctx, &models.NotificationDelivery{Channel: models.ChannelEmail, Recipient: user.Email}, event, NotificationJob{PasswordLink: link})
+}
+
+func (s *notificationService) queue(ctx context.Context, delivery *models.NotificationDelivery, event string, job NotificationJob) error {
+	delivery.Event = event
+	delivery.Status = models.DeliveryPending
+	if err := s.repo.Create(ctx, delivery); err != nil {
+		return err
+	}
+	job.DeliveryID = delivery.ID
+	return s.outbox.Enqueue(ctx, JobNotification, job)
+}
+
+// Deliver sends a queued delivery and records the outcome. It is called by the outbox worker, which retries on error.
+// A delivery on a channel that is not configured is marked skipped and not retried.
+func (s *notificationService) Deliver(ctx context.Context, job NotificationJob) error {
+	delivery, err := s.repo.FindByID(ctx, job.DeliveryID)
+	if err != nil {
+		return err
+	}
+	// A retry after the status update failed must not send the message twice
+	if delivery.Status == models.DeliverySent {
+		return nil
+	}
+
+	notifier, ok := s.notifiers[delivery.Channel]
+	if !ok {
+		return fmt.Errorf("%w: no notifier for channel %s", errPermanent, delivery.Channel)
+	}
+
+	msg := job.Message
+	if job.PasswordLink != nil {
+		if msg, err = s.renderPasswordLink(ctx, *job.PasswordLink); err != nil {
+			return err
+		}
+	}
+
+	if err := notifier.Send(ctx, delivery.Recipient, msg); err != nil {
+		if errors.Is(err, ErrChannelNotConfigured) {
+			return s.repo.MarkSkipped(ctx, delivery.ID, err.Error())
+		}
+		if markErr := s.repo.MarkFailed(ctx, delivery.ID, err.Error()); markErr != nil {
+			return markErr
+		}
+		return err
+	}
+	return s.repo.MarkSent(ctx, delivery.ID, time.Now())
+}
+
+// renderPasswordLink creates the link of a queued password link message and renders its template. Each attempt
+// creates a new link, which replaces the one of a failed earlier attempt.
+func (s *notificationService) renderPasswordLink(ctx context.Context, link PasswordLink) (Message, error) {
+	expiry := passwordLinkExpiry[link.Event]
+	linkURL, err := issuePasswordLink(s.cache, link.UserID, expiry)
+	if err != nil {
+		return Message{}, err
+	}
+	return s.templates.Render(ctx, link.Event, link.Locale, passwordLinkData(link, linkURL, expiry))
+}
+
+func (s *notificationService) GetDeliveries(ctx context.Context, santriID uint) ([]models.NotificationDelivery, error) {
+	return s.repo.FindBySantri(ctx, santriID)
+}
+
+// recipients picks the channels from the contact fields the registrant has. A phone number is reached on WhatsApp,
+// falling back to SMS only when just the SMS gateway is configured.
+func (s *notificationService) recipients(santri *models.Santri) []*models.NotificationDelivery {
+	var deliveries []*models.NotificationDelivery
+	santriID := santri.ID
+
+	if santri.ParentEmail != "" {
+		deliveries = append(deliveries, &models.NotificationDelivery{SantriID: &santriID, Channel: models.ChannelEmail, Recipient: santri.ParentEmail})
+	}
+	if santri.ParentPhone != "" {
+		channel := models.ChannelWhatsApp
+		if !s.configured(models.ChannelWhatsApp) && s.configured(models.ChannelSMS) {
+			channel = models.ChannelSMS
+		}
+		deliveries = append(deliveries, &models.NotificationDelivery{SantriID: &santriID, Channel: channel, Recipient: santri.ParentPhone})
+	}
+
+	return deliveries
+}
+
+func (s *notificationService) configured(channel models.NotificationChannel) bool {
+	n, ok := s.notifiers[channel]
+	if !ok {
+		return false
+	}
+	_, noop := n.(*noopNotifier)
+	return !noop
+}
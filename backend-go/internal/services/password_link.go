@@ -0,0 +1,76 @@
+package services
+
+import (
+	"backend-go/config"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Password links let an admin choose a password: the reset link from ForgotPassword and the link in the welcome email
+// of a new admin. Tokens are stored hashed, mapped to the user ID, and are removed when used. A link is only created
+// when its email is sent, so it never sits in the outbox.
+const (
+	PasswordResetExpiry     = time.Hour
+	PasswordSetupExpiry     = 72 * time.Hour
+	PasswordResetPrefix     = "password:reset:"
+	PasswordResetUserPrefix = "password:reset:user:" // latest token hash per user, so a new link invalidates the old one
+)
+
+// passwordLinkExpiry is how long the link of each event with a password link stays valid
+var passwordLinkExpiry = map[string]time.Duration{
+	EventPasswordReset: PasswordResetExpiry,
+	EventAdminWelcome:  PasswordSetupExpiry,
+}
+
+// PasswordLink asks for a password link to be created for a user when the message is sent
+type PasswordLink struct {
+	UserID   uint   `json:"user_id"`
+	Username string `json:"username"`
+	Event    string `json:"event"`
+	Locale   string `json:"locale"`
+}
+
+// issuePasswordLink stores a new token for the user, replacing the previous one, and returns the link to the page
+// where the password is chosen
+func issuePasswordLink(cache CacheService, userID uint, expiry time.Duration) (string, error) {
+	token, err := generateRandomToken()
+	if err != nil {
+		return "", err
+	}
+	tokenHash := hashToken(token)
+
+	userKey := fmt.Sprintf("%s%d", PasswordResetUserPrefix, userID)
+	var previous string
+	if err := cache.Get(userKey, &previous); err == nil && previous != "" {
+		_ = cache.Delete(PasswordResetPrefix + previous)
+	}
+	if err := cache.Set(PasswordResetPrefix+tokenHash, userID, expiry); err != nil {
+		return "", err
+	}
+	if err := cache.Set(userKey, tokenHash, expiry); err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(config.AppConfig.FrontendURL, "/") + "/reset-password?token=" + url.QueryEscape(token), nil
+}
+
+// consumePasswordLink returns the user a token was issued for and removes it, so it works only once. It returns 0
+// for unknown or expired tokens.
+func consumePasswordLink(cache CacheService, token string) uint {
+	var userID uint
+	if err := cache.GetDel(PasswordResetPrefix+hashToken(token), &userID); err != nil {
+		return 0
+	}
+	_ = cache.Delete(fmt.Sprintf("%s%d", PasswordResetUserPrefix, userID))
+	return userID
+}
+
+// passwordLinkData is the template data of an event with a password link
+func passwordLinkData(link PasswordLink, linkURL string, expiry time.Duration) interface{} {
+	if link.Event == EventAdminWelcome {
+		return AdminWelcomeData{Username: link.Username, SetPasswordURL: linkURL, ExpiresInHours: int(expiry.Hours())}
+	}
+	return PasswordResetData{Username: link.Username, ResetURL: linkURL, ExpiresInMinutes: int(expiry.Minutes())}
+}
@@ -0,0 +1,360 @@
+package services
+
+import (
+	"backend-go/config"
+	"backend-go/internal/models"
+	"backend-go/internal/utils"
+	"backend-go/templates"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/skip2/go-qrcode"
+)
+
+// maxPhotoSize caps how much of a registrant photo is downloaded for the registration card
+const maxPhotoSize = 5 * 1024 * 1024
+
+// Letterhead is the institution header printed on every generated document (templates/psb/letterhead.json)
+type Letterhead struct {
+	Institution    string `json:"institution"`
+	Tagline        string `json:"tagline"`
+	Address        string `json:"address"`
+	Contact        string `json:"contact"`
+	City           string `json:"city"`
+	SignatoryName  string `json:"signatory_name"`
+	SignatoryTitle string `json:"signatory_title"`
+}
+
+type PDFService interface {
+	GenerateRegistrationCard(ctx context.Context, santri *models.Santri) (*bytes.Buffer, error)
+	GenerateAcceptanceLetter(ctx context.Context, santri *models.Santri) (*bytes.Buffer, error)
+}
+
+type pdfService struct {
+	issued     IssuedDocumentService
+	letterhead Letterhead
+	texts      *template.Template
+	httpClient *http.Client
+}
+
+func NewPDFService(issued IssuedDocumentService) (PDFService, error) {
+	raw, err := templates.TemplateFS.ReadFile("psb/letterhead.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read letterhead template: %w", err)
+	}
+
+	var letterhead Letterhead
+	if err := json.Unmarshal(raw, &letterhead); err != nil {
+		return nil, fmt.Errorf("failed to parse letterhead template: %w", err)
+	}
+
+	texts, err := template.ParseFS(templates.TemplateFS, "psb/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PDF templates: %w", err)
+	}
+
+	return &pdfService{
+		issued:     issued,
+		letterhead: letterhead,
+		texts:      texts,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+			// A redirect could lead away from the media host that fetchPhoto checked
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+	}, nil
+}
+
+// documentData is what the text templates can reference
+type documentData struct {
+	Institution      string
+	RegistrationCode string
+	FullName         string
+	AcademicYear     string
+	NIS              string
+	Class            string
+	EntryYear        int
+}
+
+func (s *pdfService) newDocumentData(santri *models.Santri) documentData {
+	data := documentData{
+		Institution:      s.letterhead.Institution,
+		RegistrationCode: santri.RegistrationCode,
+		FullName:         santri.FullName,
+		EntryYear:        santri.EntryYear,
+	}
+	if santri.Wave != nil {
+		data.AcademicYear = santri.Wave.AcademicYear
+	}
+	if santri.NIS != nil {
+		data.NIS = *santri.NIS
+	}
+	if santri.Class != nil {
+		data.Class = *santri.Class
+	}
+	return data
+}
+
+// GenerateRegistrationCard renders an A5 landscape card with the registrant's photo and a verification QR code
+func (s *pdfService) GenerateRegistrationCard(ctx context.Context, santri *models.Santri) (*bytes.Buffer, error) {
+	data := s.newDocumentData(santri)
+
+	document, err := s.issued.Issue(ctx, santri, models.IssuedRegistrationCard)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := gofpdf.New("L", "mm", "A5", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetMargins(12, 10, 12)
+	pdf.SetAutoPageBreak(false, 10)
+	pdf.AddPage()
+
+	s.drawLetterhead(pdf, tr)
+
+	pdf.SetFont("Helvetica", "B", 13)
+	pdf.CellFormat(0, 8, tr(s.render("card_title", data)), "", 1, "C", false, 0, "")
+	pdf.Ln(3)
+
+	// Photo (left), details (middle), QR code (right)
+	top := pdf.GetY()
+	s.drawPhoto(ctx, pdf, santri.PhotoURL, 12, top, 30, 40)
+
+	if err := s.drawVerificationQR(pdf, document, 158, top, 38); err != nil {
+		return nil, err
+	}
+
+	waveLabel := "-"
+	if santri.Wave != nil {
+		waveLabel = fmt.Sprintf("%s (%s)", santri.Wave.Name, santri.Wave.AcademicYear)
+	}
+	genderLabel := "Laki-laki"
+	if santri.Gender == "P" {
+		genderLabel = "Perempuan"
+	}
+
+	pdf.SetXY(48, top)
+	s.drawFields(pdf, tr, 48, 34, [][2]string{
+		{"No. Registrasi", santri.RegistrationCode},
+		{"Nama Lengkap", santri.FullName},
+		{"Jenis Kelamin", genderLabel},
+		{"Tempat, Tgl Lahir", fmt.Sprintf("%s, %s", santri.BirthPlace, formatIndonesianDate(santri.BirthDate))},
+		{"Gelombang", waveLabel},
+		{"Tanggal Daftar", formatIndonesianDate(santri.CreatedAt)},
+	})
+
+	pdf.SetXY(12, top+46)
+	pdf.SetFont("Helvetica", "I", 8)
+	pdf.MultiCell(0, 4, tr(s.render("card_notes", data)), "", "L", false)
+
+	return outputPDF(pdf)
+}
+
+// GenerateAcceptanceLetter renders the official A4 acceptance letter. Only accepted santri with a NIS have one.
+func (s *pdfService) GenerateAcceptanceLetter(ctx context.Context, santri *models.Santri) (*bytes.Buffer, error) {
+	if santri.Status != models.StatusAccepted || santri.NIS == nil {
+		return nil, utils.NewAppError(400, "Acceptance letter is only available for accepted santri")
+	}
+	data := s.newDocumentData(santri)
+
+	document, err := s.issued.Issue(ctx, santri, models.IssuedAcceptanceLetter)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetMargins(20, 15, 20)
+	pdf.AddPage()
+
+	s.drawLetterhead(pdf, tr)
+
+	pdf.SetFont("Helvetica", "BU", 13)
+	pdf.CellFormat(0, 7, tr(s.render("letter_title", data)), "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 5, tr(s.render("letter_number", data)), "", 1, "C", false, 0, "")
+	pdf.Ln(8)
+
+	pdf.SetFont("Helvetica", "", 11)
+	pdf.MultiCell(0, 6, tr(s.render("letter_opening", data)), "", "J", false)
+	pdf.Ln(3)
+
+	s.drawFields(pdf, tr, 30, 45, [][2]string{
+		{"Nama Lengkap", santri.FullName},
+		{"Tempat, Tgl Lahir", fmt.Sprintf("%s, %s", santri.BirthPlace, formatIndonesianDate(santri.BirthDate))},
+		{"No. Registrasi", santri.RegistrationCode},
+		{"NIS", data.NIS},
+		{"Kelas", data.Class},
+		{"Tahun Masuk", fmt.Sprintf("%d", santri.EntryYear)},
+	})
+	pdf.Ln(3)
+
+	pdf.SetFont("Helvetica", "", 11)
+	pdf.MultiCell(0, 6, tr(s.render("letter_closing", data)), "", "J", false)
+	pdf.Ln(12)
+
+	// Verification QR code (left) and signature block (right)
+	if err := s.drawVerificationQR(pdf, document, 20, pdf.GetY(), 30); err != nil {
+		return nil, err
+	}
+
+	signX := 120.0
+	pdf.SetX(signX)
+	pdf.CellFormat(0, 6, tr(fmt.Sprintf("%s, %s", s.letterhead.City, formatIndonesianDate(time.Now()))), "", 1, "L", false, 0, "")
+	pdf.SetX(signX)
+	pdf.CellFormat(0, 6, tr(s.letterhead.SignatoryTitle), "", 1, "L", false, 0, "")
+	pdf.Ln(20)
+	pdf.SetX(signX)
+	pdf.SetFont("Helvetica", "BU", 11)
+	pdf.CellFormat(0, 6, tr(s.letterhead.SignatoryName), "", 1, "L", false, 0, "")
+
+	return outputPDF(pdf)
+}
+
+// drawLetterhead prints the institution header followed by a double rule
+func (s *pdfService) drawLetterhead(pdf *gofpdf.Fpdf, tr func(string) string) {
+	pdf.SetFont("Helvetica", "B", 15)
+	pdf.CellFormat(0, 7, tr(strings.ToUpper(s.letterhead.Institution)), "", 1, "C", false, 0, "")
+	if s.letterhead.Tagline != "" {
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(0, 5, tr(s.letterhead.Tagline), "", 1, "C", false, 0, "")
+	}
+	pdf.SetFont("Helvetica", "", 8)
+	if s.letterhead.Address != "" {
+		pdf.MultiCell(0, 4, tr(s.letterhead.Address), "", "C", false)
+	}
+	if s.letterhead.Contact != "" {
+		pdf.CellFormat(0, 4, tr(s.letterhead.Contact), "", 1, "C", false, 0, "")
+	}
+
+	left, _, right, _ := pdf.GetMargins()
+	width, _ := pdf.GetPageSize()
+	y := pdf.GetY() + 1.5
+	pdf.SetLineWidth(0.6)
+	pdf.Line(left, y, width-right, y)
+	pdf.SetLineWidth(0.2)
+	pdf.Line(left, y+1, width-right, y+1)
+	pdf.SetY(y + 4)
+}
+
+// drawVerificationQR places a QR code of the document's verification URL with a caption below it
+func (s *pdfService) drawVerificationQR(pdf *gofpdf.Fpdf, document *models.IssuedDocument, x, y, size float64) error {
+	qr, err := qrcode.Encode(s.issued.VerificationURL(document), qrcode.Medium, 256)
+	if err != nil {
+		return fmt.Errorf("failed to generate QR code: %w", err)
+	}
+
+	options := gofpdf.ImageOptions{ImageType: "PNG"}
+	pdf.RegisterImageOptionsReader("qr", options, bytes.NewReader(qr))
+	pdf.ImageOptions("qr", x, y, size, size, false, options, 0, "")
+
+	// Restore the cursor so the caller's layout is unaffected
+	curX, curY := pdf.GetXY()
+	pdf.SetXY(x, y+size)
+	pdf.SetFont("Helvetica", "", 6)
+	pdf.CellFormat(size, 3, "Pindai untuk verifikasi", "", 0, "C", false, 0, "")
+	pdf.SetXY(curX, curY)
+	return nil
+}
+
+// drawFields prints label/value rows starting at x, with labels labelWidth wide
+func (s *pdfService) drawFields(pdf *gofpdf.Fpdf, tr func(string) string, x, labelWidth float64, fields [][2]string) {
+	for _, field := range fields {
+		pdf.SetX(x)
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(labelWidth, 6, tr(field[0]), "", 0, "L", false, 0, "")
+		pdf.CellFormat(4, 6, ":", "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(0, 6, tr(field[1]), "", 1, "L", false, 0, "")
+	}
+}
+
+// drawPhoto places the registrant photo in the given box, or an empty frame when it cannot be loaded
+func (s *pdfService) drawPhoto(ctx context.Context, pdf *gofpdf.Fpdf, photoURL string, x, y, w, h float64) {
+	if imageType, photo := s.fetchPhoto(ctx, photoURL); photo != nil {
+		options := gofpdf.ImageOptions{ImageType: imageType}
+		pdf.RegisterImageOptionsReader("photo", options, bytes.NewReader(photo))
+		if pdf.Ok() {
+			pdf.ImageOptions("photo", x, y, w, h, false, options, 0, "")
+			return
+		}
+		pdf.ClearError()
+	}
+
+	pdf.Rect(x, y, w, h, "D")
+	pdf.SetXY(x, y+h/2-3)
+	pdf.SetFont("Helvetica", "", 8)
+	pdf.CellFormat(w, 6, "Pas Foto 3x4", "", 0, "C", false, 0, "")
+}
+
+// fetchPhoto downloads a photo in a format gofpdf can embed (JPEG, PNG, GIF). The photo URL comes from the public
+// registration form, so only photos uploaded to our Cloudinary account are fetched.
+func (s *pdfService) fetchPhoto(ctx context.Context, photoURL string) (string, []byte) {
+	if !utils.IsMediaURL(photoURL, config.AppConfig.CloudinaryURL) {
+		return "", nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, photoURL, nil)
+	if err != nil {
+		return "", nil
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", nil
+	}
+
+	photo, err := io.ReadAll(io.LimitReader(resp.Body, maxPhotoSize))
+	if err != nil {
+		return "", nil
+	}
+
+	switch http.DetectContentType(photo) {
+	case "image/jpeg":
+		return "JPG", photo
+	case "image/png":
+		return "PNG", photo
+	case "image/gif":
+		return "GIF", photo
+	default:
+		return "", nil
+	}
+}
+
+// render executes one of the named text templates, falling back to an empty string on error
+func (s *pdfService) render(name string, data documentData) string {
+	var buf bytes.Buffer
+	if err := s.texts.ExecuteTemplate(&buf, name, data); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+func outputPDF(pdf *gofpdf.Fpdf) (*bytes.Buffer, error) {
+	buf := new(bytes.Buffer)
+	if err := pdf.Output(buf); err != nil {
+		return nil, fmt.Errorf("failed to render PDF: %w", err)
+	}
+	return buf, nil
+}
+
+var indonesianMonths = [...]string{
+	"Januari", "Februari", "Maret", "April", "Mei", "Juni",
+	"Juli", "Agustus", "September", "Oktober", "November", "Desember",
+}
+
+// formatIndonesianDate formats a date as "2 Januari 2006"
+func formatIndonesianDate(t time.Time) string {
+	return fmt.Sprintf("%d %s %d", t.Day(), indonesianMonths[t.Month()-1], t.Year())
+}
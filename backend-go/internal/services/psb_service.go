@@ -1,47 +1,92 @@
 package services
 
 import (
+	"backend-go/config"
+	"backend-go/internal/dto"
 	"backend-go/internal/models"
 	"backend-go/internal/repository"
+	"backend-go/internal/utils"
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
+// registrationCodePrefix and registrationCodeLength define codes like "PSB-7KQ2M9XA"
+const (
+	registrationCodePrefix = "PSB-"
+	registrationCodeLength = 8
+)
+
 type PSBService interface {
 	RegisterSantri(ctx context.Context, santri *models.Santri) error
-	GetAllRegistrants(ctx context.Context) ([]models.Santri, error)
-	GetRegistrantsPaginated(ctx context.Context, page, limit int, status string) ([]models.Santri, int64, error)
-	GetRegistrantsByStatus(ctx context.Context, status string) ([]models.Santri, error)
+	GetRegistrants(ctx context.Context, status string, waveID uint) ([]models.Santri, error)
+	GetRegistrantsPaginated(ctx context.Context, page, limit int, status string, waveID uint) ([]models.Santri, int64, error)
 	GetRegistrantByID(ctx context.Context, id uint) (*models.Santri, error)
 	UpdateSantri(ctx context.Context, id uint, data *models.Santri) error
 	DeleteSantri(ctx context.Context, id uint) error
-	UpdateStatus(ctx context.Context, id uint, status string) error
-	VerifySantri(ctx context.Context, id uint, nis string, class string, entryYear int) error
+	UpdateStatus(ctx context.Context, id uint, status string, actorID uint, reason string) error
+	VerifySantri(ctx context.Context, id uint, class string, entryYear int, actorID uint) (*models.Santri, error)
+	GetStatusHistory(ctx context.Context, id uint) ([]models.SantriStatusHistory, error)
+	LookupRegistration(ctx context.Context, code string, birthDate time.Time) (*models.Santri, error)
+	GetPublicStatus(ctx context.Context, code string, birthDate time.Time) (*dto.PSBStatusResponse, error)
 }
 
 type psbService struct {
-	repo repository.SantriRepository
+	repo        repository.SantriRepository
+	waveRepo    repository.AdmissionWaveRepository
+	docRepo     repository.SantriDocumentRepository
+	sessionRepo repository.TestSessionRepository
+	tx          repository.TxManager
+	notifier    NotificationService
 }
 
-func NewPSBService(repo repository.SantriRepository) PSBService {
-	return &psbService{repo}
+func NewPSBService(repo repository.SantriRepository, waveRepo repository.AdmissionWaveRepository, docRepo repository.SantriDocumentRepository, sessionRepo repository.TestSessionRepository, tx repository.TxManager, notifier NotificationService) PSBService {
+	return &psbService{repo, waveRepo, docRepo, sessionRepo, tx, notifier}
 }
 
 func (s *psbService) RegisterSantri(ctx context.Context, santri *models.Santri) error {
-	// Add business logic validation here if needed
-	return s.repo.Create(ctx, santri)
-}
+	// Registration is only accepted while an admission wave is open
+	wave, err := s.waveRepo.FindOpen(ctx, time.Now())
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return utils.NewAppError(400, "PSB registration is currently closed")
+		}
+		return err
+	}
+
+	code, err := utils.GenerateRandomCode(registrationCodeLength)
+	if err != nil {
+		return fmt.Errorf("failed to generate registration code: %w", err)
+	}
+
+	santri.RegistrationCode = registrationCodePrefix + code
+	santri.WaveID = &wave.ID
+	// The confirmation is queued in the same transaction, so it goes out exactly when the registration is stored
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateInWave(ctx, santri); err != nil {
+			return err
+		}
+		return s.notifier.Notify(ctx, santri, EventPSBRegistered, PSBRegisteredData{
+			SantriName:       santri.FullName,
+			RegistrationCode: santri.RegistrationCode,
+		})
+	})
+	if err != nil {
+		return err
+	}
 
-func (s *psbService) GetAllRegistrants(ctx context.Context) ([]models.Santri, error) {
-	return s.repo.FindAll(ctx)
+	santri.Wave = wave
+	return nil
 }
 
-func (s *psbService) GetRegistrantsPaginated(ctx context.Context, page, limit int, status string) ([]models.Santri, int64, error) {
-	return s.repo.FindAllPaginated(ctx, page, limit, status)
+func (s *psbService) GetRegistrants(ctx context.Context, status string, waveID uint) ([]models.Santri, error) {
+	return s.repo.FindFiltered(ctx, status, waveID)
 }
 
-func (s *psbService) GetRegistrantsByStatus(ctx context.Context, status string) ([]models.Santri, error) {
-	return s.repo.FindByStatus(ctx, models.SantriStatus(status))
+func (s *psbService) GetRegistrantsPaginated(ctx context.Context, page, limit int, status string, waveID uint) ([]models.Santri, int64, error) {
+	return s.repo.FindAllPaginated(ctx, page, limit, status, waveID)
 }
 
 func (s *psbService) GetRegistrantByID(ctx context.Context, id uint) (*models.Santri, error) {
@@ -79,12 +124,30 @@ func (s *psbService) UpdateSantri(ctx context.Context, id uint, data *models.San
 	if data.ParentPhone != "" {
 		existing.ParentPhone = data.ParentPhone
 	}
+	if data.ParentEmail != "" {
+		existing.ParentEmail = data.ParentEmail
+	}
+	if data.Locale != "" {
+		existing.Locale = data.Locale
+	}
 	if data.PhotoURL != "" {
 		existing.PhotoURL = data.PhotoURL
 	}
 
+	for _, guardian := range data.Guardians {
+		mergeGuardian(existing, guardian)
+	}
+	if data.PriorEducation != nil {
+		mergePriorEducation(existing, data.PriorEducation)
+	}
+
 	existing.UpdatedAt = time.Now()
-	return s.repo.Update(ctx, existing)
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, existing); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionUpdate, "santri", &id, nil, data)
+	})
 }
 
 func (s *psbService) DeleteSantri(ctx context.Context, id uint) error {
@@ -93,14 +156,238 @@ func (s *psbService) DeleteSantri(ctx context.Context, id uint) error {
 	if err != nil {
 		return err
 	}
-	return s.repo.Delete(ctx, id)
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionDelete, "santri", &id, nil, nil)
+	})
+}
+
+func (s *psbService) UpdateStatus(ctx context.Context, id uint, status string, actorID uint, reason string) error {
+	santri, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Acceptance also assigns NIS and class, so it has its own endpoint
+	if models.SantriStatus(status) == models.StatusAccepted {
+		return utils.NewAppError(400, "Use the verify endpoint to accept a santri")
+	}
+
+	oldStatus := santri.Status
+	history, err := newStatusTransition(santri, models.SantriStatus(status), actorID, reason)
+	if err != nil {
+		return err
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateStatus(ctx, history); err != nil {
+			return err
+		}
+		if err := s.notifier.Notify(ctx, santri, EventPSBStatusUpdated, PSBStatusUpdatedData{SantriName: santri.FullName, Status: status}); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionUpdate, "santri", &id, map[string]models.SantriStatus{"status": oldStatus}, map[string]string{"status": status, "reason": reason})
+	})
+}
+
+// VerifySantri accepts a verified registrant: the status, class, entry year and a generated NIS are stored in one transaction
+func (s *psbService) VerifySantri(ctx context.Context, id uint, class string, entryYear int, actorID uint) (*models.Santri, error) {
+	santri, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := newStatusTransition(santri, models.StatusAccepted, actorID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		nis, err := s.repo.AcceptSantri(ctx, history, class, entryYear, config.AppConfig.NISPattern)
+		if err != nil {
+			return err
+		}
+		if err := s.notifier.Notify(ctx, santri, EventPSBStatusUpdated, PSBStatusUpdatedData{SantriName: santri.FullName, Status: string(models.StatusAccepted)}); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionVerify, "santri", &id, nil, map[string]interface{}{"nis": nis, "class": class})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *psbService) GetStatusHistory(ctx context.Context, id uint) ([]models.SantriStatusHistory, error) {
+	// First verify it exists
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.FindStatusHistory(ctx, id)
+}
+
+// LookupRegistration finds a registration by its code for public endpoints.
+// The birth date acts as a second factor; a mismatch is reported exactly like an unknown code.
+func (s *psbService) LookupRegistration(ctx context.Context, code string, birthDate time.Time) (*models.Santri, error) {
+	santri, err := s.repo.FindByRegistrationCode(ctx, strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return nil, ErrRegistrationNotFound
+		}
+		return nil, err
+	}
+	if santri.BirthDate.Format("2006-01-02") != birthDate.Format("2006-01-02") {
+		return nil, ErrRegistrationNotFound
+	}
+	return santri, nil
+}
+
+func (s *psbService) GetPublicStatus(ctx context.Context, code string, birthDate time.Time) (*dto.PSBStatusResponse, error) {
+	santri, err := s.LookupRegistration(ctx, code, birthDate)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := s.docRepo.FindBySantriID(ctx, santri.ID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.repo.FindStatusHistory(ctx, santri.ID)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := s.sessionRepo.FindBySantri(ctx, santri.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.PSBStatusResponse{
+		RegistrationCode:     santri.RegistrationCode,
+		FullName:             santri.FullName,
+		Status:               string(santri.Status),
+		Documents:            make([]dto.PSBStatusDocument, 0, len(documents)),
+		OutstandingDocuments: make([]string, 0),
+		Notes:                make([]dto.PSBStatusNote, 0),
+		Schedule:             make([]dto.PSBStatusSchedule, 0, len(assignments)),
+		RegisteredAt:         santri.CreatedAt,
+		UpdatedAt:            santri.UpdatedAt,
+	}
+	if santri.Wave != nil {
+		response.Wave = &dto.PSBStatusWave{Name: santri.Wave.Name, AcademicYear: santri.Wave.AcademicYear}
+	}
+
+	// A required document is outstanding until it is uploaded and not sent back for re-upload
+	uploaded := make(map[models.DocumentType]models.DocumentStatus, len(documents))
+	for _, document := range documents {
+		uploaded[document.Type] = document.Status
+		response.Documents = append(response.Documents, dto.PSBStatusDocument{
+			Type:   string(document.Type),
+			Status: string(document.Status),
+			Note:   document.Note,
+		})
+	}
+	for _, required := range models.RequiredSantriDocuments {
+		if status, ok := uploaded[required]; !ok || status == models.DocumentNeedsReupload {
+			response.OutstandingDocuments = append(response.OutstandingDocuments, string(required))
+		}
+	}
+
+	// Only the notes, never who wrote them
+	for _, entry := range history {
+		if entry.Reason == "" {
+			continue
+		}
+		response.Notes = append(response.Notes, dto.PSBStatusNote{
+			Status:    string(entry.ToStatus),
+			Note:      entry.Reason,
+			CreatedAt: entry.CreatedAt,
+		})
+	}
+
+	// A rejected registrant no longer has to attend
+	if santri.Status != models.StatusRejected {
+		for _, assignment := range assignments {
+			if assignment.Session == nil {
+				continue
+			}
+			response.Schedule = append(response.Schedule, dto.PSBStatusSchedule{
+				Kind:     string(assignment.Session.Kind),
+				Name:     assignment.Session.Name,
+				StartsAt: assignment.Session.StartsAt,
+				EndsAt:   assignment.Session.EndsAt,
+				Room:     assignment.Session.Room,
+			})
+		}
+	}
+
+	return response, nil
+}
+
+// mergeGuardian applies the provided guardian fields, adding the guardian if it was not recorded yet
+func mergeGuardian(santri *models.Santri, data models.Guardian) {
+	existing := santri.GuardianByRelation(data.Relation)
+	if existing == nil {
+		if data.Name != "" {
+			santri.Guardians = append(santri.Guardians, models.Guardian{
+				SantriID: santri.ID,
+				Relation: data.Relation,
+				Name:     data.Name,
+				Job:      data.Job,
+			})
+		}
+		return
+	}
+
+	if data.Name != "" {
+		existing.Name = data.Name
+	}
+	if data.Job != "" {
+		existing.Job = data.Job
+	}
 }
 
-func (s *psbService) UpdateStatus(ctx context.Context, id uint, status string) error {
-	return s.repo.UpdateStatus(ctx, id, models.SantriStatus(status))
+// mergePriorEducation applies the provided prior education fields, adding the record if it was not recorded yet
+func mergePriorEducation(santri *models.Santri, data *models.PriorEducation) {
+	if santri.PriorEducation == nil {
+		if data.SchoolName != "" {
+			santri.PriorEducation = &models.PriorEducation{
+				SantriID:       santri.ID,
+				SchoolName:     data.SchoolName,
+				SchoolAddress:  data.SchoolAddress,
+				GraduationYear: data.GraduationYear,
+			}
+		}
+		return
+	}
+
+	if data.SchoolName != "" {
+		santri.PriorEducation.SchoolName = data.SchoolName
+	}
+	if data.SchoolAddress != "" {
+		santri.PriorEducation.SchoolAddress = data.SchoolAddress
+	}
+	if data.GraduationYear != 0 {
+		santri.PriorEducation.GraduationYear = data.GraduationYear
+	}
 }
 
-func (s *psbService) VerifySantri(ctx context.Context, id uint, nis string, class string, entryYear int) error {
-	// Logic: When verifying (Accepting), we assign NIS and Class
-	return s.repo.UpdateAcademicInfo(ctx, id, nis, class, entryYear)
+// newStatusTransition checks a status change against the PSB lifecycle and builds its history entry
+func newStatusTransition(santri *models.Santri, next models.SantriStatus, actorID uint, reason string) (*models.SantriStatusHistory, error) {
+	if !santri.Status.CanTransitionTo(next) {
+		return nil, utils.NewAppError(400, fmt.Sprintf("Cannot change status from %s to %s", santri.Status, next))
+	}
+
+	history := &models.SantriStatusHistory{
+		SantriID:   santri.ID,
+		FromStatus: santri.Status,
+		ToStatus:   next,
+		Reason:     reason,
+	}
+	if actorID != 0 {
+		history.ActorID = &actorID
+	}
+	return history, nil
 }
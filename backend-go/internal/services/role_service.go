@@ -0,0 +1,172 @@
+package services
+
+import (
+	"backend-go/internal/dto"
+	"backend-go/internal/models"
+	"backend-go/internal/repository"
+	"backend-go/internal/utils"
+	"context"
+	"fmt"
+	"regexp"
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// RoleService manages roles and their permissions. Access tokens carry the permissions they were issued with, so
+// changing a role's permissions ends the sessions of the admins who have it.
+type RoleService interface {
+	GetAll(ctx context.Context) ([]models.Role, error)
+	GetByID(ctx context.Context, id uint) (*models.Role, error)
+	Create(ctx context.Context, req dto.CreateRoleRequest) (*models.Role, error)
+	Update(ctx context.Context, id uint, req dto.UpdateRoleRequest) (*models.Role, error)
+	Delete(ctx context.Context, id uint) error
+	GetPermissions(ctx context.Context) ([]models.Permission, error)
+}
+
+type roleService struct {
+	repo     repository.RoleRepository
+	sessions SessionService
+	tx       repository.TxManager
+}
+
+func NewRoleService(repo repository.RoleRepository, sessions SessionService, tx repository.TxManager) RoleService {
+	return &roleService{repo, sessions, tx}
+}
+
+func (s *roleService) GetAll(ctx context.Context) ([]models.Role, error) {
+	return s.repo.FindAll(ctx)
+}
+
+func (s *roleService) GetByID(ctx context.Context, id uint) (*models.Role, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *roleService) Create(ctx context.Context, req dto.CreateRoleRequest) (*models.Role, error) {
+	if !roleNamePattern.MatchString(req.Name) {
+		return nil, utils.NewAppError(400, "Role name may only contain lowercase letters, digits and underscores")
+	}
+	permissions, err := s.resolvePermissions(ctx, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &models.Role{Name: req.Name, Description: req.Description, Permissions: permissions}
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, role); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionCreate, "role", &role.ID, nil, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// Update replaces the description and permissions of a role. The super admin role always keeps every permission,
+// so there is always someone who can manage roles. When the permissions change, the admins with the role are logged
+// out in the same transaction, so a removed permission stops working right away.
+func (s *roleService) Update(ctx context.Context, id uint, req dto.UpdateRoleRequest) (*models.Role, error) {
+	role, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if role.Name == models.RoleSuperAdmin {
+		return nil, utils.NewAppError(400, "The permissions of the super admin role cannot be changed")
+	}
+
+	permissions, err := s.resolvePermissions(ctx, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := !samePermissions(role.Permissions, permissions)
+	role.Description = req.Description
+	role.Permissions = permissions
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, role); err != nil {
+			return err
+		}
+		if changed {
+			userIDs, err := s.repo.FindUserIDs(ctx, role.Name)
+			if err != nil {
+				return err
+			}
+			for _, userID := range userIDs {
+				if _, err := s.sessions.RevokeAll(ctx, userID, ""); err != nil {
+					return err
+				}
+			}
+		}
+		return recordActivity(ctx, models.ActionUpdate, "role", &role.ID, nil, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// Delete removes a role nobody has. Built-in roles cannot be deleted.
+func (s *roleService) Delete(ctx context.Context, id uint) error {
+	role, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if role.IsSystem {
+		return utils.NewAppError(400, "Built-in roles cannot be deleted")
+	}
+
+	count, err := s.repo.CountUsers(ctx, role.Name)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return utils.NewAppError(409, fmt.Sprintf("Role is assigned to %d admin(s)", count))
+	}
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionDelete, "role", &id, nil, nil)
+	})
+}
+
+func (s *roleService) GetPermissions(ctx context.Context) ([]models.Permission, error) {
+	return s.repo.FindPermissions(ctx)
+}
+
+// resolvePermissions looks up permissions by code, rejecting unknown codes
+func (s *roleService) resolvePermissions(ctx context.Context, codes []string) ([]models.Permission, error) {
+	permissions, err := s.repo.FindPermissionsByCodes(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		found[permission.Code] = true
+	}
+	for _, code := range codes {
+		if !found[code] {
+			return nil, utils.NewAppError(400, "Unknown permission: "+code)
+		}
+	}
+	return permissions, nil
+}
+
+// samePermissions reports whether both lists hold the same permission codes, in any order
+func samePermissions(a, b []models.Permission) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	codes := make(map[string]bool, len(a))
+	for _, permission := range a {
+		codes[permission.Code] = true
+	}
+	for _, permission := range b {
+		if !codes[permission.Code] {
+			return false
+		}
+	}
+	return true
+}
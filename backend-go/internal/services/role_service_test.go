@@ -0,0 +1,268 @@
+package services_test
+
+import (
+	"backend-go/config"
+	"backend-go/internal/dto"
+	"backend-go/internal/models"
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Manual Mock for RoleRepository, seeded with the built-in roles. Users are counted from the user mock, if set.
+type mockRoleRepository struct {
+	roles       map[uint]*models.Role
+	permissions []models.Permission
+	users       *mockUserRepository
+}
+
+func newMockRoles(users *mockUserRepository) *mockRoleRepository {
+	m := &mockRoleRepository{roles: make(map[uint]*models.Role), users: users}
+	for i, code := range []string{models.PermPSBRead, models.PermArticleWrite, models.PermArticlePublish, models.PermAdminManage} {
+		m.permissions = append(m.permissions, models.Permission{ID: uint(i + 1), Code: code})
+	}
+	m.roles[1] = &models.Role{ID: 1, Name: models.RoleSuperAdmin, IsSystem: true, Permissions: m.permissions}
+	m.roles[2] = &models.Role{ID: 2, Name: models.RoleAdmin, IsSystem: true, Permissions: m.permissions[:3]}
+	return m
+}
+
+func (m *mockRoleRepository) FindAll(ctx context.Context) ([]models.Role, error) {
+	var roles []models.Role
+	for _, role := range m.roles {
+		roles = append(roles, *role)
+	}
+	return roles, nil
+}
+
+func (m *mockRoleRepository) FindByID(ctx context.Context, id uint) (*models.Role, error) {
+	role, ok := m.roles[id]
+	if !ok {
+		return nil, utils.ErrNotFound
+	}
+	copied := *role
+	return &copied, nil
+}
+
+func (m *mockRoleRepository) FindByName(ctx context.Context, name string) (*models.Role, error) {
+	for _, role := range m.roles {
+		if role.Name == name {
+			copied := *role
+			return &copied, nil
+		}
+	}
+	return nil, utils.ErrNotFound
+}
+
+func (m *mockRoleRepository) Create(ctx context.Context, role *models.Role) error {
+	if _, err := m.FindByName(ctx, role.Name); err == nil {
+		return utils.ErrConflict
+	}
+	role.ID = uint(len(m.roles) + 1)
+	copied := *role
+	m.roles[role.ID] = &copied
+	return nil
+}
+
+func (m *mockRoleRepository) Update(ctx context.Context, role *models.Role) error {
+	copied := *role
+	m.roles[role.ID] = &copied
+	return nil
+}
+
+func (m *mockRoleRepository) Delete(ctx context.Context, id uint) error {
+	delete(m.roles, id)
+	return nil
+}
+
+func (m *mockRoleRepository) CountUsers(ctx context.Context, name string) (int64, error) {
+	var count int64
+	if m.users != nil {
+		for _, user := range m.users.users {
+			if user.Role == name {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+func (m *mockRoleRepository) FindUserIDs(ctx context.Context, name string) ([]uint, error) {
+	var ids []uint
+	if m.users != nil {
+		for _, user := range m.users.users {
+			if user.Role == name {
+				ids = append(ids, user.ID)
+			}
+		}
+	}
+	return ids, nil
+}
+
+func (m *mockRoleRepository) FindPermissions(ctx context.Context) ([]models.Permission, error) {
+	return m.permissions, nil
+}
+
+func (m *mockRoleRepository) FindPermissionsByCodes(ctx context.Context, codes []string) ([]models.Permission, error) {
+	var permissions []models.Permission
+	for _, permission := range m.permissions {
+		for _, code := range codes {
+			if permission.Code == code {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	return permissions, nil
+}
+
+func (m *mockRoleRepository) FindPermissionCodes(ctx context.Context, roleName string) ([]string, error) {
+	role, err := m.FindByName(context.Background(), roleName)
+	if err != nil {
+		return []string{}, nil
+	}
+	codes := make([]string, len(role.Permissions))
+	for i, permission := range role.Permissions {
+		codes[i] = permission.Code
+	}
+	return codes, nil
+}
+
+func TestRoleService(t *testing.T) {
+	users := newMockRepo()
+	sessions := newMockSessions()
+	service := services.NewRoleService(newMockRoles(users), sessions, mockTxManager{})
+	ctx := context.Background()
+
+	if _, err := service.Create(ctx, dto.CreateRoleRequest{Name: "Media Team"}); err == nil {
+		t.Error("expected error for an invalid role name")
+	}
+	if _, err := service.Create(ctx, dto.CreateRoleRequest{Name: "media", Permissions: []string{"article.write", "psb.everything"}}); err == nil {
+		t.Error("expected error for an unknown permission")
+	}
+
+	role, err := service.Create(ctx, dto.CreateRoleRequest{Name: "media", Permissions: []string{models.PermArticleWrite}})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if len(role.Permissions) != 1 || role.Permissions[0].Code != models.PermArticleWrite {
+		t.Errorf("expected the role to have article.write, got %+v", role.Permissions)
+	}
+
+	users.users["editor"] = &models.User{ID: 10, Username: "editor", Role: "media"}
+	session, _ := sessions.Start(ctx, 10, services.ClientInfo{})
+	if _, err := service.Update(ctx, role.ID, dto.UpdateRoleRequest{Description: "Media team", Permissions: []string{models.PermArticleWrite}}); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if !sessions.IsActive(ctx, session.ID) {
+		t.Error("expected sessions to survive an update that keeps the permissions")
+	}
+	role, err = service.Update(ctx, role.ID, dto.UpdateRoleRequest{Permissions: []string{models.PermArticleWrite, models.PermArticlePublish}})
+	if err != nil || len(role.Permissions) != 2 {
+		t.Errorf("expected the permissions to be replaced, got %+v, %v", role, err)
+	}
+	if sessions.IsActive(ctx, session.ID) {
+		t.Error("expected the sessions of the role's admins to be revoked when its permissions change")
+	}
+	if _, err := service.Update(ctx, 1, dto.UpdateRoleRequest{Permissions: []string{}}); err == nil {
+		t.Error("expected the super admin permissions to be protected")
+	}
+
+	if err := service.Delete(ctx, role.ID); err == nil {
+		t.Error("expected error deleting a role in use")
+	}
+	if err := service.Delete(ctx, 2); err == nil {
+		t.Error("expected error deleting a built-in role")
+	}
+	delete(users.users, "editor")
+	if err := service.Delete(ctx, role.ID); err != nil {
+		t.Errorf("expected an unused role to be deleted, got %v", err)
+	}
+}
+
+func TestRoleService_RecordsActivity(t *testing.T) {
+	outbox := &mockOutbox{}
+	services.SetOutbox(outbox)
+	defer services.SetOutbox(nil)
+
+	service := services.NewRoleService(newMockRoles(newMockRepo()), newMockSessions(), mockTxManager{})
+
+	if _, err := service.Create(context.Background(), dto.CreateRoleRequest{Name: "media"}); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if len(outbox.jobs) != 0 {
+		t.Errorf("expected nothing to be recorded without a signed-in user, got %v", outbox.jobs)
+	}
+
+	ctx := services.WithActor(context.Background(), 7, services.ClientInfo{IPAddress: "10.0.0.1"})
+	role, err := service.Create(ctx, dto.CreateRoleRequest{Name: "finance"})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if len(outbox.jobs) != 1 || outbox.jobs[0] != services.JobActivityLog {
+		t.Fatalf("expected one activity log job, got %v", outbox.jobs)
+	}
+	var entry struct {
+		UserID     uint   `json:"user_id"`
+		Action     string `json:"action"`
+		EntityType string `json:"entity_type"`
+		EntityID   uint   `json:"entity_id"`
+		IPAddress  string `json:"ip_address"`
+	}
+	if err := json.Unmarshal([]byte(outbox.payloads[0]), &entry); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if entry.UserID != 7 || entry.Action != string(models.ActionCreate) || entry.EntityType != "role" || entry.EntityID != role.ID || entry.IPAddress != "10.0.0.1" {
+		t.Errorf("unexpected activity log entry %+v", entry)
+	}
+
+	// The entry is part of the transaction, so failing to queue it fails the change
+	outbox.err = errors.New("database is down")
+	if _, err := service.Create(ctx, dto.CreateRoleRequest{Name: "library"}); err == nil {
+		t.Error("expected the change to fail when its activity log entry cannot be queued")
+	}
+}
+
+func TestAuthService_RolePermissions(t *testing.T) {
+	config.AppConfig.JWTSecret = "supersecret"
+
+	users := newMockRepo()
+	roles := newMockRoles(users)
+	service := services.NewAuthService(users, roles, newMockRecoveryCodes(), newMockSessions(), newMockCache(), mockTxManager{}, &mockEmailNotifier{})
+	ctx := context.Background()
+
+	if err := service.RegisterAdmin(ctx, "ghost", "Password123", "", "nonexistent"); err == nil {
+		t.Error("expected error for an unknown role")
+	}
+	service.RegisterAdmin(ctx, "root", "Password123", "", models.RoleSuperAdmin)
+	service.RegisterAdmin(ctx, "user1", "Password123", "", "")
+
+	result, err := service.Login(ctx, "user1", "Password123", services.ClientInfo{})
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(result.AccessToken, claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(config.AppConfig.JWTSecret), nil
+	}); err != nil {
+		t.Fatalf("parse token failed: %v", err)
+	}
+	permissions, _ := claims["permissions"].([]interface{})
+	if len(permissions) != 3 || len(result.User.Permissions) != 3 {
+		t.Errorf("expected the admin role's permissions in the token, got %v", claims["permissions"])
+	}
+
+	root, _ := users.FindByUsername(ctx, "root")
+	if err := service.UpdateAdminRole(ctx, root.ID, models.RoleAdmin); err == nil {
+		t.Error("expected the last super admin to keep the role")
+	}
+	if err := service.UpdateAdminRole(ctx, result.User.ID, models.RoleSuperAdmin); err != nil {
+		t.Fatalf("update role failed: %v", err)
+	}
+	if err := service.UpdateAdminRole(ctx, root.ID, models.RoleAdmin); err != nil {
+		t.Errorf("expected a super admin to be demoted once there is another, got %v", err)
+	}
+}
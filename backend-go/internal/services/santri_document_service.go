@@ -0,0 +1,146 @@
+package services
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/repository"
+	"backend-go/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"strings"
+	"time"
+)
+
+// ErrRegistrationNotFound is returned when a public request cannot be matched to a registration.
+// A wrong registration code and a wrong birth date produce the same error so registrations cannot be probed.
+var ErrRegistrationNotFound = utils.NewAppError(404, "Registration not found, please check your data")
+
+type SantriDocumentService interface {
+	UploadDocument(ctx context.Context, registrationCode string, birthDate time.Time, docType models.DocumentType, file multipart.File, header *multipart.FileHeader) (*models.SantriDocument, error)
+	GetDocuments(ctx context.Context, santriID uint) ([]models.SantriDocument, error)
+	VerifyDocument(ctx context.Context, id uint, status models.DocumentStatus, note string, actorID uint) (*models.SantriDocument, error)
+}
+
+type santriDocumentService struct {
+	repo       repository.SantriDocumentRepository
+	santriRepo repository.SantriRepository
+	media      MediaService
+	tx         repository.TxManager
+}
+
+func NewSantriDocumentService(repo repository.SantriDocumentRepository, santriRepo repository.SantriRepository, media MediaService, tx repository.TxManager) SantriDocumentService {
+	return &santriDocumentService{repo, santriRepo, media, tx}
+}
+
+func (s *santriDocumentService) UploadDocument(ctx context.Context, registrationCode string, birthDate time.Time, docType models.DocumentType, file multipart.File, header *multipart.FileHeader) (*models.SantriDocument, error) {
+	santri, err := s.santriRepo.FindByRegistrationCode(ctx, strings.ToUpper(strings.TrimSpace(registrationCode)))
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return nil, ErrRegistrationNotFound
+		}
+		return nil, err
+	}
+	if santri.BirthDate.Format("2006-01-02") != birthDate.Format("2006-01-02") {
+		return nil, ErrRegistrationNotFound
+	}
+
+	// Documents can only change while the registration is still being processed
+	if santri.Status == models.StatusAccepted || santri.Status == models.StatusRejected {
+		return nil, utils.NewAppError(400, "Registration is already closed, documents can no longer be changed")
+	}
+
+	existing, err := s.repo.FindBySantriAndType(ctx, santri.ID, docType)
+	if err != nil {
+		if !errors.Is(err, utils.ErrNotFound) {
+			return nil, err
+		}
+		existing = nil
+	}
+	if existing != nil && existing.Status == models.DocumentApproved {
+		return nil, utils.NewAppError(409, "This document has already been approved")
+	}
+
+	mimeType, err := s.media.ValidateDocument(file, header)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := s.media.UploadDocument(ctx, file, header, fmt.Sprintf("k3arafah/psb/%d", santri.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	// First upload of this type
+	if existing == nil {
+		document := &models.SantriDocument{
+			SantriID: santri.ID,
+			Type:     docType,
+			FileURL:  url,
+			FileName: header.Filename,
+			MimeType: mimeType,
+			Status:   models.DocumentPending,
+		}
+		if err := s.repo.Create(ctx, document); err != nil {
+			CleanupImageAsync(url)
+			return nil, err
+		}
+		return document, nil
+	}
+
+	// Re-upload replaces the file and starts verification over
+	oldURL := existing.FileURL
+	existing.FileURL = url
+	existing.FileName = header.Filename
+	existing.MimeType = mimeType
+	existing.Status = models.DocumentPending
+	existing.Note = ""
+	existing.VerifiedBy = nil
+	existing.VerifiedAt = nil
+
+	if err := s.repo.Update(ctx, existing); err != nil {
+		CleanupImageAsync(url)
+		return nil, err
+	}
+
+	CleanupImageAsync(oldURL)
+	return existing, nil
+}
+
+func (s *santriDocumentService) GetDocuments(ctx context.Context, santriID uint) ([]models.SantriDocument, error) {
+	// First verify it exists
+	if _, err := s.santriRepo.FindByID(ctx, santriID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindBySantriID(ctx, santriID)
+}
+
+func (s *santriDocumentService) VerifyDocument(ctx context.Context, id uint, status models.DocumentStatus, note string, actorID uint) (*models.SantriDocument, error) {
+	if status == models.DocumentNeedsReupload && note == "" {
+		return nil, utils.NewAppError(400, "A note is required when asking for a re-upload")
+	}
+
+	document, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	document.Status = status
+	document.Note = note
+	document.VerifiedAt = &now
+	if actorID != 0 {
+		document.VerifiedBy = &actorID
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, document); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionVerify, "santri_document", &id, nil, map[string]string{"status": string(status), "note": note})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return document, nil
+}
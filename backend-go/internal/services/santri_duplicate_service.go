@@ -0,0 +1,163 @@
+package services
+
+import (
+	"backend-go/internal/dto"
+	"backend-go/internal/models"
+	"backend-go/internal/repository"
+	"backend-go/internal/utils"
+	"context"
+	"math"
+	"sort"
+)
+
+// Weights of each matching field in the duplicate score. They add up to 100.
+const (
+	duplicateWeightName       = 35
+	duplicateWeightBirthDate  = 25
+	duplicateWeightPhone      = 20
+	duplicateWeightBirthPlace = 10
+	duplicateWeightNIK        = 10
+
+	// duplicateMinNameSimilarity is how alike two names must be before the other fields are compared
+	duplicateMinNameSimilarity = 0.6
+
+	// duplicateScoreThreshold is the minimum score for a registrant to be reported as a possible duplicate
+	duplicateScoreThreshold = 50
+)
+
+type SantriDuplicateService interface {
+	FindPossibleDuplicates(ctx context.Context, id uint) ([]dto.PossibleDuplicate, error)
+	Merge(ctx context.Context, primaryID, duplicateID uint, actorID uint) (*models.Santri, error)
+}
+
+type santriDuplicateService struct {
+	repo repository.SantriRepository
+	tx   repository.TxManager
+}
+
+func NewSantriDuplicateService(repo repository.SantriRepository, tx repository.TxManager) SantriDuplicateService {
+	return &santriDuplicateService{repo, tx}
+}
+
+// FindPossibleDuplicates scores registrants sharing a birth date, name or parent phone with the given one,
+// highest score first
+func (s *santriDuplicateService) FindPossibleDuplicates(ctx context.Context, id uint) ([]dto.PossibleDuplicate, error) {
+	santri, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.repo.FindDuplicateCandidates(ctx, santri)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates := []dto.PossibleDuplicate{}
+	for i := range candidates {
+		candidate := &candidates[i]
+		score, reasons := scoreDuplicate(santri, candidate)
+		if score < duplicateScoreThreshold {
+			continue
+		}
+		duplicates = append(duplicates, dto.PossibleDuplicate{
+			ID:               candidate.ID,
+			RegistrationCode: candidate.RegistrationCode,
+			FullName:         candidate.FullName,
+			NIK:              candidate.NIK,
+			BirthPlace:       candidate.BirthPlace,
+			BirthDate:        candidate.BirthDate,
+			ParentPhone:      candidate.ParentPhone,
+			Status:           string(candidate.Status),
+			Score:            score,
+			Reasons:          reasons,
+			RegisteredAt:     candidate.CreatedAt,
+		})
+	}
+
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return duplicates[i].Score > duplicates[j].Score
+	})
+	return duplicates, nil
+}
+
+// Merge folds duplicateID into primaryID and returns the updated primary registration.
+// An accepted registration (one with an NIS) can only be the primary.
+func (s *santriDuplicateService) Merge(ctx context.Context, primaryID, duplicateID uint, actorID uint) (*models.Santri, error) {
+	if primaryID == duplicateID {
+		return nil, utils.NewAppError(400, "A registration cannot be merged into itself")
+	}
+
+	if _, err := s.repo.FindByID(ctx, primaryID); err != nil {
+		return nil, err
+	}
+	duplicate, err := s.repo.FindByID(ctx, duplicateID)
+	if err != nil {
+		return nil, err
+	}
+	if duplicate.Status == models.StatusAccepted || duplicate.NIS != nil {
+		return nil, utils.NewAppError(400, "The duplicate registration has already been accepted, merge it the other way round")
+	}
+
+	var actor *uint
+	if actorID != 0 {
+		actor = &actorID
+	}
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Merge(ctx, primaryID, duplicateID, actor); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionMerge, "santri", &primaryID, map[string]uint{"duplicate_id": duplicateID}, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByID(ctx, primaryID)
+}
+
+// scoreDuplicate compares two registrants and returns a 0-100 score with the fields that matched
+func scoreDuplicate(a, b *models.Santri) (int, []string) {
+	score := 0.0
+	reasons := []string{}
+
+	// Full name: partial credit for spelling variants (Ahmad / Achmad). Registrants with clearly different
+	// names are never duplicates, however much else matches: twins share birth date, parents and KK.
+	nameSimilarity := utils.Similarity(utils.NormalizeName(a.FullName), utils.NormalizeName(b.FullName))
+	if nameSimilarity < duplicateMinNameSimilarity {
+		return 0, reasons
+	}
+	score += duplicateWeightName * nameSimilarity
+	reasons = append(reasons, "full_name")
+
+	// Birth date: full credit for the same date, partial credit for day and month swapped
+	switch {
+	case sameDate(a.BirthDate.Year(), int(a.BirthDate.Month()), a.BirthDate.Day(), b.BirthDate.Year(), int(b.BirthDate.Month()), b.BirthDate.Day()):
+		score += duplicateWeightBirthDate
+		reasons = append(reasons, "birth_date")
+	case sameDate(a.BirthDate.Year(), int(a.BirthDate.Month()), a.BirthDate.Day(), b.BirthDate.Year(), b.BirthDate.Day(), int(b.BirthDate.Month())):
+		score += duplicateWeightBirthDate / 2
+		reasons = append(reasons, "birth_date_swapped")
+	}
+
+	if phoneA, phoneB := utils.NormalizePhone(a.ParentPhone), utils.NormalizePhone(b.ParentPhone); phoneA != "" && phoneA == phoneB {
+		score += duplicateWeightPhone
+		reasons = append(reasons, "parent_phone")
+	}
+
+	if placeA, placeB := utils.NormalizeName(a.BirthPlace), utils.NormalizeName(b.BirthPlace); placeA != "" && utils.Similarity(placeA, placeB) >= 0.85 {
+		score += duplicateWeightBirthPlace
+		reasons = append(reasons, "birth_place")
+	}
+
+	// NIK: a typo of one or two digits (the exact value is already unique)
+	if a.NIK != "" && b.NIK != "" && utils.Levenshtein(a.NIK, b.NIK) <= 2 {
+		score += duplicateWeightNIK
+		reasons = append(reasons, "nik")
+	}
+
+	return int(math.Round(score)), reasons
+}
+
+func sameDate(y1, m1, d1, y2, m2, d2 int) bool {
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
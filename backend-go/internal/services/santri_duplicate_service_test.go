@@ -0,0 +1,73 @@
+package services_test
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/repository"
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"context"
+	"testing"
+	"time"
+)
+
+// Manual Mock for the SantriRepository lookups used by duplicate detection
+type mockDuplicateSantriRepository struct {
+	repository.SantriRepository
+	santris []models.Santri
+}
+
+func (m *mockDuplicateSantriRepository) FindByID(ctx context.Context, id uint) (*models.Santri, error) {
+	for i := range m.santris {
+		if m.santris[i].ID == id {
+			return &m.santris[i], nil
+		}
+	}
+	return nil, utils.ErrNotFound
+}
+
+func (m *mockDuplicateSantriRepository) FindDuplicateCandidates(ctx context.Context, santri *models.Santri) ([]models.Santri, error) {
+	var candidates []models.Santri
+	for _, s := range m.santris {
+		if s.ID != santri.ID {
+			candidates = append(candidates, s)
+		}
+	}
+	return candidates, nil
+}
+
+func TestSantriDuplicateService_FindPossibleDuplicates(t *testing.T) {
+	birthDate := time.Date(2012, 3, 4, 0, 0, 0, 0, time.UTC)
+	repo := &mockDuplicateSantriRepository{santris: []models.Santri{
+		{ID: 1, FullName: "Ahmad Fauzi", NIK: "3402011234567890", BirthPlace: "Bantul", BirthDate: birthDate, ParentPhone: "081234567890"},
+		// Re-submission with a NIK typo and the phone in international format
+		{ID: 2, FullName: "Achmad Fauzi", NIK: "3402011234567809", BirthPlace: "BANTUL", BirthDate: birthDate, ParentPhone: "+62 812-3456-7890"},
+		// Day and month swapped, different phone
+		{ID: 3, FullName: "Ahmad Fauzi", NIK: "3402019999999999", BirthPlace: "Bantul", BirthDate: time.Date(2012, 4, 3, 0, 0, 0, 0, time.UTC), ParentPhone: "085700000000"},
+		// Twin: same birth date and parents, different name
+		{ID: 4, FullName: "Siti Aminah", NIK: "3402011234567891", BirthPlace: "Bantul", BirthDate: birthDate, ParentPhone: "081234567890"},
+	}}
+	svc := services.NewSantriDuplicateService(repo, mockTxManager{})
+
+	duplicates, err := svc.FindPossibleDuplicates(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("FindPossibleDuplicates failed: %v", err)
+	}
+
+	if len(duplicates) != 2 {
+		t.Fatalf("expected 2 possible duplicates, got %d: %+v", len(duplicates), duplicates)
+	}
+	if duplicates[0].ID != 2 || duplicates[1].ID != 3 {
+		t.Errorf("expected registrants 2 and 3 ordered by score, got %d and %d", duplicates[0].ID, duplicates[1].ID)
+	}
+	if duplicates[0].Score < 90 {
+		t.Errorf("expected a near-identical re-submission to score at least 90, got %d", duplicates[0].Score)
+	}
+}
+
+func TestSantriDuplicateService_MergeRejectsSelf(t *testing.T) {
+	svc := services.NewSantriDuplicateService(&mockDuplicateSantriRepository{}, mockTxManager{})
+
+	if _, err := svc.Merge(context.Background(), 1, 1, 1); err == nil {
+		t.Error("expected merging a registration into itself to fail")
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/repository"
+	"backend-go/internal/utils"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// searchCacheTTL bounds how long results stay cached. Changes to searchable content drop the cache right away through
+// invalidateSearchCache.
+const searchCacheTTL = 2 * time.Minute
+
+// SearchResult is a page of site-wide search hits with the number of hits per type
+type SearchResult struct {
+	Hits   []models.SearchHit `json:"hits"`
+	Facets map[string]int64   `json:"facets"`
+	Total  int64              `json:"total"` // Hits of the requested type, or of every type
+}
+
+type SearchService interface {
+	Search(ctx context.Context, query, searchType string, page, limit int) (*SearchResult, error)
+}
+
+type searchService struct {
+	repo  repository.SearchRepository
+	cache CacheService
+}
+
+func NewSearchService(repo repository.SearchRepository, cache CacheService) SearchService {
+	return &searchService{repo, cache}
+}
+
+// Search finds public content of every type, or only of searchType when it is set. Results are cached by the
+// normalized query, so queries differing only in case and spacing share an entry.
+func (s *searchService) Search(ctx context.Context, query, searchType string, page, limit int) (*SearchResult, error) {
+	query = NormalizeSearchQuery(query)
+	if query == "" {
+		return nil, utils.NewAppError(400, "Search query is required")
+	}
+	if searchType != "" && !isSearchType(searchType) {
+		return nil, utils.NewAppError(400, "Unknown search type: "+searchType)
+	}
+
+	key := fmt.Sprintf(utils.CacheKeySearchPattern, searchType, page, limit, query)
+	var cached SearchResult
+	if err := s.cache.Get(key, &cached); err == nil {
+		return &cached, nil
+	}
+
+	hits, facets, err := s.repo.Search(ctx, query, searchType, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SearchResult{Hits: hits, Facets: facets}
+	if result.Hits == nil {
+		result.Hits = []models.SearchHit{}
+	}
+	for t, count := range facets {
+		if searchType == "" || t == searchType {
+			result.Total += count
+		}
+	}
+
+	_ = s.cache.Set(key, result, searchCacheTTL)
+	return result, nil
+}
+
+// invalidateSearchCache drops every cached search result, so deleted or unpublished content stops showing up at once
+func invalidateSearchCache(cache CacheService) {
+	cache.DeleteByPattern("search:*")
+}
+
+// NormalizeSearchQuery lowercases a query and collapses its whitespace. Full-text matching ignores case, so the
+// normalized query finds the same content.
+func NormalizeSearchQuery(query string) string {
+	return strings.ToLower(strings.Join(strings.Fields(query), " "))
+}
+
+func isSearchType(searchType string) bool {
+	for _, t := range models.SearchTypes {
+		if t == searchType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package services_test
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/services"
+	"context"
+	"testing"
+)
+
+// Manual Mock for SearchRepository
+type mockSearchRepository struct {
+	hits    []models.SearchHit
+	queries []string
+}
+
+func (m *mockSearchRepository) Search(ctx context.Context, query, searchType string, page, limit int) ([]models.SearchHit, map[string]int64, error) {
+	m.queries = append(m.queries, query)
+	facets := map[string]int64{}
+	var hits []models.SearchHit
+	for _, hit := range m.hits {
+		facets[hit.Type]++
+		if searchType == "" || hit.Type == searchType {
+			hits = append(hits, hit)
+		}
+	}
+	return hits, facets, nil
+}
+
+func TestSearchService_Search(t *testing.T) {
+	repo := &mockSearchRepository{hits: []models.SearchHit{
+		{Type: models.SearchTypeArticle, ID: 1, Title: "Wisuda Tahfidz", Slug: "wisuda-tahfidz-2025"},
+		{Type: models.SearchTypeGallery, ID: 2, Title: "Dokumentasi Wisuda Tahfidz"},
+		{Type: models.SearchTypeVideo, ID: 3, Title: "Video Wisuda"},
+	}}
+	service := services.NewSearchService(repo, newMockCache())
+	ctx := context.Background()
+
+	result, err := service.Search(ctx, "  Wisuda   TAHFIDZ ", "", 1, 10)
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if repo.queries[0] != "wisuda tahfidz" {
+		t.Errorf("expected the query to be normalized, got %q", repo.queries[0])
+	}
+	if result.Total != 3 || len(result.Hits) != 3 {
+		t.Errorf("expected 3 hits, got %d of %d", len(result.Hits), result.Total)
+	}
+	if result.Hits[0].Slug != "wisuda-tahfidz-2025" || result.Hits[1].Slug != "" {
+		t.Errorf("expected only articles to have a slug, got %q and %q", result.Hits[0].Slug, result.Hits[1].Slug)
+	}
+
+	if _, err := service.Search(ctx, "wisuda tahfidz", "", 1, 10); err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if len(repo.queries) != 1 {
+		t.Errorf("expected an equivalent query to be served from the cache, got %d lookups", len(repo.queries))
+	}
+
+	result, _ = service.Search(ctx, "wisuda", models.SearchTypeVideo, 1, 10)
+	if result.Total != 1 || len(result.Hits) != 1 || result.Facets[models.SearchTypeArticle] != 1 {
+		t.Errorf("expected one video hit with facets for every type, got %+v", result)
+	}
+
+	if _, err := service.Search(ctx, "wisuda", "santri", 1, 10); !isStatus(err, 400) {
+		t.Errorf("expected 400 for an unknown type, got %v", err)
+	}
+	if _, err := service.Search(ctx, "   ", "", 1, 10); !isStatus(err, 400) {
+		t.Errorf("expected 400 for an empty query, got %v", err)
+	}
+}
@@ -0,0 +1,357 @@
+package services
+
+import (
+	"backend-go/config"
+	"backend-go/internal/dto"
+	"backend-go/internal/models"
+	"backend-go/internal/repository"
+	"backend-go/internal/utils"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+type SelectionService interface {
+	GetRubric(ctx context.Context, waveID uint) ([]models.SelectionComponent, error)
+	UpdateRubric(ctx context.Context, waveID uint, components []models.SelectionComponent) ([]models.SelectionComponent, error)
+	GetScores(ctx context.Context, santriID uint) ([]models.SelectionScore, error)
+	UpdateScores(ctx context.Context, santriID uint, scores []models.SelectionScore, actorID uint) ([]models.SelectionScore, error)
+	GetRanking(ctx context.Context, waveID uint) (*dto.WaveRankingResponse, error)
+	RunSelection(ctx context.Context, waveID uint, input dto.RunSelectionRequest, actorID uint) (*dto.SelectionResult, error)
+}
+
+type selectionService struct {
+	repo       repository.SelectionRepository
+	waveRepo   repository.AdmissionWaveRepository
+	santriRepo repository.SantriRepository
+	tx         repository.TxManager
+	notifier   NotificationService
+}
+
+func NewSelectionService(repo repository.SelectionRepository, waveRepo repository.AdmissionWaveRepository, santriRepo repository.SantriRepository, tx repository.TxManager, notifier NotificationService) SelectionService {
+	return &selectionService{repo, waveRepo, santriRepo, tx, notifier}
+}
+
+func (s *selectionService) GetRubric(ctx context.Context, waveID uint) ([]models.SelectionComponent, error) {
+	if _, err := s.waveRepo.FindByID(ctx, waveID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindComponents(ctx, waveID)
+}
+
+func (s *selectionService) UpdateRubric(ctx context.Context, waveID uint, components []models.SelectionComponent) ([]models.SelectionComponent, error) {
+	if _, err := s.waveRepo.FindByID(ctx, waveID); err != nil {
+		return nil, err
+	}
+
+	total := 0
+	for i := range components {
+		total += components[i].Weight
+		if components[i].MaxScore == 0 {
+			components[i].MaxScore = 100
+		}
+		components[i].SortOrder = i
+	}
+	if total != 100 {
+		return nil, utils.NewAppError(400, fmt.Sprintf("Rubric weights must add up to 100, got %d", total))
+	}
+
+	var updated []models.SelectionComponent
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.ReplaceComponents(ctx, waveID, components); err != nil {
+			return err
+		}
+		var err error
+		if updated, err = s.repo.FindComponents(ctx, waveID); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionUpdate, "selection_rubric", &waveID, nil, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *selectionService) GetScores(ctx context.Context, santriID uint) ([]models.SelectionScore, error) {
+	if _, err := s.santriRepo.FindByID(ctx, santriID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindScoresBySantri(ctx, santriID)
+}
+
+func (s *selectionService) UpdateScores(ctx context.Context, santriID uint, scores []models.SelectionScore, actorID uint) ([]models.SelectionScore, error) {
+	santri, err := s.santriRepo.FindByID(ctx, santriID)
+	if err != nil {
+		return nil, err
+	}
+	if santri.WaveID == nil {
+		return nil, utils.NewAppError(400, "Registrant is not part of an admission wave")
+	}
+	if santri.Status != models.StatusPending && santri.Status != models.StatusVerified {
+		return nil, utils.NewAppError(400, "Scores can only be entered while the registration is being processed")
+	}
+
+	components, err := s.repo.FindComponents(ctx, *santri.WaveID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.SelectionComponent, len(components))
+	for _, component := range components {
+		byID[component.ID] = component
+	}
+
+	for i := range scores {
+		component, ok := byID[scores[i].ComponentID]
+		if !ok {
+			return nil, utils.NewAppError(400, fmt.Sprintf("Rubric component %d does not belong to the registrant's wave", scores[i].ComponentID))
+		}
+		if scores[i].Score > component.MaxScore {
+			return nil, utils.NewAppError(400, fmt.Sprintf("Score for %s must not exceed %g", component.Name, component.MaxScore))
+		}
+		scores[i].SantriID = santriID
+		if actorID != 0 {
+			scores[i].ScoredBy = &actorID
+		}
+	}
+
+	var updated []models.SelectionScore
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpsertScores(ctx, scores); err != nil {
+			return err
+		}
+		var err error
+		if updated, err = s.repo.FindScoresBySantri(ctx, santriID); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionUpdate, "selection_score", &santriID, nil, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *selectionService) GetRanking(ctx context.Context, waveID uint) (*dto.WaveRankingResponse, error) {
+	ranking, _, err := s.buildRanking(ctx, waveID)
+	return ranking, err
+}
+
+// RunSelection accepts the top ranked verified registrants of each gender and, if asked, rejects the rest,
+// all in one transaction. Every candidate must have a complete set of scores first.
+func (s *selectionService) RunSelection(ctx context.Context, waveID uint, input dto.RunSelectionRequest, actorID uint) (*dto.SelectionResult, error) {
+	ranking, santris, err := s.buildRanking(ctx, waveID)
+	if err != nil {
+		return nil, err
+	}
+
+	var accepts, rejects []*models.SantriStatusHistory
+	for _, group := range []struct {
+		label   string
+		ranking dto.GenderRanking
+		top     *int
+	}{
+		{"male", ranking.Male, input.TopMale},
+		{"female", ranking.Female, input.TopFemale},
+	} {
+		top := group.ranking.Remaining
+		if group.top != nil {
+			top = *group.top
+		}
+		if top < 0 {
+			return nil, utils.NewAppError(400, fmt.Sprintf("The %s quota is unlimited, please specify how many %s registrants to accept", group.label, group.label))
+		}
+		if group.ranking.Remaining >= 0 && top > group.ranking.Remaining {
+			return nil, utils.NewAppError(400, fmt.Sprintf("Cannot accept %d %s registrants, only %d seats are left", top, group.label, group.ranking.Remaining))
+		}
+
+		for i, entry := range group.ranking.Entries {
+			if !entry.Complete {
+				return nil, utils.NewAppError(400, fmt.Sprintf("%s (%s) is missing selection scores", entry.FullName, entry.RegistrationCode))
+			}
+
+			var history *models.SantriStatusHistory
+			switch {
+			case i < top:
+				history, err = newStatusTransition(santris[entry.SantriID], models.StatusAccepted, actorID, "")
+				accepts = append(accepts, history)
+			case input.RejectRest:
+				history, err = newStatusTransition(santris[entry.SantriID], models.StatusRejected, actorID, input.Reason)
+				rejects = append(rejects, history)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(accepts) == 0 && len(rejects) == 0 {
+		return nil, utils.NewAppError(400, "There are no registrants to accept or reject")
+	}
+
+	// Parents are notified through the outbox in the same transaction, so messages only go out for a committed selection
+	result := &dto.SelectionResult{Accepted: []dto.SelectionAccepted{}, Rejected: []uint{}}
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		assigned, err := s.santriRepo.ApplySelection(ctx, waveID, accepts, rejects, input.Class, input.EntryYear, config.AppConfig.NISPattern)
+		if err != nil {
+			return err
+		}
+		for _, history := range append(accepts, rejects...) {
+			santri := santris[history.SantriID]
+			data := PSBStatusUpdatedData{SantriName: santri.FullName, Status: string(history.ToStatus)}
+			if err := s.notifier.Notify(ctx, santri, EventPSBStatusUpdated, data); err != nil {
+				return err
+			}
+		}
+
+		for _, history := range accepts {
+			result.Accepted = append(result.Accepted, dto.SelectionAccepted{SantriID: history.SantriID, NIS: assigned[history.SantriID]})
+		}
+		for _, history := range rejects {
+			result.Rejected = append(result.Rejected, history.SantriID)
+		}
+		return recordActivity(ctx, models.ActionSelection, "admission_wave", &waveID, nil, map[string]interface{}{
+			"accepted":   result.Accepted,
+			"rejected":   result.Rejected,
+			"class":      input.Class,
+			"entry_year": input.EntryYear,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// buildRanking ranks the wave's verified registrants per gender: complete score sets first, then by total
+// score, then by registration time. It also returns the ranked registrants by ID.
+func (s *selectionService) buildRanking(ctx context.Context, waveID uint) (*dto.WaveRankingResponse, map[uint]*models.Santri, error) {
+	wave, err := s.waveRepo.FindByID(ctx, waveID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	components, err := s.repo.FindComponents(ctx, waveID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(components) == 0 {
+		return nil, nil, utils.NewAppError(400, "This wave has no selection rubric yet")
+	}
+
+	candidates, err := s.santriRepo.FindFiltered(ctx, string(models.StatusVerified), waveID)
+	if err != nil {
+		return nil, nil, err
+	}
+	accepted, err := s.santriRepo.FindFiltered(ctx, string(models.StatusAccepted), waveID)
+	if err != nil {
+		return nil, nil, err
+	}
+	scores, err := s.repo.FindScoresByWave(ctx, waveID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scoresBySantri := make(map[uint]map[uint]float64)
+	for _, score := range scores {
+		if scoresBySantri[score.SantriID] == nil {
+			scoresBySantri[score.SantriID] = make(map[uint]float64)
+		}
+		scoresBySantri[score.SantriID][score.ComponentID] = score.Score
+	}
+
+	response := &dto.WaveRankingResponse{
+		WaveID:     wave.ID,
+		WaveName:   wave.Name,
+		Components: make([]dto.RubricSummary, 0, len(components)),
+	}
+	for _, component := range components {
+		response.Components = append(response.Components, dto.RubricSummary{
+			ID:       component.ID,
+			Name:     component.Name,
+			Weight:   component.Weight,
+			MaxScore: component.MaxScore,
+		})
+	}
+
+	santris := make(map[uint]*models.Santri, len(candidates))
+	entries := map[string][]dto.RankingEntry{"L": {}, "P": {}}
+	registeredAt := make(map[uint]int64, len(candidates))
+	for i := range candidates {
+		santri := &candidates[i]
+		santris[santri.ID] = santri
+		registeredAt[santri.ID] = santri.CreatedAt.UnixNano()
+
+		entry := dto.RankingEntry{
+			SantriID:         santri.ID,
+			RegistrationCode: santri.RegistrationCode,
+			FullName:         santri.FullName,
+			Scores:           scoresBySantri[santri.ID],
+			Complete:         true,
+		}
+		if entry.Scores == nil {
+			entry.Scores = map[uint]float64{}
+		}
+		total := 0.0
+		for _, component := range components {
+			score, ok := entry.Scores[component.ID]
+			if !ok {
+				entry.Complete = false
+				continue
+			}
+			total += component.WeightedScore(score)
+		}
+		entry.TotalScore = math.Round(total*100) / 100
+
+		gender := "L"
+		if santri.Gender == "P" {
+			gender = "P"
+		}
+		entries[gender] = append(entries[gender], entry)
+	}
+
+	acceptedCount := map[string]int{"L": 0, "P": 0}
+	for _, santri := range accepted {
+		if santri.Gender == "P" {
+			acceptedCount["P"]++
+		} else {
+			acceptedCount["L"]++
+		}
+	}
+
+	rank := func(gender string) dto.GenderRanking {
+		list := entries[gender]
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].Complete != list[j].Complete {
+				return list[i].Complete
+			}
+			if list[i].TotalScore != list[j].TotalScore {
+				return list[i].TotalScore > list[j].TotalScore
+			}
+			return registeredAt[list[i].SantriID] < registeredAt[list[j].SantriID]
+		})
+
+		quota := wave.QuotaFor(gender)
+		remaining := -1
+		if quota > 0 {
+			remaining = max(quota-acceptedCount[gender], 0)
+		}
+		for i := range list {
+			list[i].Rank = i + 1
+			list[i].WithinQuota = list[i].Complete && (remaining < 0 || i < remaining)
+		}
+
+		return dto.GenderRanking{
+			Quota:     quota,
+			Accepted:  acceptedCount[gender],
+			Remaining: remaining,
+			Entries:   list,
+		}
+	}
+
+	response.Male = rank("L")
+	response.Female = rank("P")
+	return response, santris, nil
+}
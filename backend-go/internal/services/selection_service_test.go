@@ -0,0 +1,125 @@
+package services_test
+
+import (
+	"backend-go/internal/models"
+	"backend-go/internal/repository"
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"context"
+	"testing"
+	"time"
+)
+
+// Manual Mocks for the lookups used by the selection ranking
+type mockSelectionRepository struct {
+	repository.SelectionRepository
+	components []models.SelectionComponent
+	scores     []models.SelectionScore
+}
+
+func (m *mockSelectionRepository) FindComponents(ctx context.Context, waveID uint) ([]models.SelectionComponent, error) {
+	return m.components, nil
+}
+
+func (m *mockSelectionRepository) FindScoresByWave(ctx context.Context, waveID uint) ([]models.SelectionScore, error) {
+	return m.scores, nil
+}
+
+type mockSelectionWaveRepository struct {
+	repository.AdmissionWaveRepository
+	wave models.AdmissionWave
+}
+
+func (m *mockSelectionWaveRepository) FindByID(ctx context.Context, id uint) (*models.AdmissionWave, error) {
+	if id != m.wave.ID {
+		return nil, utils.ErrNotFound
+	}
+	return &m.wave, nil
+}
+
+type mockSelectionSantriRepository struct {
+	repository.SantriRepository
+	santris []models.Santri
+}
+
+func (m *mockSelectionSantriRepository) FindFiltered(ctx context.Context, status string, waveID uint) ([]models.Santri, error) {
+	var result []models.Santri
+	for _, s := range m.santris {
+		if string(s.Status) == status {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func TestSelectionService_GetRanking(t *testing.T) {
+	registered := time.Date(2025, 1, 10, 8, 0, 0, 0, time.UTC)
+	repo := &mockSelectionRepository{
+		components: []models.SelectionComponent{
+			{ID: 1, Name: "Tes Tulis", Weight: 60, MaxScore: 100},
+			{ID: 2, Name: "Hafalan", Weight: 40, MaxScore: 30},
+		},
+		scores: []models.SelectionScore{
+			{SantriID: 1, ComponentID: 1, Score: 80}, {SantriID: 1, ComponentID: 2, Score: 15}, // 48 + 20 = 68
+			{SantriID: 2, ComponentID: 1, Score: 70}, {SantriID: 2, ComponentID: 2, Score: 30}, // 42 + 40 = 82
+			{SantriID: 3, ComponentID: 1, Score: 70}, {SantriID: 3, ComponentID: 2, Score: 30}, // 82, registered later
+			{SantriID: 4, ComponentID: 1, Score: 100}, // missing Hafalan
+			{SantriID: 6, ComponentID: 1, Score: 50}, {SantriID: 6, ComponentID: 2, Score: 10},
+		},
+	}
+	waves := &mockSelectionWaveRepository{wave: models.AdmissionWave{ID: 1, Name: "Gelombang 1", QuotaMale: 3}}
+	santris := &mockSelectionSantriRepository{santris: []models.Santri{
+		{ID: 1, FullName: "Ahmad", Gender: "L", Status: models.StatusVerified, CreatedAt: registered},
+		{ID: 2, FullName: "Budi", Gender: "L", Status: models.StatusVerified, CreatedAt: registered},
+		{ID: 3, FullName: "Candra", Gender: "L", Status: models.StatusVerified, CreatedAt: registered.Add(time.Hour)},
+		{ID: 4, FullName: "Dimas", Gender: "L", Status: models.StatusVerified, CreatedAt: registered},
+		{ID: 5, FullName: "Eko", Gender: "L", Status: models.StatusAccepted, CreatedAt: registered},
+		{ID: 6, FullName: "Fatimah", Gender: "P", Status: models.StatusVerified, CreatedAt: registered},
+	}}
+	svc := services.NewSelectionService(repo, waves, santris, mockTxManager{}, nil)
+
+	ranking, err := svc.GetRanking(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetRanking failed: %v", err)
+	}
+
+	male := ranking.Male
+	if male.Remaining != 2 {
+		t.Errorf("expected 2 remaining male seats, got %d", male.Remaining)
+	}
+	wantOrder := []uint{2, 3, 1, 4}
+	if len(male.Entries) != len(wantOrder) {
+		t.Fatalf("expected %d male entries, got %d", len(wantOrder), len(male.Entries))
+	}
+	for i, id := range wantOrder {
+		if male.Entries[i].SantriID != id {
+			t.Errorf("rank %d: expected registrant %d, got %d", i+1, id, male.Entries[i].SantriID)
+		}
+	}
+	if male.Entries[0].TotalScore != 82 || male.Entries[2].TotalScore != 68 {
+		t.Errorf("unexpected weighted totals: %v and %v", male.Entries[0].TotalScore, male.Entries[2].TotalScore)
+	}
+	if !male.Entries[1].WithinQuota || male.Entries[2].WithinQuota {
+		t.Error("expected only the top 2 male registrants to be within quota")
+	}
+	if male.Entries[3].Complete {
+		t.Error("expected the registrant without a Hafalan score to be incomplete")
+	}
+
+	if ranking.Female.Remaining != -1 || !ranking.Female.Entries[0].WithinQuota {
+		t.Error("expected an unlimited female quota with every complete registrant within it")
+	}
+}
+
+func TestSelectionService_UpdateRubricRequiresFullWeight(t *testing.T) {
+	waves := &mockSelectionWaveRepository{wave: models.AdmissionWave{ID: 1}}
+	svc := services.NewSelectionService(&mockSelectionRepository{}, waves, &mockSelectionSantriRepository{}, mockTxManager{}, nil)
+
+	_, err := svc.UpdateRubric(context.Background(), 1, []models.SelectionComponent{
+		{Name: "Tes Tulis", Weight: 60},
+		{Name: "Wawancara", Weight: 30},
+	})
+	if err == nil {
+		t.Error("expected a rubric whose weights do not add up to 100 to be rejected")
+	}
+}
@@ -1,56 +1,90 @@
 package services
 
 import (
+	"backend-go/internal/logger"
 	"backend-go/internal/models"
 	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
 )
 
-// ActivityLogger is a global instance for logging activities
-// This allows handlers to log without requiring DI changes
-var ActivityLogger ActivityLogService
+// Outbox is a global instance for queueing background jobs
+// This allows handlers to queue work without requiring DI changes
+var Outbox OutboxService
 
-// SetActivityLogger sets the global activity logger
-func SetActivityLogger(logger ActivityLogService) {
-	ActivityLogger = logger
+// SetOutbox sets the global outbox
+func SetOutbox(outbox OutboxService) {
+	Outbox = outbox
 }
 
-// LogActivityAsync logs an activity asynchronously to avoid blocking the request
-func LogActivityAsync(ctx context.Context, userID uint, action models.ActivityAction, entityType string, entityID *uint, oldValue, newValue interface{}, ipAddress, userAgent string) {
-	if ActivityLogger == nil {
-		return
-	}
-	go func() {
-		// Use background context since the original context may be cancelled
-		_ = ActivityLogger.LogActivity(context.Background(), userID, action, entityType, entityID, oldValue, newValue, ipAddress, userAgent)
-	}()
+// actorKey carries the Actor of a request in its context
+type actorKey struct{}
+
+// Actor is the user performing a request, recorded with the activity log entries of their changes
+type Actor struct {
+	UserID uint
+	Client ClientInfo
+}
+
+// WithActor returns a context that records changes made with it as done by userID
+func WithActor(ctx context.Context, userID uint, client ClientInfo) context.Context {
+	return context.WithValue(ctx, actorKey{}, Actor{UserID: userID, Client: client})
 }
 
-// Emailer is a global instance for sending emails
-var Emailer EmailService
+// recordActivity queues an activity log entry for the actor of ctx. Call it inside the TxManager transaction that
+// makes the change, so the entry is committed or rolled back with it. Without an actor nothing is recorded.
+func recordActivity(ctx context.Context, action models.ActivityAction, entityType string, entityID *uint, oldValue, newValue interface{}) error {
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	if !ok || actor.UserID == 0 || Outbox == nil {
+		return nil
+	}
 
-// SetEmailer sets the global email service
-func SetEmailer(emailer EmailService) {
-	Emailer = emailer
+	payload, err := newActivityLogPayload(actor.UserID, action, entityType, entityID, oldValue, newValue, actor.Client.IPAddress, actor.Client.UserAgent)
+	if err != nil {
+		return err
+	}
+	return Outbox.Enqueue(ctx, JobActivityLog, payload)
 }
 
-// SendPSBConfirmationAsync sends PSB confirmation email asynchronously
-func SendPSBConfirmationAsync(to, santriName, registrationID string) {
-	if Emailer == nil {
+// LogActivityAsync queues an activity log entry outside of any transaction, for events that change no other data
+func LogActivityAsync(ctx context.Context, userID uint, action models.ActivityAction, entityType string, entityID *uint, oldValue, newValue interface{}, ipAddress, userAgent string) {
+	if Outbox == nil {
 		return
 	}
-	go func() {
-		_ = Emailer.SendPSBConfirmation(to, santriName, registrationID)
-	}()
+
+	payload, err := newActivityLogPayload(userID, action, entityType, entityID, oldValue, newValue, ipAddress, userAgent)
+	if err == nil {
+		// The log entry should survive the request being cancelled
+		err = Outbox.Enqueue(context.WithoutCancel(ctx), JobActivityLog, payload)
+	}
+	if err != nil {
+		logger.Error("Failed to queue activity log", zap.String("action", string(action)), zap.String("entity", entityType), zap.Error(err))
+	}
 }
 
-// SendStatusUpdateAsync sends status update email asynchronously
-func SendStatusUpdateAsync(to, santriName, status string) {
-	if Emailer == nil {
-		return
+func newActivityLogPayload(userID uint, action models.ActivityAction, entityType string, entityID *uint, oldValue, newValue interface{}, ipAddress, userAgent string) (activityLogPayload, error) {
+	payload := activityLogPayload{
+		UserID:     userID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		IPAddress:  ipAddress,
+		UserAgent:  userAgent,
 	}
-	go func() {
-		_ = Emailer.SendStatusUpdate(to, santriName, status)
-	}()
+	var err error
+	if payload.OldValue, err = marshalValue(oldValue); err == nil {
+		payload.NewValue, err = marshalValue(newValue)
+	}
+	return payload, err
+}
+
+// marshalValue encodes an activity log value, leaving a missing value empty
+func marshalValue(value interface{}) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return json.Marshal(value)
 }
 
 // MediaCleaner is a global instance for cleaning up media files
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"backend-go/internal/dto"
+	"backend-go/internal/logger"
+	"backend-go/internal/models"
+	"backend-go/internal/repository"
+	"backend-go/internal/utils"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Active sessions are cached so the auth middleware does not query the database on every request. Revoking a
+// session removes its cache entry, so the TTL only bounds how long a revocation can be missed if Redis fails.
+const (
+	SessionCachePrefix = "session:active:"
+	SessionCacheTTL    = 5 * time.Minute
+)
+
+// ClientInfo describes the device a session was started from
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+type SessionService interface {
+	Start(ctx context.Context, userID uint, client ClientInfo) (*models.UserSession, error)
+	Extend(ctx context.Context, sessionID string) error
+	IsActive(ctx context.Context, sessionID string) bool
+	GetActiveSessions(ctx context.Context, userID uint, currentID string) ([]dto.SessionResponse, error)
+	Revoke(ctx context.Context, userID uint, sessionID string) error
+	RevokeAll(ctx context.Context, userID uint, exceptID string) (int, error)
+	RevokeOthers(ctx context.Context, userID uint, currentID string) (int, error)
+	RevokeAllForUser(ctx context.Context, userID uint) (int, error)
+	PurgeInactive(ctx context.Context, olderThan time.Duration) (int64, error)
+}
+
+type sessionService struct {
+	repo  repository.UserSessionRepository
+	cache CacheService
+	tx    repository.TxManager
+}
+
+func NewSessionService(repo repository.UserSessionRepository, cache CacheService, tx repository.TxManager) SessionService {
+	return &sessionService{repo, cache, tx}
+}
+
+// Start registers a new login. It lasts as long as a refresh token and is extended on every refresh.
+func (s *sessionService) Start(ctx context.Context, userID uint, client ClientInfo) (*models.UserSession, error) {
+	now := time.Now()
+	userAgent := client.UserAgent
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+
+	session := &models.UserSession{
+		ID:         uuid.NewString(),
+		UserID:     userID,
+		IPAddress:  client.IPAddress,
+		UserAgent:  userAgent,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(RefreshTokenExpiry),
+	}
+	if err := s.repo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (s *sessionService) Extend(ctx context.Context, sessionID string) error {
+	now := time.Now()
+	return s.repo.Touch(ctx, sessionID, now, now.Add(RefreshTokenExpiry))
+}
+
+// IsActive reports whether a token's session is still valid. Tokens without a session ID are not.
+func (s *sessionService) IsActive(ctx context.Context, sessionID string) bool {
+	if sessionID == "" {
+		return false
+	}
+
+	key := SessionCachePrefix + sessionID
+	var active bool
+	if err := s.cache.Get(key, &active); err == nil && active {
+		return true
+	}
+
+	session, err := s.repo.FindByID(ctx, sessionID)
+	if err != nil {
+		if !errors.Is(err, utils.ErrNotFound) {
+			logger.Error("Failed to check session", zap.String("session_id", sessionID), zap.Error(err))
+		}
+		return false
+	}
+	if !session.IsActive(time.Now()) {
+		return false
+	}
+
+	ttl := SessionCacheTTL
+	if remaining := time.Until(session.ExpiresAt); remaining < ttl {
+		ttl = remaining
+	}
+	_ = s.cache.Set(key, true, ttl)
+	return true
+}
+
+func (s *sessionService) GetActiveSessions(ctx context.Context, userID uint, currentID string) ([]dto.SessionResponse, error) {
+	sessions, err := s.repo.FindActiveByUser(ctx, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		result[i] = dto.SessionResponse{
+			ID:         session.ID,
+			IPAddress:  session.IPAddress,
+			UserAgent:  session.UserAgent,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == currentID,
+		}
+	}
+	return result, nil
+}
+
+// Revoke ends one of the user's own sessions. Sessions of other users are reported as not found.
+func (s *sessionService) Revoke(ctx context.Context, userID uint, sessionID string) error {
+	session, err := s.repo.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID || !session.IsActive(time.Now()) {
+		return utils.ErrNotFound
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Revoke(ctx, sessionID, time.Now()); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionRevoke, "session", nil, nil, map[string]string{"session_id": sessionID})
+	})
+	if err != nil {
+		return err
+	}
+	_ = s.cache.Delete(SessionCachePrefix + sessionID)
+	return nil
+}
+
+// RevokeAll ends every session of the user except exceptID, which may be empty, and returns how many it ended.
+// It is part of other changes, such as a new password, so it records nothing in the activity log itself.
+func (s *sessionService) RevokeAll(ctx context.Context, userID uint, exceptID string) (int, error) {
+	return s.revokeAll(ctx, userID, exceptID, func(ctx context.Context, revoked int) error { return nil })
+}
+
+// RevokeOthers ends the user's sessions except the current one
+func (s *sessionService) RevokeOthers(ctx context.Context, userID uint, currentID string) (int, error) {
+	return s.revokeAll(ctx, userID, currentID, func(ctx context.Context, revoked int) error {
+		if revoked == 0 {
+			return nil
+		}
+		return recordActivity(ctx, models.ActionRevoke, "session", nil, nil, map[string]int{"revoked": revoked})
+	})
+}
+
+// RevokeAllForUser ends every session of another admin
+func (s *sessionService) RevokeAllForUser(ctx context.Context, userID uint) (int, error) {
+	return s.revokeAll(ctx, userID, "", func(ctx context.Context, revoked int) error {
+		return recordActivity(ctx, models.ActionRevoke, "user_sessions", &userID, nil, map[string]int{"revoked": revoked})
+	})
+}
+
+// revokeAll ends the sessions and calls record in the same transaction. Cached sessions are removed once it commits.
+func (s *sessionService) revokeAll(ctx context.Context, userID uint, exceptID string, record func(ctx context.Context, revoked int) error) (int, error) {
+	var ids []string
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if ids, err = s.repo.RevokeByUser(ctx, userID, exceptID, time.Now()); err != nil {
+			return err
+		}
+		return record(ctx, len(ids))
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range ids {
+		_ = s.cache.Delete(SessionCachePrefix + id)
+	}
+	return len(ids), nil
+}
+
+// PurgeInactive removes sessions that expired or were revoked more than olderThan ago
+func (s *sessionService) PurgeInactive(ctx context.Context, olderThan time.Duration) (int64, error) {
+	return s.repo.DeleteInactiveBefore(ctx, time.Now().Add(-olderThan))
+}
@@ -0,0 +1,177 @@
+package services_test
+
+import (
+	"backend-go/config"
+	"backend-go/internal/models"
+	"backend-go/internal/services"
+	"backend-go/internal/utils"
+	"context"
+	"testing"
+	"time"
+)
+
+// Manual Mock for UserSessionRepository
+type mockSessionRepository struct {
+	sessions map[string]*models.UserSession
+	lookups  int
+}
+
+func newMockSessionRepo() *mockSessionRepository {
+	return &mockSessionRepository{sessions: make(map[string]*models.UserSession)}
+}
+
+func newMockSessions() services.SessionService {
+	return services.NewSessionService(newMockSessionRepo(), newMockCache(), mockTxManager{})
+}
+
+func (m *mockSessionRepository) Create(ctx context.Context, session *models.UserSession) error {
+	session.CreatedAt = time.Now()
+	m.sessions[session.ID] = session
+	return nil
+}
+
+func (m *mockSessionRepository) FindByID(ctx context.Context, id string) (*models.UserSession, error) {
+	m.lookups++
+	session, ok := m.sessions[id]
+	if !ok {
+		return nil, utils.ErrNotFound
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (m *mockSessionRepository) FindActiveByUser(ctx context.Context, userID uint, now time.Time) ([]models.UserSession, error) {
+	var result []models.UserSession
+	for _, session := range m.sessions {
+		if session.UserID == userID && session.IsActive(now) {
+			result = append(result, *session)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockSessionRepository) Touch(ctx context.Context, id string, lastUsedAt, expiresAt time.Time) error {
+	if session, ok := m.sessions[id]; ok {
+		session.LastUsedAt = lastUsedAt
+		session.ExpiresAt = expiresAt
+	}
+	return nil
+}
+
+func (m *mockSessionRepository) Revoke(ctx context.Context, id string, now time.Time) error {
+	if session, ok := m.sessions[id]; ok && session.RevokedAt == nil {
+		session.RevokedAt = &now
+	}
+	return nil
+}
+
+func (m *mockSessionRepository) RevokeByUser(ctx context.Context, userID uint, exceptID string, now time.Time) ([]string, error) {
+	var ids []string
+	for id, session := range m.sessions {
+		if session.UserID == userID && id != exceptID && session.IsActive(now) {
+			session.RevokedAt = &now
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (m *mockSessionRepository) DeleteInactiveBefore(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	for id, session := range m.sessions {
+		if (session.RevokedAt != nil && session.RevokedAt.Before(before)) || session.ExpiresAt.Before(before) {
+			delete(m.sessions, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func TestSessionService_Revoke(t *testing.T) {
+	repo := newMockSessionRepo()
+	service := services.NewSessionService(repo, newMockCache(), mockTxManager{})
+	ctx := context.Background()
+
+	phone, _ := service.Start(ctx, 1, services.ClientInfo{IPAddress: "10.0.0.1", UserAgent: "Phone"})
+	laptop, _ := service.Start(ctx, 1, services.ClientInfo{IPAddress: "10.0.0.2", UserAgent: "Laptop"})
+	other, _ := service.Start(ctx, 2, services.ClientInfo{})
+
+	if !service.IsActive(ctx, phone.ID) {
+		t.Fatal("expected a new session to be active")
+	}
+	lookups := repo.lookups
+	service.IsActive(ctx, phone.ID)
+	if repo.lookups != lookups {
+		t.Error("expected an active session to be answered from the cache")
+	}
+	if service.IsActive(ctx, "") {
+		t.Error("expected a token without a session to be rejected")
+	}
+
+	sessions, _ := service.GetActiveSessions(ctx, 1, laptop.ID)
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
+	}
+	for _, session := range sessions {
+		if session.Current != (session.ID == laptop.ID) {
+			t.Errorf("expected only the laptop session to be current, got %+v", session)
+		}
+	}
+
+	if err := service.Revoke(ctx, 1, other.ID); err != utils.ErrNotFound {
+		t.Errorf("expected another user's session to be not found, got %v", err)
+	}
+	if err := service.Revoke(ctx, 1, phone.ID); err != nil {
+		t.Fatalf("revoke failed: %v", err)
+	}
+	if service.IsActive(ctx, phone.ID) {
+		t.Error("expected a revoked session to be inactive despite the cache")
+	}
+
+	revoked, err := service.RevokeAll(ctx, 1, "")
+	if err != nil || revoked != 1 {
+		t.Errorf("expected to revoke the laptop session only, got %d, %v", revoked, err)
+	}
+	if service.IsActive(ctx, laptop.ID) || !service.IsActive(ctx, other.ID) {
+		t.Error("expected only the other user's session to remain active")
+	}
+}
+
+func TestAuthService_Sessions(t *testing.T) {
+	config.AppConfig.JWTSecret = "supersecret"
+
+	sessions := services.NewSessionService(newMockSessionRepo(), newMockCache(), mockTxManager{})
+	service := services.NewAuthService(newMockRepo(), newMockRoles(nil), newMockRecoveryCodes(), sessions, newMockCache(), mockTxManager{}, &mockEmailNotifier{})
+	ctx := context.Background()
+
+	service.RegisterAdmin(ctx, "user1", "CorrectPass1", "", "")
+	phone, err := service.Login(ctx, "user1", "CorrectPass1", services.ClientInfo{UserAgent: "Phone"})
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	laptop, _ := service.Login(ctx, "user1", "CorrectPass1", services.ClientInfo{UserAgent: "Laptop"})
+
+	active, _ := sessions.GetActiveSessions(ctx, phone.User.ID, "")
+	if len(active) != 2 {
+		t.Fatalf("expected a session per login, got %d", len(active))
+	}
+
+	if _, err := service.RefreshToken(ctx, phone.RefreshToken); err != nil {
+		t.Errorf("expected refresh to work for an active session, got %v", err)
+	}
+
+	if err := service.Logout(ctx, laptop.RefreshToken, services.ClientInfo{}); err != nil {
+		t.Fatalf("logout failed: %v", err)
+	}
+	active, _ = sessions.GetActiveSessions(ctx, phone.User.ID, "")
+	if len(active) != 1 || active[0].UserAgent != "Phone" {
+		t.Errorf("expected logout to end only its own session, got %+v", active)
+	}
+
+	if _, err := sessions.RevokeAll(ctx, phone.User.ID, ""); err != nil {
+		t.Fatalf("revoke all failed: %v", err)
+	}
+	if _, err := service.RefreshToken(ctx, phone.RefreshToken); err == nil {
+		t.Error("expected refresh to fail once the session is revoked")
+	}
+}
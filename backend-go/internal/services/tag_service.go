@@ -20,16 +20,22 @@ type TagService interface {
 
 type tagService struct {
 	repo repository.TagRepository
+	tx   repository.TxManager
 }
 
-func NewTagService(repo repository.TagRepository) TagService {
-	return &tagService{repo}
+func NewTagService(repo repository.TagRepository, tx repository.TxManager) TagService {
+	return &tagService{repo, tx}
 }
 
 func (s *tagService) CreateTag(ctx context.Context, tag *models.Tag) error {
 	// Generate slug from name
 	tag.Slug = generateTagSlug(tag.Name)
-	return s.repo.Create(ctx, tag)
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, tag); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionCreate, "tag", &tag.ID, nil, tag)
+	})
 }
 
 func (s *tagService) GetAllTags(ctx context.Context) ([]models.Tag, error) {
@@ -59,7 +65,12 @@ func (s *tagService) UpdateTag(ctx context.Context, id uint, data *models.Tag) e
 		existing.Slug = generateTagSlug(data.Name)
 	}
 
-	return s.repo.Update(ctx, existing)
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, existing); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionUpdate, "tag", &id, nil, data)
+	})
 }
 
 func (s *tagService) DeleteTag(ctx context.Context, id uint) error {
@@ -67,7 +78,12 @@ func (s *tagService) DeleteTag(ctx context.Context, id uint) error {
 	if err != nil {
 		return err
 	}
-	return s.repo.Delete(ctx, id)
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionDelete, "tag", &id, nil, nil)
+	})
 }
 
 // generateTagSlug creates a URL-friendly slug from a string
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"backend-go/internal/dto"
+	"backend-go/internal/logger"
+	"backend-go/internal/models"
+	"backend-go/internal/repository"
+	"backend-go/internal/utils"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// testSessionReminderLead is how long before a session starts the reminder email goes out
+const testSessionReminderLead = 24 * time.Hour
+
+type TestSessionService interface {
+	CreateSession(ctx context.Context, session *models.TestSession) error
+	GetSessions(ctx context.Context, waveID uint, kind string) ([]models.TestSession, error)
+	GetSessionByID(ctx context.Context, id uint) (*models.TestSession, error)
+	UpdateSession(ctx context.Context, id uint, data *models.TestSession) (*models.TestSession, error)
+	DeleteSession(ctx context.Context, id uint) error
+	AutoAssign(ctx context.Context, waveID uint, kind models.TestSessionKind) (*dto.AutoAssignResult, error)
+	Assign(ctx context.Context, sessionID, santriID uint) (*models.TestSessionAssignment, error)
+	Unassign(ctx context.Context, sessionID, santriID uint) error
+	ExportCalendar(ctx context.Context, id uint) ([]byte, string, error)
+	SendDueReminders(ctx context.Context, now time.Time) (int, error)
+}
+
+type testSessionService struct {
+	repo     repository.TestSessionRepository
+	waveRepo repository.AdmissionWaveRepository
+	tx       repository.TxManager
+	notifier NotificationService
+}
+
+func NewTestSessionService(repo repository.TestSessionRepository, waveRepo repository.AdmissionWaveRepository, tx repository.TxManager, notifier NotificationService) TestSessionService {
+	return &testSessionService{repo, waveRepo, tx, notifier}
+}
+
+func (s *testSessionService) CreateSession(ctx context.Context, session *models.TestSession) error {
+	if _, err := s.waveRepo.FindByID(ctx, session.WaveID); err != nil {
+		return err
+	}
+	if !session.EndsAt.After(session.StartsAt) {
+		return utils.NewAppError(400, "End time must be after start time")
+	}
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, session); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionCreate, "test_session", &session.ID, nil, session)
+	})
+}
+
+func (s *testSessionService) GetSessions(ctx context.Context, waveID uint, kind string) ([]models.TestSession, error) {
+	return s.repo.FindAll(ctx, waveID, kind)
+}
+
+func (s *testSessionService) GetSessionByID(ctx context.Context, id uint) (*models.TestSession, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *testSessionService) UpdateSession(ctx context.Context, id uint, data *models.TestSession) (*models.TestSession, error) {
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !data.EndsAt.After(data.StartsAt) {
+		return nil, utils.NewAppError(400, "End time must be after start time")
+	}
+
+	existing.Kind = data.Kind
+	existing.Name = data.Name
+	existing.StartsAt = data.StartsAt
+	existing.EndsAt = data.EndsAt
+	existing.Room = data.Room
+	existing.Capacity = data.Capacity
+	existing.Gender = data.Gender
+	existing.Examiner = data.Examiner
+	existing.Notes = data.Notes
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, existing); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionUpdate, "test_session", &id, nil, existing)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (s *testSessionService) DeleteSession(ctx context.Context, id uint) error {
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionDelete, "test_session", &id, nil, nil)
+	})
+}
+
+func (s *testSessionService) AutoAssign(ctx context.Context, waveID uint, kind models.TestSessionKind) (*dto.AutoAssignResult, error) {
+	if _, err := s.waveRepo.FindByID(ctx, waveID); err != nil {
+		return nil, err
+	}
+
+	var assigned []models.TestSessionAssignment
+	var unplaced []models.Santri
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if assigned, unplaced, err = s.repo.AutoAssign(ctx, waveID, kind, time.Now()); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionUpdate, "test_session_assignment", &waveID, nil, map[string]interface{}{
+			"kind":     kind,
+			"assigned": len(assigned),
+			"unplaced": len(unplaced),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.AutoAssignResult{
+		Assigned: len(assigned),
+		Unplaced: make([]dto.UnplacedRegistrant, 0, len(unplaced)),
+	}
+	for _, santri := range unplaced {
+		result.Unplaced = append(result.Unplaced, dto.UnplacedRegistrant{
+			SantriID:         santri.ID,
+			RegistrationCode: santri.RegistrationCode,
+			FullName:         santri.FullName,
+			Gender:           santri.Gender,
+		})
+	}
+	return result, nil
+}
+
+func (s *testSessionService) Assign(ctx context.Context, sessionID, santriID uint) (*models.TestSessionAssignment, error) {
+	var assignment *models.TestSessionAssignment
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if assignment, err = s.repo.Assign(ctx, sessionID, santriID); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionCreate, "test_session_assignment", &assignment.ID, nil, assignment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+func (s *testSessionService) Unassign(ctx context.Context, sessionID, santriID uint) error {
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Unassign(ctx, sessionID, santriID); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionDelete, "test_session_assignment", &sessionID, map[string]interface{}{"santri_id": santriID}, nil)
+	})
+}
+
+// ExportCalendar renders the session as an iCalendar file listing the assigned registrants, and returns its file name
+func (s *testSessionService) ExportCalendar(ctx context.Context, id uint) ([]byte, string, error) {
+	session, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var description strings.Builder
+	if session.Examiner != "" {
+		fmt.Fprintf(&description, "Penguji: %s\n", session.Examiner)
+	}
+	if session.Notes != "" {
+		fmt.Fprintf(&description, "%s\n", session.Notes)
+	}
+	fmt.Fprintf(&description, "Peserta (%d/%d):\n", len(session.Assignments), session.Capacity)
+	for i, assignment := range session.Assignments {
+		if assignment.Santri == nil {
+			continue
+		}
+		fmt.Fprintf(&description, "%d. %s (%s)\n", i+1, assignment.Santri.FullName, assignment.Santri.RegistrationCode)
+	}
+
+	event := utils.ICalEvent{
+		UID:         fmt.Sprintf("test-session-%d@k3arafah", session.ID),
+		Summary:     session.Name,
+		Description: strings.TrimSpace(description.String()),
+		Location:    session.Room,
+		Start:       session.StartsAt,
+		End:         session.EndsAt,
+	}
+	ics := utils.BuildICalendar("-//Pondok Pesantren K3 Arafah//PSB//ID", []utils.ICalEvent{event}, session.UpdatedAt)
+
+	return ics, fmt.Sprintf("test-session-%d.ics", session.ID), nil
+}
+
+// SendDueReminders queues reminders to the parents of registrants whose session starts within the next day and
+// returns how many were queued. Reminders that could not be queued are tried again on the next run.
+func (s *testSessionService) SendDueReminders(ctx context.Context, now time.Time) (int, error) {
+	assignments, err := s.repo.FindDueReminders(ctx, now, now.Add(testSessionReminderLead))
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, assignment := range assignments {
+		if assignment.Session == nil || assignment.Santri == nil {
+			continue
+		}
+
+		data := TestSessionReminderData{
+			SantriName:  assignment.Santri.FullName,
+			SessionName: assignment.Session.Name,
+			Room:        assignment.Session.Room,
+			StartsAt:    assignment.Session.StartsAt,
+		}
+		// The reminder is claimed before it is queued, so another worker running at the same time skips it
+		claimed := false
+		err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			if claimed, err = s.repo.ClaimReminder(ctx, assignment.ID, now); err != nil || !claimed {
+				return err
+			}
+			return s.notifier.Notify(ctx, assignment.Santri, EventTestSessionReminder, data)
+		})
+		if err != nil {
+			logger.Warn("Failed to queue test session reminder",
+				zap.Uint("assignment_id", assignment.ID), zap.Error(err))
+			continue
+		}
+		if claimed {
+			sent++
+		}
+	}
+	return sent, nil
+}
@@ -0,0 +1,78 @@
+package services_test
+
+import (
+	"backend-go/internal/logger"
+	"backend-go/internal/models"
+	"backend-go/internal/repository"
+	"backend-go/internal/services"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Manual Mock for the reminder lookups of the TestSessionRepository
+type mockTestSessionRepository struct {
+	repository.TestSessionRepository
+	due      []models.TestSessionAssignment
+	from, to time.Time
+	claimed  map[uint]bool
+}
+
+func (m *mockTestSessionRepository) FindDueReminders(ctx context.Context, from, to time.Time) ([]models.TestSessionAssignment, error) {
+	m.from, m.to = from, to
+	return m.due, nil
+}
+
+func (m *mockTestSessionRepository) ClaimReminder(ctx context.Context, id uint, at time.Time) (bool, error) {
+	if m.claimed[id] {
+		return false, nil
+	}
+	m.claimed[id] = true
+	return true, nil
+}
+
+// Manual Mock for NotificationService that fails for one registrant
+type mockReminderNotifier struct {
+	services.NotificationService
+	failFor string
+	sent    []string
+}
+
+func (m *mockReminderNotifier) Notify(ctx context.Context, santri *models.Santri, event string, data interface{}) error {
+	if santri.FullName == m.failFor {
+		return errors.New("database unavailable")
+	}
+	m.sent = append(m.sent, santri.FullName)
+	return nil
+}
+
+func TestTestSessionService_SendDueReminders(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	now := time.Date(2025, 3, 9, 8, 0, 0, 0, time.UTC)
+	session := &models.TestSession{ID: 1, Name: "Tes Tulis", Room: "Ruang 1", StartsAt: now.Add(20 * time.Hour)}
+	repo := &mockTestSessionRepository{due: []models.TestSessionAssignment{
+		{ID: 1, Session: session, Santri: &models.Santri{FullName: "Ahmad", ParentEmail: "ahmad@example.com"}},
+		{ID: 2, Session: session, Santri: &models.Santri{FullName: "Budi", ParentPhone: "081234567890"}},
+		{ID: 3, Session: session, Santri: &models.Santri{FullName: "Citra", ParentEmail: "citra@example.com"}},
+	}}
+	// Another worker got to this one first
+	repo.claimed = map[uint]bool{3: true}
+	notifier := &mockReminderNotifier{failFor: "Budi"}
+	svc := services.NewTestSessionService(repo, nil, mockTxManager{}, notifier)
+
+	sent, err := svc.SendDueReminders(context.Background(), now)
+	if err != nil {
+		t.Fatalf("SendDueReminders failed: %v", err)
+	}
+
+	if sent != 1 || len(notifier.sent) != 1 || notifier.sent[0] != "Ahmad" {
+		t.Errorf("expected only the reminder claimed by this run to be queued, got %d sent to %v", sent, notifier.sent)
+	}
+	if !repo.from.Equal(now) || !repo.to.Equal(now.Add(24*time.Hour)) {
+		t.Errorf("expected reminders for sessions in the next 24 hours, got %s - %s", repo.from, repo.to)
+	}
+}
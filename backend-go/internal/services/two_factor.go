@@ -0,0 +1,350 @@
+package services
+
+import (
+	"backend-go/config"
+	"backend-go/internal/dto"
+	"backend-go/internal/models"
+	"backend-go/internal/utils"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/skip2/go-qrcode"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TOTP two-factor authentication (RFC 6238) for admin accounts. A login with a password only yields a challenge
+// token kept in Redis; the token pair is issued once the challenge is completed with a TOTP or recovery code.
+const (
+	LoginChallengeExpiry = 5 * time.Minute
+	LoginChallengePrefix = "auth:challenge:"
+	TOTPSetupExpiry      = 10 * time.Minute
+	TOTPPendingPrefix    = "auth:totp:pending:" // secret shown during setup, saved once a code confirms it
+	TOTPUsedPrefix       = "auth:totp:used:"    // codes already used, so one cannot be replayed within its window
+
+	totpIssuer            = "K3 Arafah"
+	maxChallengeAttempts  = 5
+	recoveryCodeCount     = 10
+	recoveryCodeAlphabet  = "0123456789abcdefghjkmnpqrstvwxyz" // Crockford's base32, without i, l, o and u
+	recoveryCodeHalfChars = 5
+)
+
+// loginChallenge is stored under the hash of the challenge token
+type loginChallenge struct {
+	UserID    uint      `json:"user_id"`
+	Attempts  int       `json:"attempts"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+var errInvalidChallenge = utils.NewAppError(401, "Invalid or expired login challenge")
+
+// twoFactorSetupRequired reports whether the user has to enroll before using anything else
+func twoFactorSetupRequired(user *models.User) bool {
+	return config.AppConfig.RequireSuperAdmin2FA && user.Role == models.RoleSuperAdmin && !user.TOTPEnabled
+}
+
+func (s *authService) createLoginChallenge(userID uint) (string, error) {
+	if !s.cache.IsAvailable() {
+		return "", utils.NewAppError(503, "Two-factor login is temporarily unavailable")
+	}
+	token, err := generateRandomToken()
+	if err != nil {
+		return "", err
+	}
+	challenge := loginChallenge{UserID: userID, ExpiresAt: time.Now().Add(LoginChallengeExpiry)}
+	if err := s.cache.Set(LoginChallengePrefix+hashToken(token), challenge, LoginChallengeExpiry); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// VerifyTwoFactor completes a login challenge with a TOTP code or a recovery code and reports whether a recovery
+// code was used. A challenge can be completed once and allows a few wrong codes before it has to be started over.
+// Wrong codes count as failed logins of the account, so they lead to the same delays and lockout as wrong passwords.
+func (s *authService) VerifyTwoFactor(ctx context.Context, challengeToken, code string, client ClientInfo) (*dto.TokenPair, bool, error) {
+	key := LoginChallengePrefix + hashToken(challengeToken)
+
+	// Taken out of the cache so two requests cannot complete the same challenge
+	var challenge loginChallenge
+	if err := s.cache.GetDel(key, &challenge); err != nil || challenge.UserID == 0 {
+		return nil, false, errInvalidChallenge
+	}
+
+	user, err := s.repo.FindByID(ctx, challenge.UserID)
+	if err != nil || !user.TOTPEnabled {
+		return nil, false, errInvalidChallenge
+	}
+
+	if err := s.checkLoginAllowed(user.Username, client.IPAddress); err != nil {
+		// A delay can be waited out with the same challenge; a lockout ends it
+		if err == errLoginDelayed {
+			s.keepChallenge(key, challenge)
+		}
+		return nil, false, err
+	}
+
+	recovery, ok, err := s.checkSecondFactor(ctx, user, code)
+	if err != nil {
+		return nil, false, err
+	}
+	if !ok {
+		s.recordLoginFailure(ctx, user.Username, user, client)
+		challenge.Attempts++
+		if challenge.Attempts < maxChallengeAttempts {
+			s.keepChallenge(key, challenge)
+		}
+		return nil, false, utils.NewAppError(401, "Invalid authentication code")
+	}
+	s.clearLoginFailures(user.Username)
+
+	tokenPair, err := s.startSession(ctx, user, client, recovery)
+	return tokenPair, recovery, err
+}
+
+// keepChallenge puts a challenge back for another try until it expires
+func (s *authService) keepChallenge(key string, challenge loginChallenge) {
+	if remaining := time.Until(challenge.ExpiresAt); remaining > 0 {
+		_ = s.cache.Set(key, challenge, remaining)
+	}
+}
+
+// SetupTwoFactor creates a TOTP secret for the user to add to an authenticator app. It is only saved once
+// EnableTwoFactor confirms a code from the app.
+func (s *authService) SetupTwoFactor(ctx context.Context, userID uint) (*dto.TwoFactorSetupResponse, error) {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, utils.NewAppError(409, "Two-factor authentication is already enabled")
+	}
+	if !s.cache.IsAvailable() {
+		return nil, utils.NewAppError(503, "Two-factor setup is temporarily unavailable")
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{Issuer: totpIssuer, AccountName: user.Username})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cache.Set(fmt.Sprintf("%s%d", TOTPPendingPrefix, user.ID), key.Secret(), TOTPSetupExpiry); err != nil {
+		return nil, err
+	}
+
+	qr, err := qrcode.Encode(key.URL(), qrcode.Medium, 256)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TwoFactorSetupResponse{
+		Secret:     key.Secret(),
+		OTPAuthURL: key.URL(),
+		QRCode:     "data:image/png;base64," + base64.StdEncoding.EncodeToString(qr),
+	}, nil
+}
+
+// EnableTwoFactor saves the secret from SetupTwoFactor once the code proves the app has it, and returns the
+// recovery codes together with tokens that no longer require enrollment
+func (s *authService) EnableTwoFactor(ctx context.Context, userID uint, sessionID, code string) (*dto.TwoFactorEnabledResponse, error) {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, utils.NewAppError(409, "Two-factor authentication is already enabled")
+	}
+
+	pendingKey := fmt.Sprintf("%s%d", TOTPPendingPrefix, user.ID)
+	var secret string
+	if err := s.cache.Get(pendingKey, &secret); err != nil || secret == "" {
+		return nil, utils.NewAppError(400, "Two-factor setup has expired, please start again")
+	}
+	if !totp.Validate(strings.TrimSpace(code), secret) {
+		return nil, utils.NewAppError(400, "Invalid authentication code")
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	user.TOTPSecret = secret
+	user.TOTPEnabled = true
+	user.TOTPEnabledAt = &now
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateUser(ctx, user); err != nil {
+			return err
+		}
+		if err := s.recoveryCodes.Replace(ctx, user.ID, hashes); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionTwoFactorEnable, "auth", nil, nil, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	_ = s.cache.Delete(pendingKey)
+
+	tokenPair, err := s.generateTokenPair(ctx, user, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.TwoFactorEnabledResponse{TokenPair: tokenPair, RecoveryCodes: codes}, nil
+}
+
+// DisableTwoFactor turns two-factor authentication off after checking the password and a code. Super admins
+// cannot turn it off while it is required for them.
+func (s *authService) DisableTwoFactor(ctx context.Context, userID uint, password, code string) error {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled {
+		return utils.NewAppError(400, "Two-factor authentication is not enabled")
+	}
+	if config.AppConfig.RequireSuperAdmin2FA && user.Role == models.RoleSuperAdmin {
+		return utils.NewAppError(400, "Two-factor authentication is required for super admins")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return utils.NewAppError(400, "Current password is incorrect")
+	}
+	if _, ok, err := s.checkSecondFactor(ctx, user, code); err != nil {
+		return err
+	} else if !ok {
+		return utils.NewAppError(400, "Invalid authentication code")
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.clearTwoFactor(ctx, user); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionTwoFactorDisable, "auth", nil, nil, nil)
+	})
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes, used or not
+func (s *authService) RegenerateRecoveryCodes(ctx context.Context, userID uint, code string) ([]string, error) {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TOTPEnabled {
+		return nil, utils.NewAppError(400, "Two-factor authentication is not enabled")
+	}
+	if _, ok, err := s.checkSecondFactor(ctx, user, code); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, utils.NewAppError(400, "Invalid authentication code")
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.recoveryCodes.Replace(ctx, user.ID, hashes); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionUpdate, "recovery_codes", nil, nil, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// ResetTwoFactor turns two-factor authentication off for an admin who lost their authenticator and recovery codes,
+// and ends their sessions in case the device was stolen
+func (s *authService) ResetTwoFactor(ctx context.Context, id uint) error {
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled {
+		return utils.NewAppError(400, "Two-factor authentication is not enabled")
+	}
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.clearTwoFactor(ctx, user); err != nil {
+			return err
+		}
+		if _, err := s.sessions.RevokeAll(ctx, user.ID, ""); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionTwoFactorDisable, "user", &id, nil, nil)
+	})
+}
+
+func (s *authService) clearTwoFactor(ctx context.Context, user *models.User) error {
+	user.TOTPSecret = ""
+	user.TOTPEnabled = false
+	user.TOTPEnabledAt = nil
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateUser(ctx, user); err != nil {
+			return err
+		}
+		return s.recoveryCodes.DeleteByUser(ctx, user.ID)
+	})
+}
+
+// checkSecondFactor accepts a 6-digit TOTP code or an unused recovery code, which it spends. It reports whether
+// the code was a recovery code.
+func (s *authService) checkSecondFactor(ctx context.Context, user *models.User, code string) (bool, bool, error) {
+	code = strings.TrimSpace(code)
+	if isTOTPCode(code) {
+		return false, s.validateTOTP(user, code), nil
+	}
+
+	used, err := s.recoveryCodes.Use(ctx, user.ID, hashToken(normalizeRecoveryCode(code)), time.Now())
+	return true, used, err
+}
+
+func (s *authService) validateTOTP(user *models.User, code string) bool {
+	if !totp.Validate(code, user.TOTPSecret) {
+		return false
+	}
+	// With the default skew a code is accepted for up to 90 seconds. Claiming it in one step means two requests
+	// cannot both use it; without Redis the code cannot be claimed and is refused.
+	key := fmt.Sprintf("%s%d:%s", TOTPUsedPrefix, user.ID, code)
+	claimed, err := s.cache.SetNX(key, true, 90*time.Second)
+	return err == nil && claimed
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != 6 {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// generateRecoveryCodes returns codes formatted as xxxxx-xxxxx and the hashes to store
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 2*recoveryCodeHalfChars)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		for j := range b {
+			b[j] = recoveryCodeAlphabet[b[j]&31]
+		}
+		codes[i] = string(b[:recoveryCodeHalfChars]) + "-" + string(b[recoveryCodeHalfChars:])
+		hashes[i] = hashToken(normalizeRecoveryCode(codes[i]))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode ignores case, spaces and dashes so codes can be typed as read
+func normalizeRecoveryCode(code string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(code))
+}
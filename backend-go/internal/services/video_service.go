@@ -15,15 +15,26 @@ type VideoService interface {
 }
 
 type videoService struct {
-	repo repository.VideoRepository
+	repo  repository.VideoRepository
+	cache CacheService
+	tx    repository.TxManager
 }
 
-func NewVideoService(repo repository.VideoRepository) VideoService {
-	return &videoService{repo}
+func NewVideoService(repo repository.VideoRepository, cache CacheService, tx repository.TxManager) VideoService {
+	return &videoService{repo, cache, tx}
 }
 
 func (s *videoService) CreateVideo(ctx context.Context, video *models.Video) error {
-	return s.repo.Create(ctx, video)
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, video); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionCreate, "video", &video.ID, nil, video)
+	})
+	if err == nil {
+		invalidateSearchCache(s.cache)
+	}
+	return err
 }
 
 func (s *videoService) GetAllVideos(ctx context.Context) ([]models.Video, error) {
@@ -51,9 +62,27 @@ func (s *videoService) UpdateVideo(ctx context.Context, id uint, data *models.Vi
 		existing.Thumbnail = data.Thumbnail
 	}
 
-	return s.repo.Update(ctx, existing)
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, existing); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionUpdate, "video", &id, nil, data)
+	})
+	if err == nil {
+		invalidateSearchCache(s.cache)
+	}
+	return err
 }
 
 func (s *videoService) DeleteVideo(ctx context.Context, id uint) error {
-	return s.repo.Delete(ctx, id)
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return recordActivity(ctx, models.ActionDelete, "video", &id, nil, nil)
+	})
+	if err == nil {
+		invalidateSearchCache(s.cache)
+	}
+	return err
 }
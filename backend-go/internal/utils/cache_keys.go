@@ -3,6 +3,13 @@ package utils
 const (
 	// Article Cache Keys
 	CacheKeyArticlesAll         = "articles:all"
-	CacheKeyArticlesIDPattern   = "articles:id:%d"   // Use with fmt.Sprintf
-	CacheKeyArticlesSlugPattern = "articles:slug:%s" // Use with fmt.Sprintf
+	CacheKeyArticlesIDPattern   = "articles:id:%d"      // Use with fmt.Sprintf
+	CacheKeyArticlesSlugPattern = "articles:slug:%s"    // Use with fmt.Sprintf
+	CacheKeyArticlesFeedPattern = "articles:feed:%s:%s" // scope (all, category:<slug>, tag:<slug>), format
+
+	// Search Cache Keys
+	CacheKeySearchPattern = "search:%s:page:%d:limit:%d:q:%s" // type, page, limit, normalized query
+
+	// Idempotency Keys
+	CacheKeyIdempotencyPattern = "idempotency:%v:%s:%s" // user ID, route, Idempotency-Key
 )
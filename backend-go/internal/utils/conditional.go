@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// NotModified reports whether a GET request's validators show that the client already has the current response.
+// If-None-Match takes precedence over If-Modified-Since, as in RFC 9110. A zero lastModified never matches.
+func NotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, tag := range strings.Split(match, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
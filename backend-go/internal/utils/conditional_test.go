@@ -0,0 +1,43 @@
+package utils_test
+
+import (
+	"backend-go/internal/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNotModified(t *testing.T) {
+	etag := `"abc123"`
+	modified := time.Date(2025, 3, 10, 8, 30, 15, 500, time.UTC)
+
+	cases := []struct {
+		name    string
+		headers map[string]string
+		want    bool
+	}{
+		{"no validators", nil, false},
+		{"matching etag", map[string]string{"If-None-Match": `"old", "abc123"`}, true},
+		{"weak etag", map[string]string{"If-None-Match": `W/"abc123"`}, true},
+		{"other etag wins over date", map[string]string{"If-None-Match": `"old"`, "If-Modified-Since": modified.Add(time.Hour).Format(http.TimeFormat)}, false},
+		{"same second", map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)}, true},
+		{"older copy", map[string]string{"If-Modified-Since": modified.Add(-time.Minute).Format(http.TimeFormat)}, false},
+		{"invalid date", map[string]string{"If-Modified-Since": "yesterday"}, false},
+	}
+	for _, tc := range cases {
+		r := httptest.NewRequest(http.MethodGet, "/feeds/articles.rss", nil)
+		for k, v := range tc.headers {
+			r.Header.Set(k, v)
+		}
+		if got := utils.NotModified(r, etag, modified); got != tc.want {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/feeds/articles.rss", nil)
+	r.Header.Set("If-Modified-Since", modified.Format(http.TimeFormat))
+	if utils.NotModified(r, etag, time.Time{}) {
+		t.Error("expected a response without Last-Modified to never match a date")
+	}
+}
@@ -0,0 +1,57 @@
+package utils_test
+
+import (
+	"backend-go/internal/utils"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildICalendar(t *testing.T) {
+	wib := time.FixedZone("WIB", 7*3600)
+	event := utils.ICalEvent{
+		UID:         "test-session-7@k3arafah",
+		Summary:     "Tes Tulis; Gelombang 1, Putra",
+		Description: "Bawa alat tulis\nDatang 15 menit lebih awal",
+		Location:    "Ruang 1",
+		Start:       time.Date(2025, 3, 10, 8, 0, 0, 0, wib),
+		End:         time.Date(2025, 3, 10, 10, 0, 0, 0, wib),
+	}
+
+	ics := string(utils.BuildICalendar("-//K3 Arafah//PSB//ID", []utils.ICalEvent{event}, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART:20250310T010000Z\r\n",
+		"DTEND:20250310T030000Z\r\n",
+		"DTSTAMP:20250301T000000Z\r\n",
+		`SUMMARY:Tes Tulis\; Gelombang 1\, Putra` + "\r\n",
+		`DESCRIPTION:Bawa alat tulis\nDatang 15 menit lebih awal` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("expected calendar to contain %q, got:\n%s", want, ics)
+		}
+	}
+}
+
+func TestBuildICalendar_FoldsLongLines(t *testing.T) {
+	event := utils.ICalEvent{
+		UID:     "long@k3arafah",
+		Summary: strings.Repeat("Wawancara santri baru ", 10),
+		Start:   time.Date(2025, 3, 10, 1, 0, 0, 0, time.UTC),
+		End:     time.Date(2025, 3, 10, 2, 0, 0, 0, time.UTC),
+	}
+
+	ics := string(utils.BuildICalendar("-//K3 Arafah//PSB//ID", []utils.ICalEvent{event}, time.Now()))
+
+	for _, line := range strings.Split(ics, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:"+event.Summary+"\r\n") {
+		t.Errorf("expected the folded summary to unfold to the original text, got:\n%s", unfolded)
+	}
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// FieldError is a validation problem with a single request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// nikProvinceCodes are the province codes (first two NIK digits) issued by Dukcapil
+var nikProvinceCodes = map[string]bool{
+	"11": true, "12": true, "13": true, "14": true, "15": true, "16": true, "17": true, "18": true, "19": true,
+	"21": true, "31": true, "32": true, "33": true, "34": true, "35": true, "36": true,
+	"51": true, "52": true, "53": true, "61": true, "62": true, "63": true, "64": true, "65": true,
+	"71": true, "72": true, "73": true, "74": true, "75": true, "76": true,
+	"81": true, "82": true, "91": true, "92": true, "93": true, "94": true, "95": true, "96": true, "97": true,
+}
+
+// ValidateNIK checks the structure of an Indonesian NIK and that it agrees with the birth date and gender (L/P).
+// A NIK is PPKKCC DDMMYY SSSS: province, regency and district codes, the birth date with 40 added to the
+// day for females, and a serial number. It returns one FieldError per problem, or nil if the NIK is valid.
+func ValidateNIK(nik string, birthDate time.Time, gender string) []FieldError {
+	if len(nik) != 16 {
+		return []FieldError{{Field: "nik", Message: "NIK must be 16 digits"}}
+	}
+	for _, r := range nik {
+		if r < '0' || r > '9' {
+			return []FieldError{{Field: "nik", Message: "NIK must only contain digits"}}
+		}
+	}
+
+	var errs []FieldError
+	if !nikProvinceCodes[nik[0:2]] {
+		errs = append(errs, FieldError{Field: "nik", Message: fmt.Sprintf("NIK province code %s does not exist", nik[0:2])})
+	}
+	if nik[2:4] == "00" || nik[4:6] == "00" {
+		errs = append(errs, FieldError{Field: "nik", Message: "NIK regency and district codes cannot be 00"})
+	}
+	if nik[12:16] == "0000" {
+		errs = append(errs, FieldError{Field: "nik", Message: "NIK serial number cannot be 0000"})
+	}
+
+	day, _ := strconv.Atoi(nik[6:8])
+	month, _ := strconv.Atoi(nik[8:10])
+	year, _ := strconv.Atoi(nik[10:12])
+
+	nikGender := "L"
+	if day > 40 {
+		nikGender = "P"
+		day -= 40
+	}
+	if day < 1 || day > 31 || month < 1 || month > 12 {
+		return append(errs, FieldError{Field: "nik", Message: fmt.Sprintf("NIK birth date segment %s is not a valid date", nik[6:12])})
+	}
+
+	if day != birthDate.Day() || month != int(birthDate.Month()) || year != birthDate.Year()%100 {
+		errs = append(errs, FieldError{
+			Field:   "birth_date",
+			Message: fmt.Sprintf("Birth date does not match the NIK (NIK says %02d-%02d-%02d)", day, month, year),
+		})
+	}
+	if gender != "" && nikGender != gender {
+		errs = append(errs, FieldError{
+			Field:   "gender",
+			Message: fmt.Sprintf("Gender does not match the NIK (NIK says %s)", nikGender),
+		})
+	}
+
+	return errs
+}
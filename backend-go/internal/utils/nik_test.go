@@ -0,0 +1,44 @@
+package utils_test
+
+import (
+	"backend-go/internal/utils"
+	"testing"
+	"time"
+)
+
+func TestValidateNIK(t *testing.T) {
+	birthDate := time.Date(2012, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name   string
+		nik    string
+		gender string
+		fields []string
+	}{
+		{"valid male", "3402010403120001", "L", nil},
+		{"valid female", "3402014403120002", "P", nil},
+		{"female with male day", "3402010403120001", "P", []string{"gender"}},
+		{"wrong birth date", "3402010503120001", "L", []string{"birth_date"}},
+		{"wrong birth year", "3402010403110001", "L", []string{"birth_date"}},
+		{"papua barat daya", "9701010403120001", "L", nil},
+		{"unknown province", "9902010403120001", "L", []string{"nik"}},
+		{"zero regency", "3400010403120001", "L", []string{"nik"}},
+		{"zero serial", "3402010403120000", "L", []string{"nik"}},
+		{"impossible date", "3402013513120001", "L", []string{"nik"}},
+		{"too short", "340201040312", "L", []string{"nik"}},
+		{"not numeric", "34020104031200A1", "L", []string{"nik"}},
+	}
+
+	for _, tc := range cases {
+		errs := utils.ValidateNIK(tc.nik, birthDate, tc.gender)
+		if len(errs) != len(tc.fields) {
+			t.Errorf("%s: expected %d errors, got %+v", tc.name, len(tc.fields), errs)
+			continue
+		}
+		for i, field := range tc.fields {
+			if errs[i].Field != field {
+				t.Errorf("%s: expected error on %s, got %s", tc.name, field, errs[i].Field)
+			}
+		}
+	}
+}
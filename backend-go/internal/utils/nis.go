@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// nisPlaceholder matches the placeholders of an NIS pattern: {year}, {yy}, {gender} and {seq} or {seq:N}
+var nisPlaceholder = regexp.MustCompile(`\{([a-z]+)(?::(\d+))?\}`)
+
+// ErrInvalidNISPattern is returned for NIS patterns without exactly one {seq} or with unknown placeholders
+var ErrInvalidNISPattern = errors.New("invalid NIS pattern: it needs exactly one {seq} and may only use {year}, {yy} and {gender}")
+
+// ValidateNISPattern checks that an NIS pattern can be formatted and draws from a sequence
+func ValidateNISPattern(pattern string) error {
+	seqCount := 0
+	for _, match := range nisPlaceholder.FindAllStringSubmatch(pattern, -1) {
+		switch match[1] {
+		case "seq":
+			seqCount++
+		case "year", "yy", "gender":
+			if match[2] != "" {
+				return ErrInvalidNISPattern
+			}
+		default:
+			return ErrInvalidNISPattern
+		}
+	}
+	if seqCount != 1 {
+		return ErrInvalidNISPattern
+	}
+	return nil
+}
+
+// NISSequenceScope returns the pattern with every placeholder except {seq} filled in.
+// NIS numbers with the same scope share one sequence, so "{year}{gender}{seq:4}" numbers
+// each entry year and gender from 1.
+func NISSequenceScope(pattern string, year int, gender string) string {
+	return nisPlaceholder.ReplaceAllStringFunc(pattern, func(token string) string {
+		match := nisPlaceholder.FindStringSubmatch(token)
+		if match[1] == "seq" {
+			return token
+		}
+		return nisValue(match[1], year, gender)
+	})
+}
+
+// FormatNIS fills in an NIS pattern, e.g. FormatNIS("{year}{gender}{seq:4}", 2026, "L", 7) = "2026L0007"
+func FormatNIS(pattern string, year int, gender string, seq int64) (string, error) {
+	if err := ValidateNISPattern(pattern); err != nil {
+		return "", err
+	}
+
+	return nisPlaceholder.ReplaceAllStringFunc(pattern, func(token string) string {
+		match := nisPlaceholder.FindStringSubmatch(token)
+		if match[1] != "seq" {
+			return nisValue(match[1], year, gender)
+		}
+		width, _ := strconv.Atoi(match[2])
+		return fmt.Sprintf("%0*d", width, seq)
+	}), nil
+}
+
+func nisValue(name string, year int, gender string) string {
+	switch name {
+	case "year":
+		return fmt.Sprintf("%04d", year)
+	case "yy":
+		return fmt.Sprintf("%02d", year%100)
+	case "gender":
+		return strings.ToUpper(gender)
+	}
+	return ""
+}
@@ -0,0 +1,61 @@
+package utils_test
+
+import (
+	"backend-go/internal/utils"
+	"errors"
+	"testing"
+)
+
+func TestFormatNIS(t *testing.T) {
+	cases := []struct {
+		pattern string
+		year    int
+		gender  string
+		seq     int64
+		want    string
+	}{
+		{"{year}{gender}{seq:4}", 2026, "L", 7, "2026L0007"},
+		{"{year}{gender}{seq:4}", 2026, "p", 12, "2026P0012"},
+		{"{yy}.{gender}.{seq:3}", 2026, "L", 1, "26.L.001"},
+		{"K3-{year}-{seq}", 2027, "P", 45, "K3-2027-45"},
+		{"{year}{seq:2}", 2026, "L", 123, "2026123"},
+	}
+
+	for _, tc := range cases {
+		got, err := utils.FormatNIS(tc.pattern, tc.year, tc.gender, tc.seq)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tc.pattern, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s: expected %q, got %q", tc.pattern, tc.want, got)
+		}
+	}
+}
+
+func TestFormatNIS_InvalidPattern(t *testing.T) {
+	for _, pattern := range []string{
+		"{year}{gender}",
+		"{seq}{seq:4}",
+		"{year}{class}{seq:4}",
+		"{year:2}{seq:4}",
+	} {
+		if _, err := utils.FormatNIS(pattern, 2026, "L", 1); !errors.Is(err, utils.ErrInvalidNISPattern) {
+			t.Errorf("%s: expected ErrInvalidNISPattern, got %v", pattern, err)
+		}
+	}
+}
+
+func TestNISSequenceScope(t *testing.T) {
+	male := utils.NISSequenceScope("{year}{gender}{seq:4}", 2026, "L")
+	female := utils.NISSequenceScope("{year}{gender}{seq:4}", 2026, "P")
+	if male != "2026L{seq:4}" {
+		t.Errorf("unexpected scope %q", male)
+	}
+	if male == female {
+		t.Error("expected male and female NIS to use separate sequences")
+	}
+	if utils.NISSequenceScope("{seq:5}", 2026, "L") != utils.NISSequenceScope("{seq:5}", 2027, "P") {
+		t.Error("expected a pattern without year or gender to use a single sequence")
+	}
+}
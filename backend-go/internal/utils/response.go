@@ -92,11 +92,17 @@ type PaginationMeta struct {
 }
 
 type PaginatedData struct {
-	Items interface{}    `json:"items"`
-	Meta  PaginationMeta `json:"meta"`
+	Items  interface{}      `json:"items"`
+	Meta   PaginationMeta   `json:"meta"`
+	Facets map[string]int64 `json:"facets,omitempty"` // Number of results per kind, when the listing is faceted
 }
 
 func SuccessResponsePaginated(c *gin.Context, code int, message string, items interface{}, page, limit int, total int64) {
+	SuccessResponsePaginatedWithFacets(c, code, message, items, nil, page, limit, total)
+}
+
+// SuccessResponsePaginatedWithFacets is SuccessResponsePaginated with result counts per kind, e.g. per content type
+func SuccessResponsePaginatedWithFacets(c *gin.Context, code int, message string, items interface{}, facets map[string]int64, page, limit int, total int64) {
 	totalPages := 0
 	if limit > 0 {
 		totalPages = int((total + int64(limit) - 1) / int64(limit)) // Ceiling division
@@ -110,6 +116,7 @@ func SuccessResponsePaginated(c *gin.Context, code int, message string, items in
 			TotalItems: total,
 			TotalPages: totalPages,
 		},
+		Facets: facets,
 	}
 	SuccessResponse(c, code, message, data)
 }
@@ -0,0 +1,77 @@
+package utils_test
+
+import (
+	"backend-go/internal/utils"
+	"testing"
+)
+
+func TestNormalizeName(t *testing.T) {
+	cases := map[string]string{
+		"  Muh. Rizky  AL-FATIH ": "muh rizky al fatih",
+		"Siti Nur'aini":           "siti nur aini",
+		"BANTUL":                  "bantul",
+		"":                        "",
+	}
+	for in, want := range cases {
+		if got := utils.NormalizeName(in); got != want {
+			t.Errorf("NormalizeName(%q): expected %q, got %q", in, want, got)
+		}
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	cases := map[string]string{
+		"+62 812-3456-789": "08123456789",
+		"0812 3456 789":    "08123456789",
+		"6281234567890":    "081234567890",
+		"(0274) 123456":    "0274123456",
+	}
+	for in, want := range cases {
+		if got := utils.NormalizePhone(in); got != want {
+			t.Errorf("NormalizePhone(%q): expected %q, got %q", in, want, got)
+		}
+	}
+}
+
+func TestInternationalPhone(t *testing.T) {
+	cases := map[string]string{
+		"0812-3456-789":    "628123456789",
+		"+62 812 3456 789": "628123456789",
+		"6281234567890":    "6281234567890",
+	}
+	for in, want := range cases {
+		if got := utils.InternationalPhone(in); got != want {
+			t.Errorf("InternationalPhone(%q): expected %q, got %q", in, want, got)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"ahmad", "achmad", 1},
+		{"3402011234567890", "3402011234567809", 2},
+		{"kitten", "sitting", 3},
+	}
+	for _, tc := range cases {
+		if got := utils.Levenshtein(tc.a, tc.b); got != tc.want {
+			t.Errorf("Levenshtein(%q, %q): expected %d, got %d", tc.a, tc.b, tc.want, got)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	if got := utils.Similarity("ahmad fauzi", "ahmad fauzi"); got != 1 {
+		t.Errorf("expected equal strings to have similarity 1, got %v", got)
+	}
+	if got := utils.Similarity("ahmad fauzi", "achmad fauzi"); got < 0.9 {
+		t.Errorf("expected a one-letter spelling variant to be similar, got %v", got)
+	}
+	if got := utils.Similarity("ahmad fauzi", "siti aminah"); got > 0.5 {
+		t.Errorf("expected different names to be dissimilar, got %v", got)
+	}
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// Operations of a DiffSegment
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
+
+// DiffSegment is a run of text that is unchanged, inserted or deleted between two versions
+type DiffSegment struct {
+	Op   string `json:"op" example:"insert"`
+	Text string `json:"text" example:"santri baru"`
+}
+
+// WordDiff compares two texts word by word, so a changed word is reported whole instead of as the characters that
+// differ. Whitespace and punctuation are tokens of their own, and joining the texts of the equal and delete segments
+// gives back before, of the equal and insert segments gives back after.
+func WordDiff(before, after string) []DiffSegment {
+	// Every distinct token becomes one rune, so the character diff works on whole tokens
+	var tokens []string
+	index := make(map[string]rune)
+	encode := func(text string) []rune {
+		words := splitWords(text)
+		runes := make([]rune, len(words))
+		for i, word := range words {
+			r, ok := index[word]
+			if !ok {
+				r = tokenRune(len(tokens))
+				index[word] = r
+				tokens = append(tokens, word)
+			}
+			runes[i] = r
+		}
+		return runes
+	}
+	decode := make(map[rune]string)
+	a, b := encode(before), encode(after)
+	for i, token := range tokens {
+		decode[tokenRune(i)] = token
+	}
+
+	dmp := diffmatchpatch.New()
+	diffs := dmp.DiffMainRunes(a, b, false)
+
+	segments := make([]DiffSegment, 0, len(diffs))
+	for _, d := range diffs {
+		var text strings.Builder
+		for _, r := range d.Text {
+			text.WriteString(decode[r])
+		}
+
+		op := DiffEqual
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			op = DiffInsert
+		case diffmatchpatch.DiffDelete:
+			op = DiffDelete
+		}
+		segments = append(segments, DiffSegment{Op: op, Text: text.String()})
+	}
+	return segments
+}
+
+// splitWords cuts text into runs of letters and digits, runs of whitespace and single other characters
+func splitWords(text string) []string {
+	var words []string
+	runes := []rune(text)
+	for start := 0; start < len(runes); {
+		end := start + 1
+		switch {
+		case isWordRune(runes[start]):
+			for end < len(runes) && isWordRune(runes[end]) {
+				end++
+			}
+		case unicode.IsSpace(runes[start]):
+			for end < len(runes) && unicode.IsSpace(runes[end]) {
+				end++
+			}
+		}
+		words = append(words, string(runes[start:end]))
+		start = end
+	}
+	return words
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// tokenRune maps a token index to a rune that survives the diff's conversion to a string, skipping the surrogates
+func tokenRune(i int) rune {
+	r := rune(i + 1)
+	if r >= 0xD800 {
+		r += 0x800
+	}
+	return r
+}
@@ -0,0 +1,46 @@
+package utils_test
+
+import (
+	"backend-go/internal/utils"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWordDiff(t *testing.T) {
+	before := "Pendaftaran santri baru dibuka hari Senin."
+	after := "Pendaftaran santri putri dibuka hari Senin dan Selasa."
+
+	got := utils.WordDiff(before, after)
+	want := []utils.DiffSegment{
+		{Op: utils.DiffEqual, Text: "Pendaftaran santri "},
+		{Op: utils.DiffDelete, Text: "baru"},
+		{Op: utils.DiffInsert, Text: "putri"},
+		{Op: utils.DiffEqual, Text: " dibuka hari Senin"},
+		{Op: utils.DiffInsert, Text: " dan Selasa"},
+		{Op: utils.DiffEqual, Text: "."},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	var oldText, newText strings.Builder
+	for _, segment := range got {
+		if segment.Op != utils.DiffInsert {
+			oldText.WriteString(segment.Text)
+		}
+		if segment.Op != utils.DiffDelete {
+			newText.WriteString(segment.Text)
+		}
+	}
+	if oldText.String() != before || newText.String() != after {
+		t.Errorf("expected the segments to rebuild both texts, got %q and %q", oldText.String(), newText.String())
+	}
+
+	if got := utils.WordDiff("sama", "sama"); len(got) != 1 || got[0].Op != utils.DiffEqual {
+		t.Errorf("expected identical texts to be one equal segment, got %+v", got)
+	}
+	if got := utils.WordDiff("", ""); len(got) != 0 {
+		t.Errorf("expected no segments for empty texts, got %+v", got)
+	}
+}
@@ -0,0 +1,12 @@
+-- Create santri_status_history table (audit trail of PSB status transitions)
+CREATE TABLE IF NOT EXISTS santri_status_history (
+    id SERIAL PRIMARY KEY,
+    santri_id INTEGER NOT NULL REFERENCES santris(id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    actor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_santri_status_history_santri ON santri_status_history(santri_id, created_at DESC);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_santris_wave_id;
+ALTER TABLE santris DROP COLUMN IF EXISTS wave_id;
+
+DROP TABLE IF EXISTS admission_waves;
@@ -0,0 +1,24 @@
+-- Create admission_waves table (PSB intake periods / gelombang)
+CREATE TABLE IF NOT EXISTS admission_waves (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    academic_year VARCHAR(9) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    quota_male INTEGER NOT NULL DEFAULT 0,
+    quota_female INTEGER NOT NULL DEFAULT 0,
+    registration_fee BIGINT NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT chk_admission_waves_dates CHECK (end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_admission_waves_deleted_at ON admission_waves(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_admission_waves_academic_year ON admission_waves(academic_year);
+CREATE INDEX IF NOT EXISTS idx_admission_waves_dates ON admission_waves(start_date, end_date);
+
+-- Link registrants to the wave they applied in
+ALTER TABLE santris ADD COLUMN IF NOT EXISTS wave_id INTEGER REFERENCES admission_waves(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_santris_wave_id ON santris(wave_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS santri_prior_educations;
+DROP TABLE IF EXISTS santri_guardians;
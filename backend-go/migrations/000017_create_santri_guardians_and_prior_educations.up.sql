@@ -0,0 +1,32 @@
+-- Guardians (father/mother) submitted on the PSB form
+CREATE TABLE IF NOT EXISTS santri_guardians (
+    id SERIAL PRIMARY KEY,
+    santri_id INTEGER NOT NULL REFERENCES santris(id) ON DELETE CASCADE,
+    relation VARCHAR(10) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    job VARCHAR(100),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_santri_guardians_relation ON santri_guardians(santri_id, relation);
+
+-- School the santri attended before applying
+CREATE TABLE IF NOT EXISTS santri_prior_educations (
+    id SERIAL PRIMARY KEY,
+    santri_id INTEGER NOT NULL REFERENCES santris(id) ON DELETE CASCADE,
+    school_name VARCHAR(100) NOT NULL,
+    school_address TEXT,
+    graduation_year INTEGER,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_santri_prior_educations_santri_id ON santri_prior_educations(santri_id);
+
+-- Existing registrants only kept the father's name (as parent_name)
+INSERT INTO santri_guardians (santri_id, relation, name)
+SELECT id, 'FATHER', parent_name
+FROM santris
+WHERE parent_name IS NOT NULL AND parent_name <> ''
+ON CONFLICT (santri_id, relation) DO NOTHING;
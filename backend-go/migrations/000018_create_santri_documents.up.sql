@@ -0,0 +1,18 @@
+-- Supporting documents uploaded by PSB registrants (KK, akta kelahiran, ijazah)
+CREATE TABLE IF NOT EXISTS santri_documents (
+    id SERIAL PRIMARY KEY,
+    santri_id INTEGER NOT NULL REFERENCES santris(id) ON DELETE CASCADE,
+    type VARCHAR(30) NOT NULL,
+    file_url TEXT NOT NULL,
+    file_name VARCHAR(255),
+    mime_type VARCHAR(100),
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    note TEXT,
+    verified_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    verified_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_santri_documents_type ON santri_documents(santri_id, type);
+CREATE INDEX IF NOT EXISTS idx_santri_documents_status ON santri_documents(status);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_santris_registration_code;
+ALTER TABLE santris DROP COLUMN IF EXISTS registration_code;
@@ -0,0 +1,9 @@
+-- Non-sequential registration code used by parents to look up their registration
+ALTER TABLE santris ADD COLUMN IF NOT EXISTS registration_code VARCHAR(20);
+
+-- Backfill existing registrants with a random code
+UPDATE santris
+SET registration_code = 'PSB-' || upper(substr(md5(random()::text || id::text), 1, 8))
+WHERE registration_code IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_santris_registration_code ON santris(registration_code);
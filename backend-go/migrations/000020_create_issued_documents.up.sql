@@ -0,0 +1,18 @@
+-- Generated PSB documents and the signed tokens printed in their QR codes
+CREATE TABLE IF NOT EXISTS issued_documents (
+    id SERIAL PRIMARY KEY,
+    santri_id INTEGER NOT NULL REFERENCES santris(id) ON DELETE CASCADE,
+    document_type VARCHAR(30) NOT NULL,
+    token VARCHAR(100) NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    revoked_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    revoke_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_issued_documents_token ON issued_documents(token);
+CREATE INDEX IF NOT EXISTS idx_issued_documents_santri_id ON issued_documents(santri_id);
+
+-- Only one active (not revoked) document per santri and type
+CREATE UNIQUE INDEX IF NOT EXISTS idx_issued_documents_active
+    ON issued_documents(santri_id, document_type) WHERE revoked_at IS NULL;
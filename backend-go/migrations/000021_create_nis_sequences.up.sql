@@ -0,0 +1,7 @@
+-- Counters for generated NIS numbers. The scope is the NIS pattern with everything but the
+-- sequence filled in (e.g. '2026L{seq:4}'), so each entry year and gender is numbered separately.
+CREATE TABLE IF NOT EXISTS nis_sequences (
+    scope VARCHAR(100) PRIMARY KEY,
+    last_value BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,13 @@
+-- Audit trail of duplicate registrations merged into another registration
+CREATE TABLE IF NOT EXISTS santri_merges (
+    id SERIAL PRIMARY KEY,
+    primary_id INTEGER NOT NULL REFERENCES santris(id) ON DELETE CASCADE,
+    merged_id INTEGER NOT NULL REFERENCES santris(id) ON DELETE CASCADE,
+    merged_code VARCHAR(20),
+    merged_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    snapshot JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_santri_merges_primary_id ON santri_merges(primary_id);
+CREATE INDEX IF NOT EXISTS idx_santri_merges_merged_id ON santri_merges(merged_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS selection_scores;
+DROP TABLE IF EXISTS selection_components;
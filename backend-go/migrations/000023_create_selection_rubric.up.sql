@@ -0,0 +1,26 @@
+-- Weighted selection rubric per admission wave
+CREATE TABLE IF NOT EXISTS selection_components (
+    id SERIAL PRIMARY KEY,
+    wave_id INTEGER NOT NULL REFERENCES admission_waves(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    weight INTEGER NOT NULL CHECK (weight > 0 AND weight <= 100),
+    max_score NUMERIC(6,2) NOT NULL DEFAULT 100 CHECK (max_score > 0),
+    sort_order INTEGER DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_selection_components_wave_id ON selection_components(wave_id);
+
+-- Scores entered by admins for each registrant and rubric component
+CREATE TABLE IF NOT EXISTS selection_scores (
+    id SERIAL PRIMARY KEY,
+    santri_id INTEGER NOT NULL REFERENCES santris(id) ON DELETE CASCADE,
+    component_id INTEGER NOT NULL REFERENCES selection_components(id) ON DELETE CASCADE,
+    score NUMERIC(6,2) NOT NULL CHECK (score >= 0),
+    scored_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_selection_scores_component ON selection_scores(santri_id, component_id);
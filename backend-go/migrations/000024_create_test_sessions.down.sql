@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS test_session_assignments;
+DROP TABLE IF EXISTS test_sessions;
+ALTER TABLE santris DROP COLUMN IF EXISTS parent_email;
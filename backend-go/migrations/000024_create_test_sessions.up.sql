@@ -0,0 +1,38 @@
+-- Parent email address for schedule reminders
+ALTER TABLE santris ADD COLUMN IF NOT EXISTS parent_email VARCHAR(100);
+
+-- Entrance test and interview sessions per admission wave
+CREATE TABLE IF NOT EXISTS test_sessions (
+    id SERIAL PRIMARY KEY,
+    wave_id INTEGER NOT NULL REFERENCES admission_waves(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('TEST', 'INTERVIEW')),
+    name VARCHAR(100) NOT NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    room VARCHAR(100) NOT NULL,
+    capacity INTEGER NOT NULL CHECK (capacity > 0),
+    gender VARCHAR(1) DEFAULT '' CHECK (gender IN ('', 'L', 'P')),
+    examiner VARCHAR(100),
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_test_sessions_wave_id ON test_sessions(wave_id);
+CREATE INDEX IF NOT EXISTS idx_test_sessions_starts_at ON test_sessions(starts_at);
+CREATE INDEX IF NOT EXISTS idx_test_sessions_deleted_at ON test_sessions(deleted_at);
+
+-- Registrants placed in a session, at most one session of each kind per registrant
+CREATE TABLE IF NOT EXISTS test_session_assignments (
+    id SERIAL PRIMARY KEY,
+    session_id INTEGER NOT NULL REFERENCES test_sessions(id) ON DELETE CASCADE,
+    santri_id INTEGER NOT NULL REFERENCES santris(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    reminder_sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_test_session_assignments_session_id ON test_session_assignments(session_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_test_session_assignments_kind ON test_session_assignments(santri_id, kind);
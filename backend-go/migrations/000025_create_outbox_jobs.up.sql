@@ -0,0 +1,20 @@
+-- Background jobs written in the same transaction as the change that caused them
+CREATE TABLE IF NOT EXISTS outbox_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'PROCESSING', 'DONE', 'DEAD')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 8,
+    available_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    processed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_jobs_type ON outbox_jobs(type);
+CREATE INDEX IF NOT EXISTS idx_outbox_jobs_status ON outbox_jobs(status);
+-- Workers only scan jobs that still have to run
+CREATE INDEX IF NOT EXISTS idx_outbox_jobs_ready ON outbox_jobs(available_at) WHERE status IN ('PENDING', 'PROCESSING');
@@ -0,0 +1,17 @@
+-- Preferred language for messages to the registrant's parents
+ALTER TABLE santris ADD COLUMN IF NOT EXISTS locale VARCHAR(5) NOT NULL DEFAULT 'id';
+
+-- Admin overrides of the built-in notification templates
+CREATE TABLE IF NOT EXISTS notification_templates (
+    id SERIAL PRIMARY KEY,
+    event VARCHAR(50) NOT NULL,
+    locale VARCHAR(5) NOT NULL CHECK (locale IN ('id', 'en')),
+    subject TEXT NOT NULL,
+    text_body TEXT NOT NULL,
+    html_body TEXT NOT NULL,
+    updated_by_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_templates_event_locale ON notification_templates(event, locale);
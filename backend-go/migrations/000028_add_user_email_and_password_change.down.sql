@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS must_change_password;
+DROP INDEX IF EXISTS idx_users_email;
+ALTER TABLE users DROP COLUMN IF EXISTS email;
@@ -0,0 +1,6 @@
+-- Email for password reset links and account notices
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email VARCHAR(255) NOT NULL DEFAULT '';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users(LOWER(email)) WHERE email <> '' AND deleted_at IS NULL;
+
+-- Set when an admin received a password from someone else and has to choose their own
+ALTER TABLE users ADD COLUMN IF NOT EXISTS must_change_password BOOLEAN NOT NULL DEFAULT FALSE;
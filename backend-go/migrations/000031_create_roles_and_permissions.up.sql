@@ -0,0 +1,67 @@
+-- Roles are named sets of permissions. users.role holds the role name.
+CREATE TABLE IF NOT EXISTS roles (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    is_system BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- The permissions checked by the API. New ones are added by migrations, together with their grant to super_admin.
+CREATE TABLE IF NOT EXISTS permissions (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    description VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id INTEGER NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission_id INTEGER NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+INSERT INTO permissions (code, description) VALUES
+    ('psb.read', 'View registrants, including NIK, their documents and issued documents'),
+    ('psb.update', 'Edit registrants, scores and test session assignments, and merge duplicates'),
+    ('psb.verify', 'Verify registrants and documents, and revoke issued documents'),
+    ('psb.delete', 'Delete registrants'),
+    ('psb.manage', 'Manage admission waves, rubrics, selection runs, test sessions and notification templates'),
+    ('psb.export', 'Export registrant data'),
+    ('article.write', 'Write articles and manage categories and tags'),
+    ('article.publish', 'Publish and unpublish articles'),
+    ('article.delete', 'Delete articles'),
+    ('media.manage', 'Upload files and manage galleries, videos and achievements'),
+    ('message.read', 'Read contact messages'),
+    ('message.delete', 'Delete contact messages'),
+    ('dashboard.read', 'View dashboard statistics'),
+    ('admin.manage', 'Manage admin accounts and roles'),
+    ('activity.read', 'View activity logs'),
+    ('system.manage', 'Manage background jobs and media storage')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO roles (name, description, is_system) VALUES
+    ('super_admin', 'Full access', TRUE),
+    ('admin', 'Content and admissions, without admin management', TRUE)
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'super_admin'
+ON CONFLICT DO NOTHING;
+
+-- Admins keep what they could do before roles existed
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'admin' AND p.code NOT IN ('psb.export', 'admin.manage', 'activity.read', 'system.manage')
+ON CONFLICT DO NOTHING;
+
+-- Any other role names already in use become roles without permissions
+INSERT INTO roles (name)
+SELECT DISTINCT role FROM users WHERE role IS NOT NULL
+ON CONFLICT (name) DO NOTHING;
+
+UPDATE users SET role = 'admin' WHERE role IS NULL;
+ALTER TABLE users ALTER COLUMN role TYPE VARCHAR(50);
+ALTER TABLE users ALTER COLUMN role SET NOT NULL;
+ALTER TABLE users ADD CONSTRAINT fk_users_role FOREIGN KEY (role) REFERENCES roles(name) ON UPDATE CASCADE ON DELETE SET DEFAULT;
@@ -0,0 +1,20 @@
+-- Every saved version of an article, numbered per article
+CREATE TABLE IF NOT EXISTS article_revisions (
+    id SERIAL PRIMARY KEY,
+    article_id INTEGER NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    content TEXT NOT NULL DEFAULT '',
+    thumbnail_url VARCHAR(255) NOT NULL DEFAULT '',
+    is_published BOOLEAN NOT NULL DEFAULT FALSE,
+    editor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    restored_from INTEGER,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (article_id, revision)
+);
+
+-- The current state of existing articles becomes their first revision
+INSERT INTO article_revisions (article_id, revision, title, content, thumbnail_url, is_published, editor_id, created_at)
+SELECT id, 1, title, COALESCE(content, ''), COALESCE(thumbnail_url, ''), COALESCE(is_published, FALSE), author_id, COALESCE(updated_at, created_at, CURRENT_TIMESTAMP)
+FROM articles
+ON CONFLICT (article_id, revision) DO NOTHING;
@@ -0,0 +1,5 @@
+ALTER TABLE article_revisions DROP COLUMN IF EXISTS status;
+
+DROP INDEX IF EXISTS idx_articles_status_publish_at;
+ALTER TABLE articles DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE articles DROP COLUMN IF EXISTS status;
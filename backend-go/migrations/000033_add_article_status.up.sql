@@ -0,0 +1,11 @@
+-- Editorial workflow state of an article. is_published is kept in sync with status = 'published'.
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'draft'
+    CHECK (status IN ('draft', 'in_review', 'scheduled', 'published', 'archived'));
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE articles SET status = 'published', publish_at = COALESCE(publish_at, created_at) WHERE is_published = TRUE;
+
+CREATE INDEX IF NOT EXISTS idx_articles_status_publish_at ON articles (status, publish_at);
+
+ALTER TABLE article_revisions ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'draft';
+UPDATE article_revisions SET status = 'published' WHERE is_published = TRUE;
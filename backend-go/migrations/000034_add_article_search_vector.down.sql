@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_articles_search_vector;
+DROP TRIGGER IF EXISTS trg_articles_search_vector ON articles;
+DROP FUNCTION IF EXISTS articles_search_vector_update();
+ALTER TABLE articles DROP COLUMN IF EXISTS search_vector;
+DROP FUNCTION IF EXISTS article_search_document(TEXT, TEXT);
+DROP FUNCTION IF EXISTS article_plain_text(TEXT);
+DROP TEXT SEARCH CONFIGURATION IF EXISTS article_search;
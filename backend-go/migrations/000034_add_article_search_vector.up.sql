@@ -0,0 +1,42 @@
+-- Full-text search over articles. Searches use the article_search configuration, a copy of the Indonesian
+-- configuration where the server ships it and of the simple one elsewhere.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'article_search') THEN
+        IF EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'indonesian') THEN
+            CREATE TEXT SEARCH CONFIGURATION article_search (COPY = pg_catalog.indonesian);
+        ELSE
+            CREATE TEXT SEARCH CONFIGURATION article_search (COPY = pg_catalog.simple);
+        END IF;
+    END IF;
+END
+$$;
+
+-- The text a reader sees: markup and entities removed
+CREATE OR REPLACE FUNCTION article_plain_text(content TEXT) RETURNS TEXT AS $$
+    SELECT regexp_replace(regexp_replace(COALESCE(content, ''), '<[^>]*>', ' ', 'g'), '&[a-zA-Z0-9#]+;', ' ', 'g')
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Matches in the title rank above matches in the content
+CREATE OR REPLACE FUNCTION article_search_document(title TEXT, content TEXT) RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('article_search', COALESCE(title, '')), 'A') ||
+           setweight(to_tsvector('article_search', article_plain_text(content)), 'B')
+$$ LANGUAGE sql IMMUTABLE;
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+CREATE OR REPLACE FUNCTION articles_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := article_search_document(NEW.title, NEW.content);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_articles_search_vector ON articles;
+CREATE TRIGGER trg_articles_search_vector
+    BEFORE INSERT OR UPDATE OF title, content ON articles
+    FOR EACH ROW EXECUTE FUNCTION articles_search_vector_update();
+
+UPDATE articles SET search_vector = article_search_document(title, content);
+
+CREATE INDEX IF NOT EXISTS idx_articles_search_vector ON articles USING GIN (search_vector);
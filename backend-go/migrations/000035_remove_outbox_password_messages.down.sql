@@ -0,0 +1,2 @@
+-- The removed messages held secrets and are not restored
+SELECT 1;
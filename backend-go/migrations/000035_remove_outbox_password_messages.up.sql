@@ -0,0 +1,17 @@
+-- Welcome and password reset emails used to be queued fully rendered, with the temporary password or the reset link
+-- in the payload. They are now rendered when sent; remove the old jobs so the outbox no longer exposes those secrets.
+UPDATE notification_deliveries d
+SET status = 'FAILED', last_error = 'Discarded: the queued message contained a password or reset link', updated_at = CURRENT_TIMESTAMP
+FROM outbox_jobs j
+WHERE j.type = 'notification.deliver'
+  AND (j.payload->>'delivery_id')::BIGINT = d.id
+  AND d.event IN ('admin.welcome', 'auth.password_reset')
+  AND NOT (j.payload ? 'password_link')
+  AND d.status <> 'SENT';
+
+DELETE FROM outbox_jobs j
+USING notification_deliveries d
+WHERE j.type = 'notification.deliver'
+  AND (j.payload->>'delivery_id')::BIGINT = d.id
+  AND d.event IN ('admin.welcome', 'auth.password_reset')
+  AND NOT (j.payload ? 'password_link');
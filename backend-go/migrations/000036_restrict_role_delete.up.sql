@@ -0,0 +1,4 @@
+-- Deleting a role used to move its users to the default role ('admin'), which could silently grant them permissions.
+-- Refuse the delete instead while any user, including soft-deleted ones, still holds the role.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS fk_users_role;
+ALTER TABLE users ADD CONSTRAINT fk_users_role FOREIGN KEY (role) REFERENCES roles(name) ON UPDATE CASCADE ON DELETE RESTRICT;
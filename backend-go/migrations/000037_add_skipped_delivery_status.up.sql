@@ -0,0 +1,3 @@
+-- Deliveries on a channel without a configured provider are recorded as SKIPPED instead of SENT
+ALTER TABLE notification_deliveries DROP CONSTRAINT IF EXISTS notification_deliveries_status_check;
+ALTER TABLE notification_deliveries ADD CONSTRAINT notification_deliveries_status_check CHECK (status IN ('PENDING', 'SENT', 'FAILED', 'SKIPPED'));
@@ -0,0 +1,11 @@
+package templates
+
+import "embed"
+
+// TemplateFS holds the customizable document templates (letterhead and letter texts) and the default
+// notification templates. Edit the files under psb/ to change what appears on generated PDFs.
+// Notifications are named notifications/<locale>/<event>.<subject|text|html>.tmpl and can be
+// overridden per event and locale by admins.
+//
+//go:embed psb/* notifications/*
+var TemplateFS embed.FS